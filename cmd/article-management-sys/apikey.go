@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const usage = `usage: article-management-sys <apikey|authors|db> <command> [flags]
+
+apikey commands:
+  create -name <name> [-scopes a,b] [-expires-in 720h]
+  list
+  rotate -id <id> [-expires-in 720h]
+  revoke -id <id>
+
+authors commands:
+  migrate
+
+db commands:
+  migrate`
+
+// runCommand runs an administrative subcommand and returns the process exit code
+func runCommand(svcCfg *config.SvcConfig, args []string) int {
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "apikey":
+		return runApiKeyCommand(svcCfg, args)
+	case "authors":
+		return runAuthorCommand(svcCfg, args)
+	case "db":
+		return runDbCommand(svcCfg, args)
+	}
+	fmt.Fprintln(os.Stderr, usage)
+	return 2
+}
+
+// runApiKeyCommand manages api keys
+func runApiKeyCommand(svcCfg *config.SvcConfig, args []string) int {
+	keys := logic.NewApiKeyLogicI(datasource.NewApiKeySql(svcCfg.DbSvc, svcCfg.Cfg.DataBase.ApiKeyTable()))
+	fs := flag.NewFlagSet("apikey "+args[1], flag.ContinueOnError)
+	name := fs.String("name", "", "name of the key")
+	scopes := fs.String("scopes", "", "comma separated scopes, role:<name> grants a role")
+	expiresIn := fs.String("expires-in", "", "lifetime of the key such as 720h, never expires when empty and a rotated key keeps its expiry")
+	id := fs.String("id", "", "id of the key")
+	if err := fs.Parse(args[2:]); err != nil {
+		return 2
+	}
+
+	var resp *model.Response
+	switch args[1] {
+	case "create":
+		req := &model.ApiKeyRequest{Name: strings.TrimSpace(*name), ExpiresIn: *expiresIn}
+		if req.Name == "" {
+			fmt.Fprintln(os.Stderr, "-name is required")
+			return 2
+		}
+		if *scopes != "" {
+			req.Scopes = strings.Split(*scopes, ",")
+		}
+		resp = keys.CreateApiKey(req)
+	case "list":
+		resp = keys.ListApiKeys()
+	case "rotate", "revoke":
+		if *id == "" {
+			fmt.Fprintln(os.Stderr, "-id is required")
+			return 2
+		}
+		if args[1] == "rotate" {
+			resp = keys.RotateApiKey(*id, &model.ApiKeyRotateRequest{ExpiresIn: *expiresIn})
+		} else {
+			resp = keys.RevokeApiKey(*id)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	return printResponse(resp)
+}
+
+// printResponse writes resp to stdout and returns the exit code matching its status
+func printResponse(resp *model.Response) int {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(resp)
+	if resp.Status >= http.StatusBadRequest {
+		return 1
+	}
+	return 0
+}
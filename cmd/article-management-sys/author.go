@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"os"
+)
+
+// runAuthorCommand manages author profiles. migrate links the articles written before author profiles existed
+// to a profile and is safe to run more than once.
+func runAuthorCommand(svcCfg *config.SvcConfig, args []string) int {
+	if args[1] != "migrate" {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	db := svcCfg.Cfg.DataBase
+	authors := datasource.NewAuthorSql(svcCfg.DbSvc, db.AuthorTable(), db.TableName)
+	articles := datasource.NewSql(svcCfg.DbSvc, db)
+	return printResponse(logic.NewAuthorLogicI(authors, articles).MigrateAuthors())
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"log"
+	"net/http"
+	"os"
+)
+
+// runDbCommand manages the database schema. migrate upgrades a database created by an older release and is
+// safe to run more than once.
+func runDbCommand(svcCfg *config.SvcConfig, args []string) int {
+	if args[1] != "migrate" {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	applied, err := datasource.NewSchemaSql(svcCfg.DbSvc, svcCfg.Cfg.DataBase).Migrate()
+	if err != nil {
+		log.Print(codes.GetErr(codes.ErrDataSource), err)
+		return printResponse(&model.Response{
+			Status:  http.StatusInternalServerError,
+			Message: codes.GetErr(codes.ErrDataSource),
+			Data:    map[string]interface{}{"applied": applied, "error": err.Error()},
+		})
+	}
+	return printResponse(&model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]interface{}{"applied": applied},
+	})
+}
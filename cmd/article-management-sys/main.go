@@ -16,6 +16,9 @@ func main() {
 		os.Exit(1)
 	}
 	svcInitCfg := config.InitSvcConfig(cfg)
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(svcInitCfg, os.Args[1:]))
+	}
 	r := router.Register(svcInitCfg)
 	log.Println("started server on port 8080")
 	http.ListenAndServe(":8080", r)
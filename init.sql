@@ -5,6 +5,128 @@ CREATE TABLE articleTable (
                          id VARCHAR(255) NOT NULL PRIMARY KEY,
                          title VARCHAR(255) NOT NULL,
                          author VARCHAR(255) NOT NULL,
+                         author_id VARCHAR(255) NOT NULL DEFAULT '',
                          content TEXT NOT NULL,
-                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+                         content_format VARCHAR(16) NOT NULL DEFAULT 'plain',
+                         content_html MEDIUMTEXT NULL,
+                         locale VARCHAR(35) NOT NULL DEFAULT 'en',
+                         status VARCHAR(32) NOT NULL DEFAULT 'draft',
+                         version INT NOT NULL DEFAULT 1,
+                         category_id VARCHAR(255) NULL,
+                         slug VARCHAR(255) NULL UNIQUE,
+                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+                         publish_at TIMESTAMP NULL,
+                         unpublish_at TIMESTAMP NULL
+);
+
+CREATE TABLE api_keys (
+                         id VARCHAR(255) NOT NULL PRIMARY KEY,
+                         name VARCHAR(255) NOT NULL,
+                         prefix VARCHAR(32) NOT NULL,
+                         key_hash CHAR(64) NOT NULL UNIQUE,
+                         scopes VARCHAR(1024) NOT NULL DEFAULT '',
+                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         last_used_at TIMESTAMP NULL,
+                         expires_at TIMESTAMP NULL,
+                         revoked_at TIMESTAMP NULL
+);
+
+CREATE TABLE scheduler_leases (
+                         name VARCHAR(255) NOT NULL PRIMARY KEY,
+                         holder VARCHAR(255) NOT NULL,
+                         expires_at TIMESTAMP(3) NOT NULL
+);
+
+CREATE TABLE article_revisions (
+                         article_id VARCHAR(255) NOT NULL,
+                         rev INT NOT NULL,
+                         title VARCHAR(255) NOT NULL,
+                         content TEXT NOT NULL,
+                         content_format VARCHAR(16) NOT NULL DEFAULT 'plain',
+                         author VARCHAR(255) NOT NULL,
+                         editor VARCHAR(255) NOT NULL DEFAULT '',
+                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         PRIMARY KEY (article_id, rev)
+);
+
+CREATE TABLE article_tags (
+                         article_id VARCHAR(255) NOT NULL,
+                         tag VARCHAR(64) NOT NULL,
+                         PRIMARY KEY (article_id, tag),
+                         INDEX (tag)
+);
+
+CREATE TABLE categories (
+                         id VARCHAR(255) NOT NULL PRIMARY KEY,
+                         name VARCHAR(255) NOT NULL,
+                         parent_id VARCHAR(255) NULL,
+                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+                         INDEX (parent_id)
+);
+
+CREATE TABLE authors (
+                         id VARCHAR(255) NOT NULL PRIMARY KEY,
+                         name VARCHAR(255) NOT NULL,
+                         bio TEXT NOT NULL,
+                         avatar_url VARCHAR(1024) NOT NULL DEFAULT '',
+                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+                         INDEX (name)
+);
+
+CREATE TABLE article_slugs (
+                         slug VARCHAR(255) NOT NULL PRIMARY KEY,
+                         article_id VARCHAR(255) NOT NULL,
+                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         INDEX (article_id)
+);
+
+CREATE TABLE comments (
+                         id VARCHAR(255) NOT NULL PRIMARY KEY,
+                         article_id VARCHAR(255) NOT NULL,
+                         parent_id VARCHAR(255) NULL,
+                         author_id VARCHAR(255) NOT NULL,
+                         author VARCHAR(255) NOT NULL,
+                         body TEXT NOT NULL,
+                         status VARCHAR(32) NOT NULL DEFAULT 'pending',
+                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+                         INDEX (article_id, status),
+                         INDEX (parent_id),
+                         INDEX (status)
+);
+
+CREATE TABLE article_views (
+                         article_id VARCHAR(255) NOT NULL PRIMARY KEY,
+                         views BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE media (
+                         id VARCHAR(255) NOT NULL PRIMARY KEY,
+                         article_id VARCHAR(255) NOT NULL,
+                         filename VARCHAR(255) NOT NULL,
+                         content_type VARCHAR(127) NOT NULL,
+                         size BIGINT NOT NULL,
+                         width INT NOT NULL DEFAULT 0,
+                         height INT NOT NULL DEFAULT 0,
+                         checksum CHAR(64) NOT NULL,
+                         storage_key VARCHAR(512) NOT NULL,
+                         thumbnail_key VARCHAR(512) NOT NULL DEFAULT '',
+                         uploader VARCHAR(255) NOT NULL,
+                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         INDEX (article_id, created_at)
+);
+
+CREATE TABLE article_translations (
+                         article_id VARCHAR(255) NOT NULL,
+                         locale VARCHAR(35) NOT NULL,
+                         title VARCHAR(255) NOT NULL,
+                         content TEXT NOT NULL,
+                         content_format VARCHAR(16) NOT NULL DEFAULT 'plain',
+                         content_html MEDIUMTEXT NOT NULL,
+                         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                         updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+                         PRIMARY KEY (article_id, locale)
 );
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"log"
+	"strings"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_apikey_verifier.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/auth ApiKeyVerifierI
+
+// ApiKeyVerifierI resolves the caller identified by an X-API-Key header
+type ApiKeyVerifierI interface {
+	Verify(key string) (*model.Identity, error)
+}
+
+const apiKeyPrefix = "ams"
+
+const rolePrefix = "role:"
+
+// lastUsedResolution bounds how often last_used_at is written for a busy key
+const lastUsedResolution = time.Minute
+
+var ErrInvalidApiKey = errors.New("invalid api key")
+
+type apiKeyVerifier struct {
+	ds  datasource.ApiKeyDataSourceI
+	now func() time.Time
+}
+
+func NewApiKeyVerifier(ds datasource.ApiKeyDataSourceI) ApiKeyVerifierI {
+	return &apiKeyVerifier{ds: ds, now: time.Now}
+}
+
+// GenerateApiKey returns a new random key of the form ams_<prefix>_<secret>, its lookup prefix and its hash
+func GenerateApiKey() (key string, prefix string, hash string, err error) {
+	b := make([]byte, 30)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	random := base64.RawURLEncoding.EncodeToString(b)
+	prefix = apiKeyPrefix + "_" + random[:8]
+	key = prefix + "_" + random[8:]
+	return key, prefix, HashApiKey(key), nil
+}
+
+// HashApiKey returns the hex sha256 under which a key is stored
+func HashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func (v apiKeyVerifier) Verify(key string) (*model.Identity, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix+"_") {
+		return nil, ErrInvalidApiKey
+	}
+	keys, err := v.ds.GetApiKeys(map[string]interface{}{"key_hash": HashApiKey(key)})
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, ErrInvalidApiKey
+	}
+	k := keys[0]
+	now := v.now()
+	if k.RevokedAt != nil || (k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)) {
+		return nil, ErrInvalidApiKey
+	}
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= lastUsedResolution {
+		if err = v.ds.UpdateApiKey(k.Id, map[string]interface{}{"last_used_at": now}); err != nil {
+			log.Print(err)
+		}
+	}
+	identity := &model.Identity{
+		Subject: "apikey:" + k.Id,
+		Name:    k.Name,
+		Scopes:  k.Scopes,
+		Method:  "api_key",
+	}
+	// scopes of the form role:<name> grant the key that role
+	for _, scope := range k.Scopes {
+		if strings.HasPrefix(scope, rolePrefix) {
+			identity.Roles = append(identity.Roles, strings.TrimPrefix(scope, rolePrefix))
+		}
+	}
+	return identity, nil
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateApiKey(t *testing.T) {
+	key, prefix, hash, err := GenerateApiKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(key, prefix+"_") || !strings.HasPrefix(prefix, "ams_") {
+		t.Errorf("Want: %v, Got: %v", prefix+"_...", key)
+	}
+	if hash != HashApiKey(key) || len(hash) != 64 {
+		t.Errorf("Want: %v, Got: %v", HashApiKey(key), hash)
+	}
+}
+
+func TestApiKeyVerifier_Verify(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	recent := now.Add(-time.Second)
+	const key = "ams_abcdefgh_secret"
+	tests := []struct {
+		name      string
+		key       string
+		setupFunc func(*mock.MockApiKeyDataSourceI)
+		want      *model.Identity
+		wantErr   error
+	}{
+		{
+			name: "SUCCESS::Verify::records last use",
+			key:  key,
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().GetApiKeys(map[string]interface{}{"key_hash": HashApiKey(key)}).
+					Return([]model.ApiKeyDs{{Id: "1", Name: "ingest", Scopes: []string{"articles:write", "role:author"}}}, nil)
+				ds.EXPECT().UpdateApiKey("1", map[string]interface{}{"last_used_at": now}).Return(nil)
+			},
+			want: &model.Identity{Subject: "apikey:1", Name: "ingest", Roles: []string{"author"}, Scopes: []string{"articles:write", "role:author"}, Method: "api_key"},
+		},
+		{
+			name: "SUCCESS::Verify::last use recently recorded",
+			key:  key,
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().GetApiKeys(gomock.Any()).Return([]model.ApiKeyDs{{Id: "1", Name: "ingest", LastUsedAt: &recent}}, nil)
+			},
+			want: &model.Identity{Subject: "apikey:1", Name: "ingest", Method: "api_key"},
+		},
+		{
+			name:      "FAILURE::Verify::malformed key",
+			key:       "secret",
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {},
+			wantErr:   ErrInvalidApiKey,
+		},
+		{
+			name: "FAILURE::Verify::unknown key",
+			key:  key,
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().GetApiKeys(gomock.Any()).Return(nil, nil)
+			},
+			wantErr: ErrInvalidApiKey,
+		},
+		{
+			name: "FAILURE::Verify::expired key",
+			key:  key,
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().GetApiKeys(gomock.Any()).Return([]model.ApiKeyDs{{Id: "1", ExpiresAt: &past}}, nil)
+			},
+			wantErr: ErrInvalidApiKey,
+		},
+		{
+			name: "FAILURE::Verify::revoked key",
+			key:  key,
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().GetApiKeys(gomock.Any()).Return([]model.ApiKeyDs{{Id: "1", RevokedAt: &past}}, nil)
+			},
+			wantErr: ErrInvalidApiKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := mock.NewMockApiKeyDataSourceI(mockCtrl)
+			tt.setupFunc(ds)
+			v := apiKeyVerifier{ds: ds, now: func() time.Time { return now }}
+			got, err := v.Verify(tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Want: %v, Got: %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"context"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying the authenticated caller
+func WithIdentity(ctx context.Context, identity *model.Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFrom returns the authenticated caller attached to ctx, if any
+func IdentityFrom(ctx context.Context) (*model.Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*model.Identity)
+	return identity, ok && identity != nil
+}
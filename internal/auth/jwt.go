@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"strings"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_token_verifier.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/auth TokenVerifierI
+
+// TokenVerifierI validates bearer tokens and resolves the caller they identify
+type TokenVerifierI interface {
+	Verify(token string) (*model.Identity, error)
+}
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// ErrWeakSecret is returned for a jwt_secret anyone could guess, it would let them sign tokens with any role
+var ErrWeakSecret = fmt.Errorf("auth.jwt_secret must be a random value of at least %d bytes", minSecretBytes)
+
+// minSecretBytes is the size of the HS256 hash, RFC 7518 requires keys of at least that size
+const minSecretBytes = 32
+
+// placeholderSecrets are the example values of the sample configurations
+var placeholderSecrets = []string{"change-me", "changeme", "secret"}
+
+type jwtVerifier struct {
+	secret   []byte
+	keys     map[string]crypto.PublicKey
+	issuer   string
+	audience string
+	methods  []string
+}
+
+// NewJwtVerifier creates a verifier accepting HS256 tokens signed with the shared secret
+// and RS256/ES256 tokens signed by a key of the local JWKS file. HS256 is disabled when the secret is empty,
+// and a placeholder or short secret is refused.
+func NewJwtVerifier(cfg config.AuthConfig) (TokenVerifierI, error) {
+	if err := checkSecret(cfg.JwtSecret); err != nil {
+		return nil, err
+	}
+	v := &jwtVerifier{
+		secret:   []byte(cfg.JwtSecret),
+		keys:     map[string]crypto.PublicKey{},
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+	}
+	if cfg.JwtSecret != "" {
+		v.methods = append(v.methods, jwt.SigningMethodHS256.Alg())
+	}
+	if cfg.JwksFile != "" {
+		keys, err := LoadJWKS(cfg.JwksFile)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = keys
+		v.methods = append(v.methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+	return v, nil
+}
+
+func checkSecret(secret string) error {
+	if secret == "" {
+		return nil
+	}
+	for _, placeholder := range placeholderSecrets {
+		if strings.EqualFold(strings.TrimSpace(secret), placeholder) {
+			return ErrWeakSecret
+		}
+	}
+	if len(secret) < minSecretBytes {
+		return ErrWeakSecret
+	}
+	return nil
+}
+
+func (v jwtVerifier) Verify(tokenString string) (*model.Identity, error) {
+	if len(v.methods) == 0 {
+		return nil, fmt.Errorf("%w: no signing keys configured", ErrInvalidToken)
+	}
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, v.key, jwt.WithValidMethods(v.methods))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	// the parser only checks exp when present, a token without one would never expire
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fmt.Errorf("%w: missing expiry", ErrInvalidToken)
+	}
+	if v.issuer != "" && !claims.VerifyIssuer(v.issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	if v.audience != "" && !claims.VerifyAudience(v.audience, true) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+	identity := &model.Identity{
+		Subject: sub,
+		Roles:   stringList(claims["roles"]),
+		Method:  "jwt",
+	}
+	identity.Name, _ = claims["name"].(string)
+	if scope, ok := claims["scope"].(string); ok {
+		identity.Scopes = strings.Fields(scope)
+	} else {
+		identity.Scopes = stringList(claims["scp"])
+	}
+	return identity, nil
+}
+
+// key selects the verification key for the token's algorithm, matching JWKS keys by kid
+func (v jwtVerifier) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return v.secret, nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := v.keys[kid]; ok {
+			return key, nil
+		}
+		if kid == "" {
+			// tokens without a kid are accepted when exactly one key of the right type is configured
+			var match crypto.PublicKey
+			for _, key := range v.keys {
+				if keyMatches(token.Method, key) {
+					if match != nil {
+						return nil, errors.New("kid required")
+					}
+					match = key
+				}
+			}
+			if match != nil {
+				return match, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+}
+
+func keyMatches(method jwt.SigningMethod, key crypto.PublicKey) bool {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		_, ok := method.(*jwt.SigningMethodRSA)
+		return ok
+	case *ecdsa.PublicKey:
+		_, ok := method.(*jwt.SigningMethodECDSA)
+		return ok
+	}
+	return false
+}
+
+// stringList reads a claim holding either a list of strings or a single string
+func stringList(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var list []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeJWKS(t *testing.T, rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) string {
+	enc := func(b *big.Int) string { return base64.RawURLEncoding.EncodeToString(b.Bytes()) }
+	set := map[string]interface{}{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": enc(rsaKey.N), "e": enc(big.NewInt(int64(rsaKey.E)))},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": enc(ecKey.X), "y": enc(ecKey.Y)},
+	}}
+	b, _ := json.Marshal(set)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestJwtVerifier_Verify(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	cfg := config.AuthConfig{
+		JwtSecret: "test-secret-of-at-least-32-bytes",
+		JwksFile:  writeJWKS(t, rsaKey, ecKey),
+		Issuer:    "issuer",
+		Audience:  "articles",
+	}
+	claims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":   "user-1",
+			"name":  "Jane Doe",
+			"iss":   "issuer",
+			"aud":   "articles",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": []string{"author"},
+			"scope": "articles:write articles:read",
+		}
+	}
+	sign := func(method jwt.SigningMethod, kid string, key interface{}, c jwt.MapClaims) string {
+		token := jwt.NewWithClaims(method, c)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		s, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	want := &model.Identity{Subject: "user-1", Name: "Jane Doe", Roles: []string{"author"}, Scopes: []string{"articles:write", "articles:read"}, Method: "jwt"}
+	tests := []struct {
+		name    string
+		token   func() string
+		want    *model.Identity
+		wantErr bool
+	}{
+		{
+			name: "Success::HS256",
+			token: func() string {
+				return sign(jwt.SigningMethodHS256, "", []byte("test-secret-of-at-least-32-bytes"), claims())
+			},
+			want: want,
+		},
+		{
+			name:  "Success::RS256",
+			token: func() string { return sign(jwt.SigningMethodRS256, "rsa-1", rsaKey, claims()) },
+			want:  want,
+		},
+		{
+			name:  "Success::ES256 without kid",
+			token: func() string { return sign(jwt.SigningMethodES256, "", ecKey, claims()) },
+			want:  want,
+		},
+		{
+			name:    "Failure::wrong secret",
+			token:   func() string { return sign(jwt.SigningMethodHS256, "", []byte("guess"), claims()) },
+			wantErr: true,
+		},
+		{
+			name:    "Failure::unknown kid",
+			token:   func() string { return sign(jwt.SigningMethodRS256, "rsa-2", rsaKey, claims()) },
+			wantErr: true,
+		},
+		{
+			name: "Failure::algorithm not allowed",
+			token: func() string {
+				return sign(jwt.SigningMethodHS512, "", []byte("test-secret-of-at-least-32-bytes"), claims())
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failure::expired",
+			token: func() string {
+				c := claims()
+				c["exp"] = time.Now().Add(-time.Minute).Unix()
+				return sign(jwt.SigningMethodHS256, "", []byte("test-secret-of-at-least-32-bytes"), c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failure::missing expiry",
+			token: func() string {
+				c := claims()
+				delete(c, "exp")
+				return sign(jwt.SigningMethodHS256, "", []byte("test-secret-of-at-least-32-bytes"), c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failure::wrong audience",
+			token: func() string {
+				c := claims()
+				c["aud"] = "other"
+				return sign(jwt.SigningMethodHS256, "", []byte("test-secret-of-at-least-32-bytes"), c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failure::missing subject",
+			token: func() string {
+				c := claims()
+				delete(c, "sub")
+				return sign(jwt.SigningMethodHS256, "", []byte("test-secret-of-at-least-32-bytes"), c)
+			},
+			wantErr: true,
+		},
+	}
+	verifier, err := NewJwtVerifier(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifier.Verify(tt.token())
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Errorf("Want: %v, Got: %v", ErrInvalidToken, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Want: %v, Got: %v", nil, err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewJwtVerifier(t *testing.T) {
+	if _, err := NewJwtVerifier(config.AuthConfig{JwksFile: "does-not-exist.json"}); err == nil {
+		t.Errorf("Want: %v, Got: %v", "error", nil)
+	}
+	for _, secret := range []string{"change-me", "Change-Me ", "short"} {
+		if _, err := NewJwtVerifier(config.AuthConfig{JwtSecret: secret}); !errors.Is(err, ErrWeakSecret) {
+			t.Errorf("Want: %v, Got: %v", ErrWeakSecret, err)
+		}
+	}
+	verifier, err := NewJwtVerifier(config.AuthConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = verifier.Verify("a.b.c"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Want: %v, Got: %v", ErrInvalidToken, err)
+	}
+}
@@ -0,0 +1,51 @@
+package authz
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"log"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_author_authz.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/authz AuthorPolicyI
+
+// AuthorPolicyI decides who may change author profiles, profiles are public to read
+type AuthorPolicyI interface {
+	InsertAuthor(identity *model.Identity, req *model.AuthorRequest) *model.Response
+	UpdateAuthor(identity *model.Identity, id string, req *model.AuthorRequest) *model.Response
+}
+
+type authorPolicy struct {
+	logic logic.AuthorLogicI
+}
+
+func NewAuthorPolicyI(l logic.AuthorLogicI) AuthorPolicyI {
+	return &authorPolicy{
+		logic: l,
+	}
+}
+
+// InsertAuthor lets editors create profiles for authors who do not write through the API
+func (p authorPolicy) InsertAuthor(identity *model.Identity, req *model.AuthorRequest) *model.Response {
+	if resp := require(identity, RoleEditor); resp != nil {
+		return resp
+	}
+	return p.logic.InsertAuthor(req)
+}
+
+// UpdateAuthor lets authors edit their own profile and editors edit any
+func (p authorPolicy) UpdateAuthor(identity *model.Identity, id string, req *model.AuthorRequest) *model.Response {
+	if resp := require(identity, RoleAuthor); resp != nil {
+		return resp
+	}
+	if !Has(identity, RoleEditor) && identity.Subject != id {
+		log.Print(codes.GetErr(codes.ErrNotAuthorProfileOwner))
+		return &model.Response{
+			Status:  http.StatusForbidden,
+			Message: codes.GetErr(codes.ErrNotAuthorProfileOwner),
+			Data:    nil,
+		}
+	}
+	return p.logic.UpdateAuthor(id, req)
+}
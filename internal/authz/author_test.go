@@ -0,0 +1,59 @@
+package authz
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"testing"
+)
+
+func TestAuthorPolicy_UpdateAuthor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	req := &model.AuthorRequest{Name: "Jane Doe"}
+	tests := []struct {
+		name     string
+		identity *model.Identity
+		setup    func(*mock.MockAuthorLogicI)
+		want     int
+	}{
+		{
+			name:     "Success:: own profile",
+			identity: &model.Identity{Subject: "user-1", Roles: []string{RoleAuthor}},
+			setup: func(l *mock.MockAuthorLogicI) {
+				l.EXPECT().UpdateAuthor("user-1", req).Return(&model.Response{Status: http.StatusOK})
+			},
+			want: http.StatusOK,
+		},
+		{
+			name:     "Success:: editor edits any profile",
+			identity: &model.Identity{Subject: "user-2", Roles: []string{RoleEditor}},
+			setup: func(l *mock.MockAuthorLogicI) {
+				l.EXPECT().UpdateAuthor("user-1", req).Return(&model.Response{Status: http.StatusOK})
+			},
+			want: http.StatusOK,
+		},
+		{
+			name:     "Failure:: another author's profile",
+			identity: &model.Identity{Subject: "user-2", Roles: []string{RoleAuthor}},
+			setup:    func(l *mock.MockAuthorLogicI) {},
+			want:     http.StatusForbidden,
+		},
+		{
+			name:  "Failure:: anonymous",
+			setup: func(l *mock.MockAuthorLogicI) {},
+			want:  http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockAuthorLogicI(mockCtrl)
+			tt.setup(mockLogic)
+			got := NewAuthorPolicyI(mockLogic).UpdateAuthor(tt.identity, "user-1", req)
+			if got.Status != tt.want {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package authz
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"log"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_authz.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/authz ArticlePolicyI
+
+// Roles in increasing order of privilege, each role holds every permission of the roles before it
+const (
+	RoleReader = "reader"
+	RoleAuthor = "author"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+var hierarchy = []string{RoleReader, RoleAuthor, RoleEditor, RoleAdmin}
+
+// AtLeast returns role and every role above it, for use with middleware.RequireRole
+func AtLeast(role string) []string {
+	for i, r := range hierarchy {
+		if r == role {
+			return append([]string(nil), hierarchy[i:]...)
+		}
+	}
+	return []string{role}
+}
+
+// Has reports whether identity holds role or a role above it
+func Has(identity *model.Identity, role string) bool {
+	return identity != nil && identity.HasRole(AtLeast(role)...)
+}
+
+// ArticlePolicyI decides what the caller may do before handing the request to the logic layer
+type ArticlePolicyI interface {
+	InsertArticle(identity *model.Identity, req *model.Article) *model.Response
+	UpdateArticle(identity *model.Identity, id string, req *model.Article) *model.Response
+	TransitionArticle(identity *model.Identity, id string, action string) *model.Response
+	ScheduleArticle(identity *model.Identity, id string, req *model.ScheduleRequest) *model.Response
+	GetRevisions(identity *model.Identity, id string) *model.Response
+	GetRevision(identity *model.Identity, id string, rev int) *model.Response
+	DiffRevisions(identity *model.Identity, id string, from int, to int) *model.Response
+	RestoreRevision(identity *model.Identity, id string, rev int, version int) *model.Response
+	GetArticle(identity *model.Identity, id string) *model.Response
+	GetArticleBySlug(identity *model.Identity, slug string) *model.Response
+	GetAllArticle(identity *model.Identity, limit int, page int, status string, tags *model.TagFilter) *model.Response
+	GetTags(identity *model.Identity) *model.Response
+}
+
+type articlePolicy struct {
+	logic logic.ArticleManagementLogicI
+}
+
+func NewArticlePolicyI(l logic.ArticleManagementLogicI) ArticlePolicyI {
+	return &articlePolicy{
+		logic: l,
+	}
+}
+
+// InsertArticle lets authors and above create articles, which are attributed to the caller
+func (p articlePolicy) InsertArticle(identity *model.Identity, req *model.Article) *model.Response {
+	if resp := require(identity, RoleAuthor); resp != nil {
+		return resp
+	}
+	req.AuthorId = identity.Subject
+	req.Editor = identity.Subject
+	req.Author = identity.Name
+	if req.Author == "" {
+		req.Author = identity.Subject
+	}
+	return p.logic.InsertArticle(req)
+}
+
+// UpdateArticle lets authors edit their own drafts and editors edit any article
+func (p articlePolicy) UpdateArticle(identity *model.Identity, id string, req *model.Article) *model.Response {
+	if resp := p.editable(identity, id); resp != nil {
+		return resp
+	}
+	req.Editor = identity.Subject
+	return p.logic.UpdateArticle(id, req)
+}
+
+// TransitionArticle lets authors submit their own drafts for review, every other action is reserved to editors
+func (p articlePolicy) TransitionArticle(identity *model.Identity, id string, action string) *model.Response {
+	if action != logic.ActionSubmit {
+		if resp := require(identity, RoleEditor); resp != nil {
+			return resp
+		}
+		return p.logic.TransitionArticle(id, action)
+	}
+	if resp := require(identity, RoleAuthor); resp != nil {
+		return resp
+	}
+	if !Has(identity, RoleEditor) {
+		if _, resp := p.owned(identity, id); resp != nil {
+			return resp
+		}
+	}
+	return p.logic.TransitionArticle(id, action)
+}
+
+// ScheduleArticle lets editors set when an article is published and withdrawn
+func (p articlePolicy) ScheduleArticle(identity *model.Identity, id string, req *model.ScheduleRequest) *model.Response {
+	if resp := require(identity, RoleEditor); resp != nil {
+		return resp
+	}
+	return p.logic.ScheduleArticle(id, req)
+}
+
+// GetArticle returns published articles to everyone, other articles only to their author and editors
+func (p articlePolicy) GetArticle(identity *model.Identity, id string) *model.Response {
+	resp := p.logic.GetArticle(id)
+	if resp.Status != http.StatusOK {
+		return resp
+	}
+	articles, _ := resp.Data.([]model.ArticleDs)
+	if len(articles) > 0 && !visible(identity, articles[0]) {
+		log.Print(codes.GetErr(codes.ErrArticleNotFound))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrArticleNotFound),
+			Data:    nil,
+		}
+	}
+	return resp
+}
+
+// GetArticleBySlug is GetArticle for an article addressed by its current or a former slug
+func (p articlePolicy) GetArticleBySlug(identity *model.Identity, slug string) *model.Response {
+	resp := p.logic.GetArticleBySlug(slug)
+	if resp.Status != http.StatusOK && resp.Status != http.StatusMovedPermanently {
+		return resp
+	}
+	articles, _ := resp.Data.([]model.ArticleDs)
+	if len(articles) > 0 && !visible(identity, articles[0]) {
+		log.Print(codes.GetErr(codes.ErrArticleNotFound))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrArticleNotFound),
+			Data:    nil,
+		}
+	}
+	return resp
+}
+
+// GetAllArticle lists published articles, or the caller's own articles in another status (any author's for editors),
+// optionally restricted to the articles matching tags
+func (p articlePolicy) GetAllArticle(identity *model.Identity, limit int, page int, status string, tags *model.TagFilter) *model.Response {
+	if status == "" || status == model.StatusPublished {
+		filter := map[string]interface{}{"status": model.StatusPublished}
+		if tags != nil {
+			filter[model.FilterTags] = *tags
+		}
+		return p.logic.GetAllArticle(limit, page, filter)
+	}
+	if !model.ValidStatus(status) {
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrInvalidStatus),
+			Data:    nil,
+		}
+	}
+	if resp := require(identity, RoleAuthor); resp != nil {
+		return resp
+	}
+	filter := map[string]interface{}{"status": status}
+	if !Has(identity, RoleEditor) {
+		filter["author_id"] = identity.Subject
+	}
+	if tags != nil {
+		filter[model.FilterTags] = *tags
+	}
+	return p.logic.GetAllArticle(limit, page, filter)
+}
+
+// GetTags is public, only tags of published articles are counted
+func (p articlePolicy) GetTags(_ *model.Identity) *model.Response {
+	return p.logic.GetTags()
+}
+
+// GetRevisions lets authors list the revisions of their own articles and editors those of any article
+func (p articlePolicy) GetRevisions(identity *model.Identity, id string) *model.Response {
+	if resp := p.revisionsReadable(identity, id); resp != nil {
+		return resp
+	}
+	return p.logic.GetRevisions(id)
+}
+
+func (p articlePolicy) GetRevision(identity *model.Identity, id string, rev int) *model.Response {
+	if resp := p.revisionsReadable(identity, id); resp != nil {
+		return resp
+	}
+	return p.logic.GetRevision(id, rev)
+}
+
+func (p articlePolicy) DiffRevisions(identity *model.Identity, id string, from int, to int) *model.Response {
+	if resp := p.revisionsReadable(identity, id); resp != nil {
+		return resp
+	}
+	return p.logic.DiffRevisions(id, from, to)
+}
+
+// RestoreRevision is allowed to whoever may update the article
+func (p articlePolicy) RestoreRevision(identity *model.Identity, id string, rev int, version int) *model.Response {
+	if resp := p.editable(identity, id); resp != nil {
+		return resp
+	}
+	return p.logic.RestoreRevision(id, rev, version, identity.Subject)
+}
+
+// editable returns the response to send when identity may not change the article, authors may change only their own drafts
+func (p articlePolicy) editable(identity *model.Identity, id string) *model.Response {
+	if resp := require(identity, RoleAuthor); resp != nil {
+		return resp
+	}
+	if Has(identity, RoleEditor) {
+		return nil
+	}
+	article, resp := p.owned(identity, id)
+	if resp != nil {
+		return resp
+	}
+	if article.Status != model.StatusDraft {
+		log.Print(codes.GetErr(codes.ErrArticleNotEditable))
+		return &model.Response{
+			Status:  http.StatusForbidden,
+			Message: codes.GetErr(codes.ErrArticleNotEditable),
+			Data:    nil,
+		}
+	}
+	return nil
+}
+
+// revisionsReadable returns the response to send when identity may not read the revisions of the article
+func (p articlePolicy) revisionsReadable(identity *model.Identity, id string) *model.Response {
+	if resp := require(identity, RoleAuthor); resp != nil {
+		return resp
+	}
+	if Has(identity, RoleEditor) {
+		if resp := p.logic.GetArticle(id); resp.Status != http.StatusOK {
+			return resp
+		}
+		return nil
+	}
+	_, resp := p.owned(identity, id)
+	return resp
+}
+
+// owned returns the article with the given id, or the response to send when identity is not its author
+func (p articlePolicy) owned(identity *model.Identity, id string) (*model.ArticleDs, *model.Response) {
+	resp := p.logic.GetArticle(id)
+	if resp.Status != http.StatusOK {
+		return nil, resp
+	}
+	articles, _ := resp.Data.([]model.ArticleDs)
+	if len(articles) == 0 || articles[0].AuthorId != identity.Subject {
+		log.Print(codes.GetErr(codes.ErrNotArticleOwner))
+		return nil, &model.Response{
+			Status:  http.StatusForbidden,
+			Message: codes.GetErr(codes.ErrNotArticleOwner),
+			Data:    nil,
+		}
+	}
+	return &articles[0], nil
+}
+
+// visible reports whether identity may read article
+func visible(identity *model.Identity, article model.ArticleDs) bool {
+	if article.Status == model.StatusPublished {
+		return true
+	}
+	return identity != nil && (Has(identity, RoleEditor) || (Has(identity, RoleAuthor) && article.AuthorId == identity.Subject))
+}
+
+// require returns the response to send when identity does not hold role
+func require(identity *model.Identity, role string) *model.Response {
+	if identity == nil {
+		return &model.Response{
+			Status:  http.StatusUnauthorized,
+			Message: codes.GetErr(codes.ErrUnauthorized),
+			Data:    nil,
+		}
+	}
+	if !Has(identity, role) {
+		log.Print(codes.GetErr(codes.ErrForbidden))
+		return &model.Response{
+			Status:  http.StatusForbidden,
+			Message: codes.GetErr(codes.ErrForbidden),
+			Data:    nil,
+		}
+	}
+	return nil
+}
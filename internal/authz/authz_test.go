@@ -0,0 +1,350 @@
+package authz
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAtLeast(t *testing.T) {
+	want := []string{RoleEditor, RoleAdmin}
+	if got := AtLeast(RoleEditor); !reflect.DeepEqual(want, got) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+	if !Has(&model.Identity{Roles: []string{RoleAdmin}}, RoleAuthor) {
+		t.Errorf("Want: %v, Got: %v", true, false)
+	}
+	if Has(&model.Identity{Roles: []string{RoleReader}}, RoleAuthor) {
+		t.Errorf("Want: %v, Got: %v", false, true)
+	}
+}
+
+func TestArticlePolicy_InsertArticle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name      string
+		identity  *model.Identity
+		setupFunc func(*mock.MockArticleManagementLogicI)
+		want      *model.Response
+	}{
+		{
+			name:     "Success:: author derived from identity",
+			identity: &model.Identity{Subject: "user-1", Name: "Jane", Roles: []string{RoleAuthor}},
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().InsertArticle(&model.Article{Title: "title", Content: "content", Author: "Jane", AuthorId: "user-1", Editor: "user-1"}).
+					Return(&model.Response{Status: http.StatusCreated})
+			},
+			want: &model.Response{Status: http.StatusCreated},
+		},
+		{
+			name:      "Failure:: reader",
+			identity:  &model.Identity{Subject: "user-1", Roles: []string{RoleReader}},
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {},
+			want:      &model.Response{Status: http.StatusForbidden, Message: codes.GetErr(codes.ErrForbidden)},
+		},
+		{
+			name:      "Failure:: anonymous",
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {},
+			want:      &model.Response{Status: http.StatusUnauthorized, Message: codes.GetErr(codes.ErrUnauthorized)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := mock.NewMockArticleManagementLogicI(mockCtrl)
+			tt.setupFunc(l)
+			got := NewArticlePolicyI(l).InsertArticle(tt.identity, &model.Article{Title: "title", Content: "content"})
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestArticlePolicy_UpdateArticle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	req := &model.Article{Title: "title", Content: "content"}
+	owned := &model.Response{Status: http.StatusOK, Data: []model.ArticleDs{{Id: "1", AuthorId: "user-1", Status: model.StatusDraft}}}
+	updated := &model.Response{Status: http.StatusOK, Message: "Success", Data: map[string]string{"id": "1"}}
+	tests := []struct {
+		name      string
+		identity  *model.Identity
+		setupFunc func(*mock.MockArticleManagementLogicI)
+		want      *model.Response
+	}{
+		{
+			name:     "Success:: author edits own article",
+			identity: &model.Identity{Subject: "user-1", Roles: []string{RoleAuthor}},
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetArticle("1").Return(owned)
+				l.EXPECT().UpdateArticle("1", req).Return(updated)
+			},
+			want: updated,
+		},
+		{
+			name:     "Failure:: author edits someone else's article",
+			identity: &model.Identity{Subject: "user-2", Roles: []string{RoleAuthor}},
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetArticle("1").Return(owned)
+			},
+			want: &model.Response{Status: http.StatusForbidden, Message: codes.GetErr(codes.ErrNotArticleOwner)},
+		},
+		{
+			name:     "Success:: editor edits any article",
+			identity: &model.Identity{Subject: "user-2", Roles: []string{RoleEditor}},
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().UpdateArticle("1", req).Return(updated)
+			},
+			want: updated,
+		},
+		{
+			name:     "Failure:: article not found",
+			identity: &model.Identity{Subject: "user-1", Roles: []string{RoleAuthor}},
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetArticle("1").Return(&model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrArticleNotFound)})
+			},
+			want: &model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrArticleNotFound)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := mock.NewMockArticleManagementLogicI(mockCtrl)
+			tt.setupFunc(l)
+			got := NewArticlePolicyI(l).UpdateArticle(tt.identity, "1", req)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestArticlePolicy_TransitionArticle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	owned := &model.Response{Status: http.StatusOK, Data: []model.ArticleDs{{Id: "1", AuthorId: "user-1", Status: model.StatusDraft}}}
+	done := &model.Response{Status: http.StatusOK, Message: "Success"}
+	tests := []struct {
+		name      string
+		identity  *model.Identity
+		action    string
+		setupFunc func(*mock.MockArticleManagementLogicI)
+		want      *model.Response
+	}{
+		{
+			name:     "Success:: author submits own draft",
+			identity: &model.Identity{Subject: "user-1", Roles: []string{RoleAuthor}},
+			action:   logic.ActionSubmit,
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetArticle("1").Return(owned)
+				l.EXPECT().TransitionArticle("1", logic.ActionSubmit).Return(done)
+			},
+			want: done,
+		},
+		{
+			name:     "Failure:: author submits someone else's draft",
+			identity: &model.Identity{Subject: "user-2", Roles: []string{RoleAuthor}},
+			action:   logic.ActionSubmit,
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetArticle("1").Return(owned)
+			},
+			want: &model.Response{Status: http.StatusForbidden, Message: codes.GetErr(codes.ErrNotArticleOwner)},
+		},
+		{
+			name:      "Failure:: author approves",
+			identity:  &model.Identity{Subject: "user-1", Roles: []string{RoleAuthor}},
+			action:    logic.ActionApprove,
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {},
+			want:      &model.Response{Status: http.StatusForbidden, Message: codes.GetErr(codes.ErrForbidden)},
+		},
+		{
+			name:     "Success:: editor approves",
+			identity: &model.Identity{Subject: "user-2", Roles: []string{RoleEditor}},
+			action:   logic.ActionApprove,
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().TransitionArticle("1", logic.ActionApprove).Return(done)
+			},
+			want: done,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := mock.NewMockArticleManagementLogicI(mockCtrl)
+			tt.setupFunc(l)
+			got := NewArticlePolicyI(l).TransitionArticle(tt.identity, "1", tt.action)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestArticlePolicy_Visibility(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	draft := &model.Response{Status: http.StatusOK, Data: []model.ArticleDs{{Id: "1", AuthorId: "user-1", Status: model.StatusDraft}}}
+	notFound := &model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrArticleNotFound)}
+	list := &model.Response{Status: http.StatusOK, Message: "Success"}
+	author := &model.Identity{Subject: "user-1", Roles: []string{RoleAuthor}}
+	tests := []struct {
+		name      string
+		setupFunc func(*mock.MockArticleManagementLogicI)
+		run       func(ArticlePolicyI) *model.Response
+		want      *model.Response
+	}{
+		{
+			name: "Success:: author reads own draft",
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetArticle("1").Return(draft)
+			},
+			run:  func(p ArticlePolicyI) *model.Response { return p.GetArticle(author, "1") },
+			want: draft,
+		},
+		{
+			name: "Failure:: anonymous reads draft",
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetArticle("1").Return(draft)
+			},
+			run:  func(p ArticlePolicyI) *model.Response { return p.GetArticle(nil, "1") },
+			want: notFound,
+		},
+		{
+			name: "Success:: public list is published only",
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetAllArticle(20, 1, map[string]interface{}{"status": model.StatusPublished}).Return(list)
+			},
+			run:  func(p ArticlePolicyI) *model.Response { return p.GetAllArticle(nil, 20, 1, "", nil) },
+			want: list,
+		},
+		{
+			name: "Success:: author lists own drafts",
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetAllArticle(20, 1, map[string]interface{}{"status": model.StatusDraft, "author_id": "user-1"}).Return(list)
+			},
+			run:  func(p ArticlePolicyI) *model.Response { return p.GetAllArticle(author, 20, 1, model.StatusDraft, nil) },
+			want: list,
+		},
+		{
+			name: "Success:: anonymous lists published articles by tag",
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetAllArticle(20, 1, map[string]interface{}{"status": model.StatusPublished, model.FilterTags: model.TagFilter{Tags: []string{"go"}, All: true}}).Return(list)
+			},
+			run: func(p ArticlePolicyI) *model.Response {
+				return p.GetAllArticle(nil, 20, 1, "", &model.TagFilter{Tags: []string{"go"}, All: true})
+			},
+			want: list,
+		},
+		{
+			name:      "Failure:: anonymous lists drafts",
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {},
+			run:       func(p ArticlePolicyI) *model.Response { return p.GetAllArticle(nil, 20, 1, model.StatusDraft, nil) },
+			want:      &model.Response{Status: http.StatusUnauthorized, Message: codes.GetErr(codes.ErrUnauthorized)},
+		},
+		{
+			name: "Failure:: author edits own published article",
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetArticle("1").Return(&model.Response{Status: http.StatusOK, Data: []model.ArticleDs{{Id: "1", AuthorId: "user-1", Status: model.StatusPublished}}})
+			},
+			run:  func(p ArticlePolicyI) *model.Response { return p.UpdateArticle(author, "1", &model.Article{}) },
+			want: &model.Response{Status: http.StatusForbidden, Message: codes.GetErr(codes.ErrArticleNotEditable)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := mock.NewMockArticleManagementLogicI(mockCtrl)
+			tt.setupFunc(l)
+			if got := tt.run(NewArticlePolicyI(l)); !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestArticlePolicy_Revisions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	draft := &model.Response{Status: http.StatusOK, Data: []model.ArticleDs{{Id: "1", AuthorId: "user-1", Status: model.StatusDraft}}}
+	published := &model.Response{Status: http.StatusOK, Data: []model.ArticleDs{{Id: "1", AuthorId: "user-1", Status: model.StatusPublished}}}
+	restored := &model.Response{Status: http.StatusOK, Message: "Success", Data: map[string]interface{}{"id": "1", "restored": 2}}
+	revisions := &model.Response{Status: http.StatusOK, Message: "Success", Data: []model.RevisionDs{{ArticleId: "1", Rev: 1}}}
+	tests := []struct {
+		name      string
+		identity  *model.Identity
+		call      func(ArticlePolicyI, *model.Identity) *model.Response
+		setupFunc func(*mock.MockArticleManagementLogicI)
+		want      *model.Response
+	}{
+		{
+			name:     "Success:: author lists revisions of own article",
+			identity: &model.Identity{Subject: "user-1", Roles: []string{RoleAuthor}},
+			call: func(p ArticlePolicyI, identity *model.Identity) *model.Response {
+				return p.GetRevisions(identity, "1")
+			},
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetArticle("1").Return(published)
+				l.EXPECT().GetRevisions("1").Return(revisions)
+			},
+			want: revisions,
+		},
+		{
+			name:     "Failure:: author lists revisions of someone else's article",
+			identity: &model.Identity{Subject: "user-2", Roles: []string{RoleAuthor}},
+			call: func(p ArticlePolicyI, identity *model.Identity) *model.Response {
+				return p.GetRevisions(identity, "1")
+			},
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetArticle("1").Return(published)
+			},
+			want: &model.Response{Status: http.StatusForbidden, Message: codes.GetErr(codes.ErrNotArticleOwner)},
+		},
+		{
+			name:     "Success:: author restores own draft",
+			identity: &model.Identity{Subject: "user-1", Roles: []string{RoleAuthor}},
+			call: func(p ArticlePolicyI, identity *model.Identity) *model.Response {
+				return p.RestoreRevision(identity, "1", 2, 3)
+			},
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetArticle("1").Return(draft)
+				l.EXPECT().RestoreRevision("1", 2, 3, "user-1").Return(restored)
+			},
+			want: restored,
+		},
+		{
+			name:     "Failure:: author restores own published article",
+			identity: &model.Identity{Subject: "user-1", Roles: []string{RoleAuthor}},
+			call: func(p ArticlePolicyI, identity *model.Identity) *model.Response {
+				return p.RestoreRevision(identity, "1", 2, 3)
+			},
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().GetArticle("1").Return(published)
+			},
+			want: &model.Response{Status: http.StatusForbidden, Message: codes.GetErr(codes.ErrArticleNotEditable)},
+		},
+		{
+			name:     "Success:: editor restores any article",
+			identity: &model.Identity{Subject: "user-2", Roles: []string{RoleEditor}},
+			call: func(p ArticlePolicyI, identity *model.Identity) *model.Response {
+				return p.RestoreRevision(identity, "1", 2, 3)
+			},
+			setupFunc: func(l *mock.MockArticleManagementLogicI) {
+				l.EXPECT().RestoreRevision("1", 2, 3, "user-2").Return(restored)
+			},
+			want: restored,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := mock.NewMockArticleManagementLogicI(mockCtrl)
+			tt.setupFunc(l)
+			got := tt.call(NewArticlePolicyI(l), tt.identity)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package authz
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"log"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_media_authz.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/authz MediaPolicyI
+
+// MediaPolicyI decides who may attach files to articles and read them, files go with their article
+type MediaPolicyI interface {
+	UploadMedia(identity *model.Identity, articleId string, upload *model.MediaUpload) *model.Response
+	DeleteMedia(identity *model.Identity, articleId string, mediaId string) *model.Response
+	GetArticleMedia(identity *model.Identity, articleId string) *model.Response
+	OpenMedia(identity *model.Identity, id string, thumbnail bool) *model.Response
+}
+
+type mediaPolicy struct {
+	logic    logic.MediaLogicI
+	articles articlePolicy
+}
+
+func NewMediaPolicyI(l logic.MediaLogicI, articles logic.ArticleManagementLogicI) MediaPolicyI {
+	return &mediaPolicy{
+		logic:    l,
+		articles: articlePolicy{logic: articles},
+	}
+}
+
+// UploadMedia is allowed to whoever may update the article, the file is attributed to the caller
+func (p mediaPolicy) UploadMedia(identity *model.Identity, articleId string, upload *model.MediaUpload) *model.Response {
+	if resp := p.articles.editable(identity, articleId); resp != nil {
+		return resp
+	}
+	upload.Uploader = identity.Subject
+	return p.logic.UploadMedia(articleId, upload)
+}
+
+// DeleteMedia is allowed to whoever may update the article
+func (p mediaPolicy) DeleteMedia(identity *model.Identity, articleId string, mediaId string) *model.Response {
+	if resp := p.articles.editable(identity, articleId); resp != nil {
+		return resp
+	}
+	return p.logic.DeleteMedia(articleId, mediaId)
+}
+
+// GetArticleMedia lists the files of the articles identity may read
+func (p mediaPolicy) GetArticleMedia(identity *model.Identity, articleId string) *model.Response {
+	article, resp := p.readable(identity, articleId)
+	if resp != nil {
+		return resp
+	}
+	resp = p.logic.GetArticleMedia(articleId)
+	if list, ok := resp.Data.([]model.MediaDs); ok {
+		resp.Data = model.MediaList{Media: list, Public: article.Status == model.StatusPublished}
+	}
+	return resp
+}
+
+// OpenMedia opens the files of the articles identity may read
+func (p mediaPolicy) OpenMedia(identity *model.Identity, id string, thumbnail bool) *model.Response {
+	resp := p.logic.GetMedia(id)
+	if resp.Status != http.StatusOK {
+		return resp
+	}
+	found, _ := resp.Data.([]model.MediaDs)
+	if len(found) == 0 {
+		return mediaNotFound()
+	}
+	article, resp := p.readable(identity, found[0].ArticleId)
+	if resp != nil {
+		// files of articles the caller may not read do not exist for them
+		if resp.Status == http.StatusBadRequest {
+			return mediaNotFound()
+		}
+		return resp
+	}
+	m := found[0]
+	m.Public = article.Status == model.StatusPublished
+	return p.logic.OpenMedia(m, thumbnail)
+}
+
+// readable returns the article with the given id, or the response to send when identity may not read it
+func (p mediaPolicy) readable(identity *model.Identity, id string) (*model.ArticleDs, *model.Response) {
+	resp := p.articles.logic.GetArticle(id)
+	if resp.Status != http.StatusOK {
+		return nil, resp
+	}
+	articles, _ := resp.Data.([]model.ArticleDs)
+	if len(articles) == 0 || !visible(identity, articles[0]) {
+		log.Print(codes.GetErr(codes.ErrArticleNotFound))
+		return nil, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrArticleNotFound),
+			Data:    nil,
+		}
+	}
+	return &articles[0], nil
+}
+
+func mediaNotFound() *model.Response {
+	log.Print(codes.GetErr(codes.ErrMediaNotFound))
+	return &model.Response{
+		Status:  http.StatusBadRequest,
+		Message: codes.GetErr(codes.ErrMediaNotFound),
+		Data:    nil,
+	}
+}
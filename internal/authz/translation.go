@@ -0,0 +1,42 @@
+package authz
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_translation_authz.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/authz TranslationPolicyI
+
+// TranslationPolicyI decides who may translate articles, translations are read with their article
+type TranslationPolicyI interface {
+	PutTranslation(identity *model.Identity, articleId string, locale string, req *model.TranslationRequest) *model.Response
+	DeleteTranslation(identity *model.Identity, articleId string, locale string) *model.Response
+}
+
+type translationPolicy struct {
+	logic    logic.TranslationLogicI
+	articles articlePolicy
+}
+
+func NewTranslationPolicyI(l logic.TranslationLogicI, articles logic.ArticleManagementLogicI) TranslationPolicyI {
+	return &translationPolicy{
+		logic:    l,
+		articles: articlePolicy{logic: articles},
+	}
+}
+
+// PutTranslation is allowed to whoever may update the article
+func (p translationPolicy) PutTranslation(identity *model.Identity, articleId string, locale string, req *model.TranslationRequest) *model.Response {
+	if resp := p.articles.editable(identity, articleId); resp != nil {
+		return resp
+	}
+	return p.logic.PutTranslation(articleId, locale, req)
+}
+
+// DeleteTranslation is allowed to whoever may update the article
+func (p translationPolicy) DeleteTranslation(identity *model.Identity, articleId string, locale string) *model.Response {
+	if resp := p.articles.editable(identity, articleId); resp != nil {
+		return resp
+	}
+	return p.logic.DeleteTranslation(articleId, locale)
+}
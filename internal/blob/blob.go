@@ -0,0 +1,40 @@
+package blob
+
+import (
+	"errors"
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"io"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_blob.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/blob StoreI
+
+// Storage drivers
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// ErrNotFound is returned by Get when nothing is stored under the key
+var ErrNotFound = errors.New("blob: not found")
+
+// StoreI keeps files under keys of the form a/b/c. Stored files are never changed, only replaced or deleted.
+type StoreI interface {
+	// Put stores data under key, replacing what was stored there
+	Put(key string, data []byte, contentType string) error
+	// Get opens the file stored under key, the caller closes it
+	Get(key string) (io.ReadCloser, error)
+	// Delete removes the file stored under key, deleting a missing file is not an error
+	Delete(key string) error
+}
+
+// NewStoreI creates the store selected by cfg.Driver, the local filesystem by default
+func NewStoreI(cfg config.BlobConfig) (StoreI, error) {
+	switch cfg.Driver {
+	case "", DriverLocal:
+		return NewFileStore(cfg.Dir), nil
+	case DriverS3:
+		return NewS3Store(cfg.S3), nil
+	}
+	return nil, fmt.Errorf("blob: unknown storage driver %q", cfg.Driver)
+}
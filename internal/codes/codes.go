@@ -12,14 +12,98 @@ const (
 	ErrUnmarshall
 	ErrDataSource
 	ErrArticleNotFound
+	ErrUnauthorized
+	ErrInvalidToken
+	ErrInvalidApiKey
+	ErrForbidden
+	ErrApiKeyNotFound
+	ErrInvalidExpiry
+	ErrNotArticleOwner
+	ErrRateLimited
+	ErrInvalidIdempotencyKey
+	ErrIdempotencyKeyReused
+	ErrIdempotencyInProgress
+	ErrUnknownTransition
+	ErrInvalidTransition
+	ErrInvalidStatus
+	ErrArticleNotEditable
+	ErrInvalidSchedule
+	ErrRevisionNotFound
+	ErrInvalidRevision
+	ErrPreconditionRequired
+	ErrVersionMismatch
+	ErrInvalidTags
+	ErrCategoryNotFound
+	ErrCategoryCycle
+	ErrCategoryInUse
+	ErrAuthorNotFound
+	ErrNotAuthorProfileOwner
+	ErrCommentNotFound
+	ErrInvalidCommentParent
+	ErrInvalidCommentStatus
+	ErrInvalidViewWindow
+	ErrMediaNotFound
+	ErrMissingUploadFile
+	ErrMediaTooLarge
+	ErrUnsupportedMediaType
+	ErrInvalidImage
+	ErrRequestTooLarge
+	ErrInvalidLocale
+	ErrTranslationLocale
+	ErrTranslationNotFound
+	ErrAmbiguousAuthor
+	ErrApiKeyInactive
+	ErrStatusChanged
 )
 
 var errCodes = map[errCode]string{
-	ErrAssertid:        "Unable to assert article id",
-	ErrReadingReqBody:  "Unable to read request body",
-	ErrUnmarshall:      "Unable to unmarshal request body",
-	ErrDataSource:      "DataSource error",
-	ErrArticleNotFound: "No article found for specified id",
+	ErrAssertid:              "Unable to assert article id",
+	ErrReadingReqBody:        "Unable to read request body",
+	ErrUnmarshall:            "Unable to unmarshal request body",
+	ErrDataSource:            "DataSource error",
+	ErrArticleNotFound:       "No article found for specified id",
+	ErrUnauthorized:          "Authentication required",
+	ErrInvalidToken:          "Invalid or expired bearer token",
+	ErrInvalidApiKey:         "Invalid, expired or revoked api key",
+	ErrForbidden:             "Not allowed to perform this action",
+	ErrApiKeyNotFound:        "No api key found for specified id",
+	ErrInvalidExpiry:         "Invalid expires_in duration",
+	ErrNotArticleOwner:       "Only the author or an editor may modify this article",
+	ErrRateLimited:           "Too many requests, retry later",
+	ErrInvalidIdempotencyKey: "Idempotency-Key must be 1 to 255 printable characters",
+	ErrIdempotencyKeyReused:  "Idempotency-Key was already used with a different request",
+	ErrIdempotencyInProgress: "A request with this Idempotency-Key is still being processed",
+	ErrUnknownTransition:     "Unknown workflow action",
+	ErrInvalidTransition:     "Action not allowed in the article's current status",
+	ErrInvalidStatus:         "Unknown article status",
+	ErrArticleNotEditable:    "Only drafts may be edited by their author",
+	ErrInvalidSchedule:       "unpublish_at must be after publish_at",
+	ErrRevisionNotFound:      "No revision found for specified article and number",
+	ErrInvalidRevision:       "Revision numbers must be positive integers",
+	ErrPreconditionRequired:  "If-Match with the article's ETag is required",
+	ErrVersionMismatch:       "Article was modified since it was read, fetch it again and retry",
+	ErrInvalidTags:           "Tags must be 1 to 32 characters long and at most 10 per article",
+	ErrCategoryNotFound:      "No category found for specified id",
+	ErrCategoryCycle:         "A category cannot be moved below itself or one of its subcategories",
+	ErrCategoryInUse:         "Category still has subcategories or articles",
+	ErrAuthorNotFound:        "No author found for specified id",
+	ErrNotAuthorProfileOwner: "Only the author or an editor may change this profile",
+	ErrCommentNotFound:       "No comment found for specified id",
+	ErrInvalidCommentParent:  "Replies must answer an approved top-level comment of the same article",
+	ErrInvalidCommentStatus:  "Comment status must be one of pending, approved or rejected",
+	ErrInvalidViewWindow:     "Window must be one of 24h, 7d or 30d",
+	ErrMediaNotFound:         "No media found for specified id",
+	ErrMissingUploadFile:     "A multipart form with the file in the file field is required",
+	ErrMediaTooLarge:         "File exceeds the maximum upload size",
+	ErrUnsupportedMediaType:  "File type is not accepted",
+	ErrInvalidImage:          "File is not a valid image",
+	ErrRequestTooLarge:       "Request body is too large",
+	ErrInvalidLocale:         "Locale must be a BCP 47 language tag such as en or pt-BR",
+	ErrTranslationLocale:     "The article is written in this locale, update the article instead",
+	ErrTranslationNotFound:   "No translation found for specified article and locale",
+	ErrAmbiguousAuthor:       "Some author names match several profiles, rename or merge those profiles before migrating",
+	ErrApiKeyInactive:        "Revoked or expired api keys cannot be rotated, create a new key instead",
+	ErrStatusChanged:         "Article status changed meanwhile, fetch it again and retry",
 }
 
 func GetErr(code errCode) string {
@@ -0,0 +1,105 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"github.com/andybalholm/brotli"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+const (
+	Identity = "identity"
+	Gzip     = "gzip"
+	Brotli   = "br"
+)
+
+// Negotiate picks the content-coding to answer with from an Accept-Encoding header.
+// supported is in server preference order, which breaks ties between equal q-values.
+// Identity is returned when the client accepts none of the supported codings.
+func Negotiate(acceptEncoding string, supported []string) string {
+	qualities := map[string]float64{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, q := part, 1.0
+		if i := strings.Index(part, ";"); i >= 0 {
+			name = strings.TrimSpace(part[:i])
+			param := strings.TrimSpace(part[i+1:])
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		qualities[strings.ToLower(name)] = q
+	}
+	best, bestQ := Identity, 0.0
+	for _, encoding := range supported {
+		q, ok := qualities[encoding]
+		if !ok {
+			q, ok = qualities["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// Accepts reports whether the Accept-Encoding header allows the given coding
+func Accepts(acceptEncoding string, encoding string) bool {
+	return Negotiate(acceptEncoding, []string{encoding}) == encoding
+}
+
+// NewWriter returns a writer compressing into w with the given coding
+func NewWriter(encoding string, w io.Writer) (io.WriteCloser, error) {
+	switch encoding {
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Brotli:
+		return brotli.NewWriterLevel(w, 5), nil
+	default:
+		return nil, fmt.Errorf("unsupported content-coding %q", encoding)
+	}
+}
+
+// Encode compresses data with the given coding
+func Encode(encoding string, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := NewWriter(encoding, &buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(data); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode decompresses data encoded with the given coding
+func Decode(encoding string, data []byte) ([]byte, error) {
+	switch encoding {
+	case "", Identity:
+		return data, nil
+	case Gzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	case Brotli:
+		return ioutil.ReadAll(brotli.NewReader(bytes.NewReader(data)))
+	default:
+		return nil, fmt.Errorf("unsupported content-coding %q", encoding)
+	}
+}
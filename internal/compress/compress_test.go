@@ -0,0 +1,51 @@
+package compress
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	supported := []string{Brotli, Gzip}
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "Success::server preference on tie", accept: "gzip, deflate, br", want: Brotli},
+		{name: "Success::q-values honoured", accept: "br;q=0.1, gzip;q=0.8", want: Gzip},
+		{name: "Success::refused coding", accept: "br;q=0, gzip", want: Gzip},
+		{name: "Success::wildcard", accept: "*", want: Brotli},
+		{name: "Success::nothing acceptable", accept: "deflate", want: Identity},
+		{name: "Success::no header", accept: "", want: Identity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.accept, supported); got != tt.want {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	data := []byte("some article content some article content")
+	for _, encoding := range []string{Gzip, Brotli} {
+		t.Run(encoding, func(t *testing.T) {
+			encoded, err := Encode(encoding, data)
+			if err != nil {
+				t.Fatalf("Want: %v, Got: %v", nil, err)
+			}
+			decoded, err := Decode(encoding, encoded)
+			if err != nil {
+				t.Fatalf("Want: %v, Got: %v", nil, err)
+			}
+			if !reflect.DeepEqual(data, decoded) {
+				t.Errorf("Want: %v, Got: %v", string(data), string(decoded))
+			}
+		})
+	}
+	if _, err := Encode("deflate", data); err == nil {
+		t.Errorf("Want: %v, Got: %v", "error", nil)
+	}
+}
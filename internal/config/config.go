@@ -12,9 +12,17 @@ import (
 )
 
 type Config struct {
-	ServerConfig ServerConfig `json:"server_config"`
-	DataBase     DbCfg        `json:"data_source"`
-	Cacher       CacheConfig  `json:"cacher"`
+	ServerConfig ServerConfig      `json:"server_config"`
+	DataBase     DbCfg             `json:"data_source"`
+	Cacher       CacheConfig       `json:"cacher"`
+	Compression  CompressionConfig `json:"compression"`
+	Auth         AuthConfig        `json:"auth"`
+	RateLimit    RateLimitConfig   `json:"rate_limit"`
+	Idempotency  IdempotencyConfig `json:"idempotency"`
+	Scheduler    SchedulerConfig   `json:"scheduler"`
+	Views        ViewsConfig       `json:"views"`
+	Related      RelatedConfig     `json:"related"`
+	Media        MediaConfig       `json:"media"`
 }
 
 type SvcConfig struct {
@@ -46,6 +54,28 @@ type DbCfg struct {
 	Pass      string `json:"dbPass"`
 	DbName    string `json:"dbName"`
 	TableName string `json:"tableName"`
+	// ApiKeyTableName defaults to api_keys
+	ApiKeyTableName string `json:"apiKeyTableName"`
+	// LeaseTableName defaults to scheduler_leases
+	LeaseTableName string `json:"leaseTableName"`
+	// RevisionTableName defaults to article_revisions
+	RevisionTableName string `json:"revisionTableName"`
+	// TagTableName defaults to article_tags
+	TagTableName string `json:"tagTableName"`
+	// CategoryTableName defaults to categories
+	CategoryTableName string `json:"categoryTableName"`
+	// AuthorTableName defaults to authors
+	AuthorTableName string `json:"authorTableName"`
+	// SlugTableName defaults to article_slugs
+	SlugTableName string `json:"slugTableName"`
+	// CommentTableName defaults to comments
+	CommentTableName string `json:"commentTableName"`
+	// ViewTableName defaults to article_views
+	ViewTableName string `json:"viewTableName"`
+	// MediaTableName defaults to media
+	MediaTableName string `json:"mediaTableName"`
+	// TranslationTableName defaults to article_translations
+	TranslationTableName string `json:"translationTableName"`
 }
 
 type CacheConfig struct {
@@ -59,6 +89,145 @@ type CacheConfig struct {
 	MinIdleConns      int           `json:"min_idle_conns"`
 	KeyExpiry         string        `json:"key_expiry"`
 	KeyExpiryDuration time.Duration
+	// RouteExpiry overrides KeyExpiry per route, keyed by the route's path template e.g. /articles/{id}
+	RouteExpiry         map[string]string `json:"route_expiry"`
+	RouteExpiryDuration map[string]time.Duration
+	// KeyPrefix and KeyVersion namespace every cache key; bumping KeyVersion on deploy invalidates all entries
+	KeyPrefix  string `json:"key_prefix"`
+	KeyVersion string `json:"key_version"`
+	// QueryParams whitelists the query parameters that take part in the cache key, all are kept when empty
+	QueryParams []string `json:"query_params"`
+	// VaryHeaders lists the request headers whose values take part in the cache key
+	VaryHeaders []string `json:"vary_headers"`
+	// MaxCacheableBytes skips caching responses whose body is larger, defaults to 1MiB
+	MaxCacheableBytes int64 `json:"max_cacheable_bytes"`
+	// BypassKey lets callers presenting it in X-Cache-Bypass-Key skip the cache with Cache-Control: no-cache/no-store
+	BypassKey string           `json:"bypass_key"`
+	Local     LocalCacheConfig `json:"local"`
+}
+
+// LocalCacheConfig struct defines the in-process cache tier kept in front of redis
+type LocalCacheConfig struct {
+	Enabled             bool   `json:"enabled"`
+	MaxEntries          int    `json:"max_entries"`
+	MaxBytes            int64  `json:"max_bytes"`
+	TTL                 string `json:"ttl"`
+	TTLDuration         time.Duration
+	InvalidationChannel string `json:"invalidation_channel"`
+}
+
+// CompressionConfig struct defines how responses are compressed
+type CompressionConfig struct {
+	Enabled bool `json:"enabled"`
+	// Encodings lists the supported content-codings in order of preference e.g. ["br", "gzip"]
+	Encodings []string `json:"encodings"`
+	// MinSize is the smallest body, in bytes, that is compressed on the wire or in the cache
+	MinSize int `json:"min_size"`
+}
+
+// AuthConfig struct defines how callers are authenticated
+type AuthConfig struct {
+	// JwtSecret is the shared secret of HS256 tokens
+	JwtSecret string `json:"jwt_secret"`
+	// JwksFile is a local JSON Web Key Set holding the public keys of RS256/ES256 tokens
+	JwksFile string `json:"jwks_file"`
+	Issuer   string `json:"issuer"`
+	Audience string `json:"audience"`
+	// RequireAuthForReads makes GET endpoints require authentication too, they are public by default
+	RequireAuthForReads bool `json:"require_auth_for_reads"`
+}
+
+// RateLimitConfig struct defines the token buckets applied per client, reads and writes are budgeted separately
+type RateLimitConfig struct {
+	Enabled bool `json:"enabled"`
+	// KeyPrefix namespaces the bucket keys in redis
+	KeyPrefix string `json:"key_prefix"`
+	// TrustForwardedFor identifies anonymous clients by the first X-Forwarded-For address instead of the peer address
+	TrustForwardedFor bool      `json:"trust_forwarded_for"`
+	Read              RateLimit `json:"read"`
+	Write             RateLimit `json:"write"`
+	// Auth budgets the requests presenting an API key or bearer token per client address, before they are verified
+	Auth RateLimit `json:"auth"`
+	// Routes overrides Read and Write per route, keyed by the route's path template e.g. /articles/{id}
+	Routes map[string]RouteRateLimit `json:"routes"`
+}
+
+type RouteRateLimit struct {
+	Read  *RateLimit `json:"read"`
+	Write *RateLimit `json:"write"`
+}
+
+// RateLimit allows Requests per Period with bursts of up to Burst requests, Burst defaults to Requests
+type RateLimit struct {
+	Requests       int    `json:"requests"`
+	Period         string `json:"period"`
+	PeriodDuration time.Duration
+	Burst          int `json:"burst"`
+}
+
+// IdempotencyConfig struct defines how long responses to requests carrying an Idempotency-Key are kept for replay
+type IdempotencyConfig struct {
+	Enabled        bool   `json:"enabled"`
+	Window         string `json:"window"`
+	WindowDuration time.Duration
+}
+
+// SchedulerConfig struct defines the background job publishing and withdrawing scheduled articles.
+// Instances compete for a lease in the database so that only one of them runs the job at a time.
+type SchedulerConfig struct {
+	Enabled          bool   `json:"enabled"`
+	Interval         string `json:"interval"`
+	IntervalDuration time.Duration
+	// LeaseTTL is how long a crashed instance keeps the lease, it should be a few intervals long
+	LeaseTTL         string `json:"lease_ttl"`
+	LeaseTTLDuration time.Duration
+}
+
+// ViewsConfig struct defines how views of articles are counted in redis and added to the database
+type ViewsConfig struct {
+	Enabled bool `json:"enabled"`
+	// KeyPrefix namespaces the view counters in redis
+	KeyPrefix string `json:"key_prefix"`
+	// DedupeWindow is how long repeated views of an article by the same client count once
+	DedupeWindow         string `json:"dedupe_window"`
+	DedupeWindowDuration time.Duration
+	// FlushInterval is how often the views counted since the last flush are added to the database
+	FlushInterval         string `json:"flush_interval"`
+	FlushIntervalDuration time.Duration
+}
+
+// RelatedConfig struct defines how often every instance rebuilds its in-process index of related articles
+type RelatedConfig struct {
+	RefreshInterval         string `json:"refresh_interval"`
+	RefreshIntervalDuration time.Duration
+}
+
+// MediaConfig struct defines the files that may be attached to articles and where they are stored
+type MediaConfig struct {
+	// MaxUploadBytes bounds the size of an uploaded file, defaults to 10MiB
+	MaxUploadBytes int64 `json:"max_upload_bytes"`
+	// AllowedTypes lists the accepted content types, as sniffed from the file and not as declared by the client
+	AllowedTypes []string `json:"allowed_types"`
+	// ThumbnailSize is the longest side in pixels of the thumbnails made of images, defaults to 320
+	ThumbnailSize int        `json:"thumbnail_size"`
+	Storage       BlobConfig `json:"storage"`
+}
+
+// BlobConfig struct selects the storage of uploaded files: the local filesystem below Dir or an S3 compatible service
+type BlobConfig struct {
+	// Driver is local or s3, local by default
+	Driver string   `json:"driver"`
+	Dir    string   `json:"dir"`
+	S3     S3Config `json:"s3"`
+}
+
+// S3Config struct defines the bucket of an S3 compatible service, addressed path-style at Endpoint
+type S3Config struct {
+	Endpoint  string `json:"endpoint"`
+	Region    string `json:"region"`
+	Bucket    string `json:"bucket"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
 }
 
 func LoadFromJson(filepath string, cfg interface{}) error {
@@ -106,6 +275,72 @@ func InitSvcConfig(cfg Config) *SvcConfig {
 		panic(err.Error())
 	}
 	cfg.Cacher.KeyExpiryDuration = duration
+	for route, expiry := range cfg.Cacher.RouteExpiry {
+		duration, err = time.ParseDuration(expiry)
+		if err != nil {
+			panic(err.Error())
+		}
+		if cfg.Cacher.RouteExpiryDuration == nil {
+			cfg.Cacher.RouteExpiryDuration = map[string]time.Duration{}
+		}
+		cfg.Cacher.RouteExpiryDuration[route] = duration
+	}
+	if cfg.Cacher.Local.TTL != "" {
+		duration, err = time.ParseDuration(cfg.Cacher.Local.TTL)
+		if err != nil {
+			panic(err.Error())
+		}
+		cfg.Cacher.Local.TTLDuration = duration
+	}
+	parseRateLimit(&cfg.RateLimit.Read)
+	parseRateLimit(&cfg.RateLimit.Write)
+	parseRateLimit(&cfg.RateLimit.Auth)
+	for _, route := range cfg.RateLimit.Routes {
+		parseRateLimit(route.Read)
+		parseRateLimit(route.Write)
+	}
+	if cfg.Idempotency.Window != "" {
+		duration, err = time.ParseDuration(cfg.Idempotency.Window)
+		if err != nil {
+			panic(err.Error())
+		}
+		cfg.Idempotency.WindowDuration = duration
+	}
+	if cfg.Scheduler.Interval != "" {
+		duration, err = time.ParseDuration(cfg.Scheduler.Interval)
+		if err != nil {
+			panic(err.Error())
+		}
+		cfg.Scheduler.IntervalDuration = duration
+	}
+	if cfg.Scheduler.LeaseTTL != "" {
+		duration, err = time.ParseDuration(cfg.Scheduler.LeaseTTL)
+		if err != nil {
+			panic(err.Error())
+		}
+		cfg.Scheduler.LeaseTTLDuration = duration
+	}
+	if cfg.Views.DedupeWindow != "" {
+		duration, err = time.ParseDuration(cfg.Views.DedupeWindow)
+		if err != nil {
+			panic(err.Error())
+		}
+		cfg.Views.DedupeWindowDuration = duration
+	}
+	if cfg.Views.FlushInterval != "" {
+		duration, err = time.ParseDuration(cfg.Views.FlushInterval)
+		if err != nil {
+			panic(err.Error())
+		}
+		cfg.Views.FlushIntervalDuration = duration
+	}
+	if cfg.Related.RefreshInterval != "" {
+		duration, err = time.ParseDuration(cfg.Related.RefreshInterval)
+		if err != nil {
+			panic(err.Error())
+		}
+		cfg.Related.RefreshIntervalDuration = duration
+	}
 	return &SvcConfig{
 		Cfg:       &cfg,
 		SvrCfg:    cfg.ServerConfig,
@@ -113,3 +348,102 @@ func InitSvcConfig(cfg Config) *SvcConfig {
 		DbSvc:     DbSvc{Db: dataBase},
 	}
 }
+
+// ApiKeyTable returns the name of the api key table
+func (c DbCfg) ApiKeyTable() string {
+	if c.ApiKeyTableName == "" {
+		return "api_keys"
+	}
+	return c.ApiKeyTableName
+}
+
+// LeaseTable returns the name of the scheduler lease table
+func (c DbCfg) LeaseTable() string {
+	if c.LeaseTableName == "" {
+		return "scheduler_leases"
+	}
+	return c.LeaseTableName
+}
+
+// RevisionTable returns the name of the article revision table
+func (c DbCfg) RevisionTable() string {
+	if c.RevisionTableName == "" {
+		return "article_revisions"
+	}
+	return c.RevisionTableName
+}
+
+// TagTable returns the name of the article tag table
+func (c DbCfg) TagTable() string {
+	if c.TagTableName == "" {
+		return "article_tags"
+	}
+	return c.TagTableName
+}
+
+// CategoryTable returns the name of the category table
+func (c DbCfg) CategoryTable() string {
+	if c.CategoryTableName == "" {
+		return "categories"
+	}
+	return c.CategoryTableName
+}
+
+// AuthorTable returns the name of the author profile table
+func (c DbCfg) AuthorTable() string {
+	if c.AuthorTableName == "" {
+		return "authors"
+	}
+	return c.AuthorTableName
+}
+
+// SlugTable returns the name of the table keeping the former slugs of articles
+func (c DbCfg) SlugTable() string {
+	if c.SlugTableName == "" {
+		return "article_slugs"
+	}
+	return c.SlugTableName
+}
+
+// CommentTable returns the name of the article comment table
+func (c DbCfg) CommentTable() string {
+	if c.CommentTableName == "" {
+		return "comments"
+	}
+	return c.CommentTableName
+}
+
+// ViewTable returns the name of the table keeping the total views of articles
+func (c DbCfg) ViewTable() string {
+	if c.ViewTableName == "" {
+		return "article_views"
+	}
+	return c.ViewTableName
+}
+
+// MediaTable returns the name of the table of files attached to articles
+func (c DbCfg) MediaTable() string {
+	if c.MediaTableName == "" {
+		return "media"
+	}
+	return c.MediaTableName
+}
+
+// TranslationTable returns the name of the table of the translations of articles
+func (c DbCfg) TranslationTable() string {
+	if c.TranslationTableName == "" {
+		return "article_translations"
+	}
+	return c.TranslationTableName
+}
+
+func parseRateLimit(l *RateLimit) {
+	if l == nil || l.Period == "" {
+		return
+	}
+	duration, err := time.ParseDuration(l.Period)
+	if err != nil {
+		panic(err.Error())
+	}
+	l.PeriodDuration = duration
+}
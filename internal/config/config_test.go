@@ -45,7 +45,11 @@ func TestInitSvcConfig(t *testing.T) {
 							Driver: "sqlmock",
 							DbName: "newTemp",
 						},
-						Cacher: CacheConfig{KeyExpiry: "1m"},
+						Cacher: CacheConfig{KeyExpiry: "1m", RouteExpiry: map[string]string{"/articles": "30s"}},
+						RateLimit: RateLimitConfig{
+							Read:   RateLimit{Requests: 60, Period: "1m"},
+							Routes: map[string]RouteRateLimit{"/articles": {Write: &RateLimit{Requests: 5, Period: "10s"}}},
+						},
 					},
 				}
 			},
@@ -57,7 +61,16 @@ func TestInitSvcConfig(t *testing.T) {
 							Driver: "sqlmock",
 							DbName: "newTemp",
 						},
-						Cacher: CacheConfig{KeyExpiry: "1m", KeyExpiryDuration: time.Minute},
+						Cacher: CacheConfig{
+							KeyExpiry:           "1m",
+							KeyExpiryDuration:   time.Minute,
+							RouteExpiry:         map[string]string{"/articles": "30s"},
+							RouteExpiryDuration: map[string]time.Duration{"/articles": 30 * time.Second},
+						},
+						RateLimit: RateLimitConfig{
+							Read:   RateLimit{Requests: 60, Period: "1m", PeriodDuration: time.Minute},
+							Routes: map[string]RouteRateLimit{"/articles": {Write: &RateLimit{Requests: 5, Period: "10s", PeriodDuration: 10 * time.Second}}},
+						},
 					},
 					SvrCfg: ServerConfig{},
 				}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/go-playground/validator"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_apikey_handler.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/handler ApiKeyHandlerI
+
+type ApiKeyHandlerI interface {
+	CreateApiKey(w http.ResponseWriter, r *http.Request)
+	ListApiKeys(w http.ResponseWriter, r *http.Request)
+	RotateApiKey(w http.ResponseWriter, r *http.Request)
+	RevokeApiKey(w http.ResponseWriter, r *http.Request)
+}
+
+type apiKeyManagement struct {
+	logic logic.ApiKeyLogicI
+}
+
+func NewApiKeyHandlerI(ds datasource.ApiKeyDataSourceI) ApiKeyHandlerI {
+	return &apiKeyManagement{
+		logic: logic.NewApiKeyLogicI(ds),
+	}
+}
+
+func (svc apiKeyManagement) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	bytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrReadingReqBody),
+			Data:    nil,
+		})
+		return
+	}
+	var req model.ApiKeyRequest
+	err = json.Unmarshal(bytes, &req)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrUnmarshall),
+			Data:    nil,
+		})
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+			Data:    nil,
+		})
+		return
+	}
+	writeResponse(w, svc.logic.CreateApiKey(&req))
+}
+
+func (svc apiKeyManagement) ListApiKeys(w http.ResponseWriter, _ *http.Request) {
+	writeResponse(w, svc.logic.ListApiKeys())
+}
+
+func (svc apiKeyManagement) RotateApiKey(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	var req model.ApiKeyRotateRequest
+	bytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrReadingReqBody),
+			Data:    nil,
+		})
+		return
+	}
+	// the body is optional
+	if len(bytes) > 0 {
+		if err = json.Unmarshal(bytes, &req); err != nil {
+			log.Print(err)
+			writeResponse(w, &model.Response{
+				Status:  http.StatusBadRequest,
+				Message: codes.GetErr(codes.ErrUnmarshall),
+				Data:    nil,
+			})
+			return
+		}
+	}
+	writeResponse(w, svc.logic.RotateApiKey(id, &req))
+}
+
+func (svc apiKeyManagement) RevokeApiKey(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	writeResponse(w, svc.logic.RevokeApiKey(id))
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_ApiKeyManagement_CreateApiKey(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(*mock.MockApiKeyLogicI)
+		wantStatus int
+	}{
+		{
+			name: "Success:: created",
+			body: `{"name":" ci ","scopes":["articles:write"],"expires_in":"720h"}`,
+			setup: func(l *mock.MockApiKeyLogicI) {
+				l.EXPECT().CreateApiKey(&model.ApiKeyRequest{Name: "ci", Scopes: []string{"articles:write"}, ExpiresIn: "720h"}).
+					Return(&model.Response{Status: http.StatusCreated, Message: "Success", Data: model.ApiKeyCreated{Key: "key"}})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "Failure:: invalid expires_in",
+			body: `{"name":"ci","expires_in":"soon"}`,
+			setup: func(l *mock.MockApiKeyLogicI) {
+				l.EXPECT().CreateApiKey(gomock.Any()).
+					Return(&model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrInvalidExpiry)})
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Failure:: missing name",
+			body:       `{"name":"  "}`,
+			setup:      func(l *mock.MockApiKeyLogicI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Failure:: invalid json",
+			body:       `{"name":`,
+			setup:      func(l *mock.MockApiKeyLogicI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockApiKeyLogicI(mockCtrl)
+			tt.setup(mockLogic)
+			w := httptest.NewRecorder()
+			apiKeyManagement{logic: mockLogic}.CreateApiKey(w, httptest.NewRequest(http.MethodPost, "/admin/api-keys", strings.NewReader(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_ApiKeyManagement_ListApiKeys(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockLogic := mock.NewMockApiKeyLogicI(mockCtrl)
+	mockLogic.EXPECT().ListApiKeys().Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: []model.ApiKeyDs{{Id: "1"}}})
+	w := httptest.NewRecorder()
+	apiKeyManagement{logic: mockLogic}.ListApiKeys(w, httptest.NewRequest(http.MethodGet, "/admin/api-keys", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("Want: %v, Got: %v", http.StatusOK, w.Code)
+	}
+	// key hashes are never sent
+	if strings.Contains(w.Body.String(), "key_hash") {
+		t.Errorf("Want: %v, Got: %v", "no key_hash", w.Body.String())
+	}
+}
+
+func Test_ApiKeyManagement_RotateApiKey(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(*mock.MockApiKeyLogicI)
+		wantStatus int
+	}{
+		{
+			name: "Success:: keeps the remaining lifetime without a body",
+			setup: func(l *mock.MockApiKeyLogicI) {
+				l.EXPECT().RotateApiKey("1", &model.ApiKeyRotateRequest{}).
+					Return(&model.Response{Status: http.StatusCreated, Message: "Success", Data: model.ApiKeyCreated{Key: "key"}})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "Success:: new lifetime",
+			body: `{"expires_in":"720h"}`,
+			setup: func(l *mock.MockApiKeyLogicI) {
+				l.EXPECT().RotateApiKey("1", &model.ApiKeyRotateRequest{ExpiresIn: "720h"}).
+					Return(&model.Response{Status: http.StatusCreated, Message: "Success", Data: model.ApiKeyCreated{Key: "key"}})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "Failure:: revoked key",
+			setup: func(l *mock.MockApiKeyLogicI) {
+				l.EXPECT().RotateApiKey("1", gomock.Any()).
+					Return(&model.Response{Status: http.StatusConflict, Message: codes.GetErr(codes.ErrApiKeyInactive)})
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "Failure:: invalid json",
+			body:       `{"expires_in":`,
+			setup:      func(l *mock.MockApiKeyLogicI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockApiKeyLogicI(mockCtrl)
+			tt.setup(mockLogic)
+			r := mux.SetURLVars(httptest.NewRequest(http.MethodPost, "/admin/api-keys/1/rotate", strings.NewReader(tt.body)), map[string]string{"id": "1"})
+			w := httptest.NewRecorder()
+			apiKeyManagement{logic: mockLogic}.RotateApiKey(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_ApiKeyManagement_RevokeApiKey(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name       string
+		vars       map[string]string
+		setup      func(*mock.MockApiKeyLogicI)
+		wantStatus int
+	}{
+		{
+			name: "Success:: revoked",
+			vars: map[string]string{"id": "1"},
+			setup: func(l *mock.MockApiKeyLogicI) {
+				l.EXPECT().RevokeApiKey("1").Return(&model.Response{Status: http.StatusOK, Message: "Success"})
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Failure:: unknown key",
+			vars: map[string]string{"id": "2"},
+			setup: func(l *mock.MockApiKeyLogicI) {
+				l.EXPECT().RevokeApiKey("2").Return(&model.Response{Status: http.StatusNotFound, Message: codes.GetErr(codes.ErrApiKeyNotFound)})
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Failure:: missing id",
+			setup:      func(l *mock.MockApiKeyLogicI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockApiKeyLogicI(mockCtrl)
+			tt.setup(mockLogic)
+			r := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, "/admin/api-keys/1", nil), tt.vars)
+			w := httptest.NewRecorder()
+			apiKeyManagement{logic: mockLogic}.RevokeApiKey(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
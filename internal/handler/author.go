@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/go-playground/validator"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/authz"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_author_handler.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/handler AuthorHandlerI
+
+type AuthorHandlerI interface {
+	GetAuthors(w http.ResponseWriter, r *http.Request)
+	GetAuthor(w http.ResponseWriter, r *http.Request)
+	InsertAuthor(w http.ResponseWriter, r *http.Request)
+	UpdateAuthor(w http.ResponseWriter, r *http.Request)
+	GetAuthorArticles(w http.ResponseWriter, r *http.Request)
+}
+
+type authorManagement struct {
+	logic  logic.AuthorLogicI
+	policy authz.AuthorPolicyI
+}
+
+func NewAuthorHandlerI(ds datasource.AuthorDataSourceI, articles datasource.DataSourceI) AuthorHandlerI {
+	l := logic.NewAuthorLogicI(ds, articles)
+	return &authorManagement{
+		logic:  l,
+		policy: authz.NewAuthorPolicyI(l),
+	}
+}
+
+func (svc authorManagement) GetAuthors(w http.ResponseWriter, r *http.Request) {
+	writeWithValidators(w, r, svc.logic.GetAuthors(), 0)
+}
+
+func (svc authorManagement) GetAuthor(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	writeWithValidators(w, r, svc.logic.GetAuthor(id), 0)
+}
+
+func (svc authorManagement) InsertAuthor(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeAuthor(w, r)
+	if !ok {
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	writeResponse(w, svc.policy.InsertAuthor(identity, req))
+}
+
+func (svc authorManagement) UpdateAuthor(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	req, ok := decodeAuthor(w, r)
+	if !ok {
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	writeResponse(w, svc.policy.UpdateAuthor(identity, id, req))
+}
+
+func (svc authorManagement) GetAuthorArticles(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	limit, page := pagination(r.URL.Query())
+	writeWithValidators(w, r, svc.logic.GetAuthorArticles(id, limit, page), 0)
+}
+
+func decodeAuthor(w http.ResponseWriter, r *http.Request) (*model.AuthorRequest, bool) {
+	bytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrReadingReqBody),
+			Data:    nil,
+		})
+		return nil, false
+	}
+	var req model.AuthorRequest
+	err = json.Unmarshal(bytes, &req)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrUnmarshall),
+			Data:    nil,
+		})
+		return nil, false
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.AvatarUrl = strings.TrimSpace(req.AvatarUrl)
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+			Data:    nil,
+		})
+		return nil, false
+	}
+	return &req, true
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/authz"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_AuthorManagement_GetAuthor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	found := &model.Response{Status: http.StatusOK, Message: "Success", Data: []model.AuthorDs{{Id: "user-1", Name: "Jane Doe"}}}
+	tests := []struct {
+		name        string
+		ifNoneMatch func(etag string) string
+		setup       func(*mock.MockAuthorLogicI)
+		wantStatus  int
+	}{
+		{
+			name: "Success:: found",
+			setup: func(l *mock.MockAuthorLogicI) {
+				l.EXPECT().GetAuthor("user-1").Return(found)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "Success:: not modified",
+			ifNoneMatch: func(etag string) string { return etag },
+			setup: func(l *mock.MockAuthorLogicI) {
+				l.EXPECT().GetAuthor("user-1").Return(found)
+			},
+			wantStatus: http.StatusNotModified,
+		},
+		{
+			name:        "Success:: changed since",
+			ifNoneMatch: func(string) string { return `"stale"` },
+			setup: func(l *mock.MockAuthorLogicI) {
+				l.EXPECT().GetAuthor("user-1").Return(found)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Failure:: unknown author",
+			setup: func(l *mock.MockAuthorLogicI) {
+				l.EXPECT().GetAuthor("user-1").Return(&model.Response{Status: http.StatusNotFound, Message: codes.GetErr(codes.ErrAuthorNotFound)})
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	// the ETag of the profile, as sent on a first request
+	first := httptest.NewRecorder()
+	l := mock.NewMockAuthorLogicI(mockCtrl)
+	l.EXPECT().GetAuthor("user-1").Return(found)
+	authorManagement{logic: l}.GetAuthor(first, mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/authors/user-1", nil), map[string]string{"id": "user-1"}))
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatalf("Want: %v, Got: %v", "an ETag", etag)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockAuthorLogicI(mockCtrl)
+			tt.setup(mockLogic)
+			r := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/authors/user-1", nil), map[string]string{"id": "user-1"})
+			if tt.ifNoneMatch != nil {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch(etag))
+			}
+			w := httptest.NewRecorder()
+			authorManagement{logic: mockLogic}.GetAuthor(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_AuthorManagement_GetAuthorArticles(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockLogic := mock.NewMockAuthorLogicI(mockCtrl)
+	mockLogic.EXPECT().GetAuthorArticles("user-1", 5, 2).Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: []model.ArticleDs{}})
+	r := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/authors/user-1/articles?limit=5&page=2", nil), map[string]string{"id": "user-1"})
+	w := httptest.NewRecorder()
+	authorManagement{logic: mockLogic}.GetAuthorArticles(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("Want: %v, Got: %v", http.StatusOK, w.Code)
+	}
+}
+
+func Test_AuthorManagement_InsertAuthor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name       string
+		identity   *model.Identity
+		body       string
+		setup      func(*mock.MockAuthorLogicI)
+		wantStatus int
+	}{
+		{
+			name:     "Success:: editor creates a profile",
+			identity: &model.Identity{Subject: "user-2", Roles: []string{authz.RoleEditor}},
+			body:     `{"name":" Jane Doe ","bio":"bio","avatar_url":"https://example.com/jane.png"}`,
+			setup: func(l *mock.MockAuthorLogicI) {
+				l.EXPECT().InsertAuthor(&model.AuthorRequest{Name: "Jane Doe", Bio: "bio", AvatarUrl: "https://example.com/jane.png"}).
+					Return(&model.Response{Status: http.StatusCreated, Message: "Success", Data: map[string]string{"id": "1"}})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "Failure:: without credentials",
+			body:       `{"name":"Jane Doe"}`,
+			setup:      func(l *mock.MockAuthorLogicI) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Failure:: author creates a profile",
+			identity:   &model.Identity{Subject: "user-1", Roles: []string{authz.RoleAuthor}},
+			body:       `{"name":"Jane Doe"}`,
+			setup:      func(l *mock.MockAuthorLogicI) {},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Failure:: invalid avatar url",
+			identity:   &model.Identity{Subject: "user-2", Roles: []string{authz.RoleEditor}},
+			body:       `{"name":"Jane Doe","avatar_url":"not a url"}`,
+			setup:      func(l *mock.MockAuthorLogicI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Failure:: missing name",
+			identity:   &model.Identity{Subject: "user-2", Roles: []string{authz.RoleEditor}},
+			body:       `{"name":"  "}`,
+			setup:      func(l *mock.MockAuthorLogicI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockAuthorLogicI(mockCtrl)
+			tt.setup(mockLogic)
+			r := httptest.NewRequest(http.MethodPost, "/authors", strings.NewReader(tt.body))
+			if tt.identity != nil {
+				r = r.WithContext(auth.WithIdentity(r.Context(), tt.identity))
+			}
+			w := httptest.NewRecorder()
+			authorManagement{logic: mockLogic, policy: authz.NewAuthorPolicyI(mockLogic)}.InsertAuthor(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_AuthorManagement_UpdateAuthor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name       string
+		identity   *model.Identity
+		setup      func(*mock.MockAuthorLogicI)
+		wantStatus int
+	}{
+		{
+			name:     "Success:: author edits own profile",
+			identity: &model.Identity{Subject: "user-1", Roles: []string{authz.RoleAuthor}},
+			setup: func(l *mock.MockAuthorLogicI) {
+				l.EXPECT().UpdateAuthor("user-1", &model.AuthorRequest{Name: "Jane Doe", Bio: "bio"}).
+					Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: map[string]string{"id": "user-1"}})
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:     "Success:: editor edits any profile",
+			identity: &model.Identity{Subject: "user-2", Roles: []string{authz.RoleEditor}},
+			setup: func(l *mock.MockAuthorLogicI) {
+				l.EXPECT().UpdateAuthor("user-1", gomock.Any()).
+					Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: map[string]string{"id": "user-1"}})
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Failure:: author edits another profile",
+			identity:   &model.Identity{Subject: "user-3", Roles: []string{authz.RoleAuthor}},
+			setup:      func(l *mock.MockAuthorLogicI) {},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Failure:: reader edits own profile",
+			identity:   &model.Identity{Subject: "user-1", Roles: []string{authz.RoleReader}},
+			setup:      func(l *mock.MockAuthorLogicI) {},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Failure:: without credentials",
+			setup:      func(l *mock.MockAuthorLogicI) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockAuthorLogicI(mockCtrl)
+			tt.setup(mockLogic)
+			r := httptest.NewRequest(http.MethodPut, "/authors/user-1", strings.NewReader(`{"name":"Jane Doe","bio":"bio"}`))
+			if tt.identity != nil {
+				r = r.WithContext(auth.WithIdentity(r.Context(), tt.identity))
+			}
+			r = mux.SetURLVars(r, map[string]string{"id": "user-1"})
+			w := httptest.NewRecorder()
+			authorManagement{logic: mockLogic, policy: authz.NewAuthorPolicyI(mockLogic)}.UpdateAuthor(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/go-playground/validator"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_category_handler.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/handler CategoryHandlerI
+
+type CategoryHandlerI interface {
+	GetCategories(w http.ResponseWriter, r *http.Request)
+	GetCategory(w http.ResponseWriter, r *http.Request)
+	InsertCategory(w http.ResponseWriter, r *http.Request)
+	UpdateCategory(w http.ResponseWriter, r *http.Request)
+	DeleteCategory(w http.ResponseWriter, r *http.Request)
+	AssignCategory(w http.ResponseWriter, r *http.Request)
+	GetCategoryArticles(w http.ResponseWriter, r *http.Request)
+}
+
+type categoryManagement struct {
+	logic logic.CategoryLogicI
+}
+
+func NewCategoryHandlerI(ds datasource.CategoryDataSourceI, articles datasource.DataSourceI) CategoryHandlerI {
+	return &categoryManagement{
+		logic: logic.NewCategoryLogicI(ds, articles),
+	}
+}
+
+func (svc categoryManagement) GetCategories(w http.ResponseWriter, r *http.Request) {
+	writeWithValidators(w, r, svc.logic.GetCategories(), 0)
+}
+
+func (svc categoryManagement) GetCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	writeWithValidators(w, r, svc.logic.GetCategory(id), 0)
+}
+
+func (svc categoryManagement) InsertCategory(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeCategory(w, r)
+	if !ok {
+		return
+	}
+	writeResponse(w, svc.logic.InsertCategory(req))
+}
+
+func (svc categoryManagement) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	req, ok := decodeCategory(w, r)
+	if !ok {
+		return
+	}
+	writeResponse(w, svc.logic.UpdateCategory(id, req))
+}
+
+func (svc categoryManagement) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	writeResponse(w, svc.logic.DeleteCategory(id))
+}
+
+// AssignCategory sets the primary category of an article, or clears it when category_id is null
+func (svc categoryManagement) AssignCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	bytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrReadingReqBody),
+			Data:    nil,
+		})
+		return
+	}
+	var req model.AssignCategoryRequest
+	err = json.Unmarshal(bytes, &req)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrUnmarshall),
+			Data:    nil,
+		})
+		return
+	}
+	writeResponse(w, svc.logic.AssignCategory(id, &req))
+}
+
+// GetCategoryArticles lists the published articles of a category, with ?include_descendants=true adding its subcategories
+func (svc categoryManagement) GetCategoryArticles(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	queryParams := r.URL.Query()
+	limit, page := pagination(queryParams)
+	descendants, _ := strconv.ParseBool(queryParams.Get("include_descendants"))
+	writeWithValidators(w, r, svc.logic.GetCategoryArticles(id, limit, page, descendants), 0)
+}
+
+func decodeCategory(w http.ResponseWriter, r *http.Request) (*model.CategoryRequest, bool) {
+	bytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrReadingReqBody),
+			Data:    nil,
+		})
+		return nil, false
+	}
+	var req model.CategoryRequest
+	err = json.Unmarshal(bytes, &req)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrUnmarshall),
+			Data:    nil,
+		})
+		return nil, false
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+			Data:    nil,
+		})
+		return nil, false
+	}
+	return &req, true
+}
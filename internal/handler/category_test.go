@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_CategoryManagement_GetCategoryArticles(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name   string
+		target string
+		setup  func(*mock.MockCategoryLogicI)
+	}{
+		{
+			name:   "Success:: defaults",
+			target: "/categories/news/articles",
+			setup: func(l *mock.MockCategoryLogicI) {
+				l.EXPECT().GetCategoryArticles("news", 20, 1, false).Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: []model.ArticleDs{}})
+			},
+		},
+		{
+			name:   "Success:: descendants and paging",
+			target: "/categories/news/articles?include_descendants=true&limit=5&page=3",
+			setup: func(l *mock.MockCategoryLogicI) {
+				l.EXPECT().GetCategoryArticles("news", 5, 3, true).Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: []model.ArticleDs{}})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockCategoryLogicI(mockCtrl)
+			tt.setup(mockLogic)
+			r := mux.SetURLVars(httptest.NewRequest(http.MethodGet, tt.target, nil), map[string]string{"id": "news"})
+			w := httptest.NewRecorder()
+			categoryManagement{logic: mockLogic}.GetCategoryArticles(w, r)
+			if w.Code != http.StatusOK {
+				t.Errorf("Want: %v, Got: %v", http.StatusOK, w.Code)
+			}
+		})
+	}
+}
+
+func Test_CategoryManagement_InsertCategory(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(*mock.MockCategoryLogicI)
+		wantStatus int
+	}{
+		{
+			name: "Success:: created",
+			body: `{"name":" Go ","parent_id":"tech"}`,
+			setup: func(l *mock.MockCategoryLogicI) {
+				parent := "tech"
+				l.EXPECT().InsertCategory(&model.CategoryRequest{Name: "Go", ParentId: &parent}).
+					Return(&model.Response{Status: http.StatusCreated, Message: "Success", Data: map[string]string{"id": "1"}})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "Failure:: missing name",
+			body:       `{"name":"  "}`,
+			setup:      func(l *mock.MockCategoryLogicI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockCategoryLogicI(mockCtrl)
+			tt.setup(mockLogic)
+			w := httptest.NewRecorder()
+			categoryManagement{logic: mockLogic}.InsertCategory(w, httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/go-playground/validator"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_comment_handler.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/handler CommentHandlerI
+
+type CommentHandlerI interface {
+	PostComment(w http.ResponseWriter, r *http.Request)
+	GetComments(w http.ResponseWriter, r *http.Request)
+	GetModerationQueue(w http.ResponseWriter, r *http.Request)
+	ModerateComment(w http.ResponseWriter, r *http.Request)
+}
+
+type commentManagement struct {
+	logic logic.CommentLogicI
+}
+
+func NewCommentHandlerI(ds datasource.CommentDataSourceI, articles datasource.DataSourceI) CommentHandlerI {
+	return &commentManagement{
+		logic: logic.NewCommentLogicI(ds, articles),
+	}
+}
+
+// PostComment adds a comment by the caller, attributed like articles to the caller's name or subject
+func (svc commentManagement) PostComment(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	bytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrReadingReqBody),
+			Data:    nil,
+		})
+		return
+	}
+	var req model.CommentRequest
+	err = json.Unmarshal(bytes, &req)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrUnmarshall),
+			Data:    nil,
+		})
+		return
+	}
+	req.Body = strings.TrimSpace(req.Body)
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+			Data:    nil,
+		})
+		return
+	}
+	identity, ok := auth.IdentityFrom(r.Context())
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusUnauthorized,
+			Message: codes.GetErr(codes.ErrUnauthorized),
+			Data:    nil,
+		})
+		return
+	}
+	req.AuthorId = identity.Subject
+	req.Author = identity.Name
+	if req.Author == "" {
+		req.Author = identity.Subject
+	}
+	writeResponse(w, svc.logic.PostComment(id, &req))
+}
+
+func (svc commentManagement) GetComments(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	limit, page := pagination(r.URL.Query())
+	writeWithValidators(w, r, svc.logic.GetComments(id, limit, page), 0)
+}
+
+// GetModerationQueue lists the pending comments, or those in another ?status
+func (svc commentManagement) GetModerationQueue(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	status := queryParams.Get("status")
+	if status == "" {
+		status = model.CommentPending
+	}
+	limit, page := pagination(queryParams)
+	writeResponse(w, svc.logic.GetModerationQueue(status, limit, page))
+}
+
+func (svc commentManagement) ModerateComment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	commentId, hasComment := vars["comment"]
+	if !ok || !hasComment {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	writeResponse(w, svc.logic.ModerateComment(id, commentId, vars["action"]))
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_CommentManagement_PostComment(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name       string
+		body       string
+		identity   *model.Identity
+		setup      func(*mock.MockCommentLogicI)
+		wantStatus int
+	}{
+		{
+			name:     "Success:: attributed to the caller",
+			body:     `{"body":" Nice read ","author_id":"someone-else"}`,
+			identity: &model.Identity{Subject: "user-1", Name: "Jane"},
+			setup: func(l *mock.MockCommentLogicI) {
+				l.EXPECT().PostComment("1", &model.CommentRequest{Body: "Nice read", AuthorId: "user-1", Author: "Jane"}).
+					Return(&model.Response{Status: http.StatusCreated, Message: "Success"})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "Failure:: empty body",
+			body:       `{"body":"   "}`,
+			identity:   &model.Identity{Subject: "user-1"},
+			setup:      func(l *mock.MockCommentLogicI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockCommentLogicI(mockCtrl)
+			tt.setup(mockLogic)
+			r := httptest.NewRequest(http.MethodPost, "/articles/1/comments", strings.NewReader(tt.body))
+			r = mux.SetURLVars(r.WithContext(auth.WithIdentity(r.Context(), tt.identity)), map[string]string{"id": "1"})
+			w := httptest.NewRecorder()
+			commentManagement{logic: mockLogic}.PostComment(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
@@ -1,19 +1,26 @@
 package handler
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/go-playground/validator"
 	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/authz"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/httpcache"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/i18n"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 //go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_handler.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/handler ArticleManagementHandlerI
@@ -23,16 +30,25 @@ type ArticleManagementHandlerI interface {
 	RouteNotFound(http.ResponseWriter, *http.Request)
 	InsertArticle(http.ResponseWriter, *http.Request)
 	GetArticleById(w http.ResponseWriter, r *http.Request)
+	GetArticleBySlug(w http.ResponseWriter, r *http.Request)
 	GetAllArticle(w http.ResponseWriter, r *http.Request)
+	UpdateArticle(w http.ResponseWriter, r *http.Request)
+	TransitionArticle(w http.ResponseWriter, r *http.Request)
+	ScheduleArticle(w http.ResponseWriter, r *http.Request)
+	GetRevisions(w http.ResponseWriter, r *http.Request)
+	GetRevision(w http.ResponseWriter, r *http.Request)
+	DiffRevisions(w http.ResponseWriter, r *http.Request)
+	RestoreRevision(w http.ResponseWriter, r *http.Request)
+	GetTags(w http.ResponseWriter, r *http.Request)
 }
 
 type articleManagement struct {
-	logic logic.ArticleManagementLogicI
+	policy authz.ArticlePolicyI
 }
 
-func NewArticleManagementHandlerI(ds datasource.DataSourceI) ArticleManagementHandlerI {
+func NewArticleManagementHandlerI(ds datasource.DataSourceI, revisions datasource.RevisionDataSourceI) ArticleManagementHandlerI {
 	svc := &articleManagement{
-		logic: logic.NewArticleManagementLogicI(ds),
+		policy: authz.NewArticlePolicyI(logic.NewArticleManagementLogicI(ds, revisions)),
 	}
 	return svc
 }
@@ -58,56 +74,148 @@ func (a articleManagement) RouteNotFound(w http.ResponseWriter, _ *http.Request)
 }
 
 func (svc articleManagement) InsertArticle(w http.ResponseWriter, r *http.Request) {
+	article, ok := decodeArticle(w, r)
+	if !ok {
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	resp := svc.policy.InsertArticle(identity, article)
+	writeResponse(w, resp)
+}
+
+func (svc articleManagement) UpdateArticle(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+	article, ok := decodeArticle(w, r)
+	if !ok {
+		return
+	}
+	article.Version = version
+	identity, _ := auth.IdentityFrom(r.Context())
+	resp := svc.policy.UpdateArticle(identity, id, article)
+	if resp.Status == http.StatusOK {
+		w.Header().Set("ETag", httpcache.VersionETag(version+1))
+	}
+	writeResponse(w, resp)
+}
+
+// ifMatchVersion returns the article version named by the If-Match header, writing the error response when it is
+// missing or names none. The caller must show which version it changed so concurrent edits are not silently lost.
+func ifMatchVersion(w http.ResponseWriter, r *http.Request) (int, bool) {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusPreconditionRequired,
+			Message: codes.GetErr(codes.ErrPreconditionRequired),
+			Data:    nil,
+		})
+		return 0, false
+	}
+	version, ok := httpcache.IfMatchVersion(ifMatch)
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusPreconditionFailed,
+			Message: codes.GetErr(codes.ErrVersionMismatch),
+			Data:    nil,
+		})
+		return 0, false
+	}
+	return version, true
+}
+
+// TransitionArticle applies the workflow action named in the path e.g. POST /articles/{id}/submit
+func (svc articleManagement) TransitionArticle(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	resp := svc.policy.TransitionArticle(identity, id, vars["action"])
+	writeResponse(w, resp)
+}
+
+// ScheduleArticle sets the publish_at and unpublish_at times of an article, a missing time clears it
+func (svc articleManagement) ScheduleArticle(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	var req model.ScheduleRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrUnmarshall),
+			Data:    nil,
+		})
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	resp := svc.policy.ScheduleArticle(identity, id, &req)
+	writeResponse(w, resp)
+}
+
+// decodeArticle reads and validates the article in the request body, writing the error response when it is invalid
+func decodeArticle(w http.ResponseWriter, r *http.Request) (*model.Article, bool) {
 	bytes, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		log.Print(err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(&model.Response{
+		writeResponse(w, &model.Response{
 			Status:  http.StatusBadRequest,
 			Message: codes.GetErr(codes.ErrReadingReqBody),
 			Data:    nil,
 		})
-		return
+		return nil, false
 	}
 	var article model.Article
 	err = json.Unmarshal(bytes, &article)
 	if err != nil {
 		log.Print(err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(&model.Response{
+		writeResponse(w, &model.Response{
 			Status:  http.StatusBadRequest,
 			Message: codes.GetErr(codes.ErrUnmarshall),
 			Data:    nil,
 		})
-		return
+		return nil, false
 	}
 	//removed blank spaces
 	article.Title = strings.Trim(article.Title, " ")
-	article.Author = strings.Trim(article.Author, " ")
 	article.Content = strings.Trim(article.Content, " ")
 
 	validate := validator.New()
 	if err := validate.Struct(article); err != nil {
 		log.Print(err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(&model.Response{
+		writeResponse(w, &model.Response{
 			Status:  http.StatusBadRequest,
 			Message: err.Error(),
 			Data:    nil,
 		})
-		return
+		return nil, false
 	}
-	resp := svc.logic.InsertArticle(&article)
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(resp.Status)
-	_ = json.NewEncoder(w).Encode(&model.Response{
-		Status:  resp.Status,
-		Message: resp.Message,
-		Data:    resp.Data,
-	})
+	return &article, true
 }
 
 func (svc articleManagement) GetArticleById(w http.ResponseWriter, r *http.Request) {
@@ -123,14 +231,44 @@ func (svc articleManagement) GetArticleById(w http.ResponseWriter, r *http.Reque
 		})
 		return
 	}
-	resp := svc.logic.GetArticle(id)
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(resp.Status)
-	_ = json.NewEncoder(w).Encode(&model.Response{
-		Status:  resp.Status,
-		Message: resp.Message,
-		Data:    resp.Data,
-	})
+	identity, _ := auth.IdentityFrom(r.Context())
+	resp := svc.policy.GetArticle(identity, id)
+	version := 0
+	if articles, _ := resp.Data.([]model.ArticleDs); len(articles) == 1 {
+		version = articles[0].Version
+	}
+	writeWithValidators(w, r, resp, version)
+}
+
+// GetArticleBySlug serves an article by its slug, redirecting former slugs to the current one
+func (svc articleManagement) GetArticleBySlug(w http.ResponseWriter, r *http.Request) {
+	s, ok := mux.Vars(r)["slug"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	resp := svc.policy.GetArticleBySlug(identity, s)
+	articles, _ := resp.Data.([]model.ArticleDs)
+	if resp.Status == http.StatusMovedPermanently && len(articles) == 1 {
+		location := "/articles/by-slug/" + url.PathEscape(articles[0].Slug)
+		w.Header().Set("Location", location)
+		writeResponse(w, &model.Response{
+			Status:  resp.Status,
+			Message: resp.Message,
+			Data:    map[string]string{"id": articles[0].Id, "slug": articles[0].Slug, "location": location},
+		})
+		return
+	}
+	version := 0
+	if len(articles) == 1 {
+		version = articles[0].Version
+	}
+	writeWithValidators(w, r, resp, version)
 }
 
 func (svc articleManagement) GetAllArticle(w http.ResponseWriter, r *http.Request) {
@@ -145,7 +283,50 @@ func (svc articleManagement) GetAllArticle(w http.ResponseWriter, r *http.Reques
 		log.Print(fmt.Sprintf("setting default page as %d", 1))
 		page = 1
 	}
-	resp := svc.logic.GetAllArticle(limit, page)
+	identity, _ := auth.IdentityFrom(r.Context())
+	status := queryParams.Get("status")
+	if !publicStatus(status) {
+		// other statuses are listed for their authors and editors only, even when they have no articles
+		w.Header().Set("Cache-Control", "private, no-cache")
+	}
+	resp := svc.policy.GetAllArticle(identity, limit, page, status, tagFilter(queryParams))
+	writeWithValidators(w, r, resp, 0)
+}
+
+// GetTags lists the tags of published articles with their article counts
+func (svc articleManagement) GetTags(w http.ResponseWriter, r *http.Request) {
+	identity, _ := auth.IdentityFrom(r.Context())
+	resp := svc.policy.GetTags(identity)
+	writeWithValidators(w, r, resp, 0)
+}
+
+// tagFilter reads ?tag=a&tag=b or ?tag=a,b, matching any of the tags unless match=all is given
+func tagFilter(query url.Values) *model.TagFilter {
+	var tags []string
+	for _, value := range query["tag"] {
+		tags = append(tags, strings.Split(value, ",")...)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return &model.TagFilter{Tags: tags, All: query.Get("match") == "all"}
+}
+
+// pagination reads ?limit and ?page, defaulting to the first page of 20
+func pagination(query url.Values) (limit int, page int) {
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	page, err = strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	return limit, page
+}
+
+// writeResponse writes resp as the JSON body with its status
+func writeResponse(w http.ResponseWriter, resp *model.Response) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(resp.Status)
 	_ = json.NewEncoder(w).Encode(&model.Response{
@@ -154,3 +335,170 @@ func (svc articleManagement) GetAllArticle(w http.ResponseWriter, r *http.Reques
 		Data:    resp.Data,
 	})
 }
+
+// writeWithValidators writes resp with a strong ETag and a Last-Modified taken from the newest article,
+// answering 304 when the request's conditional headers are satisfied. The ETag is a hash of the body, naming
+// the article version too when one above zero is given so that it can be sent back in If-Match.
+// Articles are translated into the language the request prefers, when they have a translation into it.
+func writeWithValidators(w http.ResponseWriter, r *http.Request, resp *model.Response, version int) {
+	data, locale, translated := localize(resp.Data, i18n.Preferences(r))
+	var buf bytes.Buffer
+	_ = json.NewEncoder(&buf).Encode(&model.Response{
+		Status:  resp.Status,
+		Message: resp.Message,
+		Data:    selectContent(data, r.URL.Query().Get("render")),
+	})
+	if resp.Status == http.StatusOK {
+		if !published(resp.Data) {
+			// drafts are visible to their author and editors only and must not reach shared caches
+			w.Header().Set("Cache-Control", "private, no-cache")
+		}
+		if locale != "" {
+			w.Header().Set("Content-Language", locale)
+		}
+		etag := httpcache.ETag(buf.Bytes())
+		if version > 0 {
+			etag = httpcache.VersionedETag(version, buf.Bytes())
+			if translated {
+				// translations are not edited through their article
+				etag = httpcache.LocalizedETag(etag, locale)
+			}
+		}
+		lastModified := httpcache.LastModified(lastModifiedOf(resp.Data))
+		if httpcache.NotModified(r, etag, lastModified) {
+			httpcache.WriteNotModified(w, etag, lastModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		if lastModified != "" {
+			w.Header().Set("Last-Modified", lastModified)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.Status)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// Values of ?render choosing the content of the articles in a response, both content and content_html are sent otherwise
+const (
+	renderHtml = "html"
+	renderRaw  = "raw"
+)
+
+// selectContent returns data with content (?render=html) or content_html (?render=raw) left out of its articles
+func selectContent(data interface{}, render string) interface{} {
+	if render != renderHtml && render != renderRaw {
+		return data
+	}
+	pick := func(article *model.ArticleDs) {
+		if render == renderHtml {
+			article.Content = ""
+		} else {
+			article.ContentHtml = ""
+		}
+	}
+	switch articles := data.(type) {
+	case []model.ArticleDs:
+		selected := append([]model.ArticleDs(nil), articles...)
+		for i := range selected {
+			pick(&selected[i])
+		}
+		return selected
+	case []model.PopularArticle:
+		selected := append([]model.PopularArticle(nil), articles...)
+		for i := range selected {
+			pick(&selected[i].ArticleDs)
+		}
+		return selected
+	case []model.RelatedArticle:
+		selected := append([]model.RelatedArticle(nil), articles...)
+		for i := range selected {
+			pick(&selected[i].ArticleDs)
+		}
+		return selected
+	}
+	return data
+}
+
+// localize returns data with its articles translated into the first of preferences they have a translation into,
+// articles without one are left in their own language. The language of a single article is returned with whether
+// it was translated.
+func localize(data interface{}, preferences []string) (interface{}, string, bool) {
+	translated := false
+	translate := func(article *model.ArticleDs) {
+		locale, ok := i18n.Select(preferences, article.Translations)
+		t, found := article.Localized[locale]
+		if !ok || !found {
+			return
+		}
+		article.Title, article.Content, article.ContentFormat, article.ContentHtml = t.Title, t.Content, t.ContentFormat, t.ContentHtml
+		article.Locale = locale
+		translated = true
+	}
+	switch articles := data.(type) {
+	case []model.ArticleDs:
+		if len(preferences) > 0 {
+			articles = append([]model.ArticleDs(nil), articles...)
+			for i := range articles {
+				translate(&articles[i])
+			}
+		}
+		if len(articles) == 1 {
+			return articles, articles[0].Locale, translated
+		}
+		return articles, "", translated
+	case []model.PopularArticle:
+		if len(preferences) == 0 {
+			return data, "", false
+		}
+		articles = append([]model.PopularArticle(nil), articles...)
+		for i := range articles {
+			translate(&articles[i].ArticleDs)
+		}
+		return articles, "", translated
+	case []model.RelatedArticle:
+		if len(preferences) == 0 {
+			return data, "", false
+		}
+		articles = append([]model.RelatedArticle(nil), articles...)
+		for i := range articles {
+			translate(&articles[i].ArticleDs)
+		}
+		return articles, "", translated
+	}
+	return data, "", false
+}
+
+// lastModifiedOf returns the most recent update time among the articles in data
+func lastModifiedOf(data interface{}) time.Time {
+	var latest time.Time
+	articles, ok := data.([]model.ArticleDs)
+	if !ok {
+		return latest
+	}
+	for _, article := range articles {
+		if article.UpdatedAt.After(latest) {
+			latest = article.UpdatedAt
+		}
+	}
+	return latest
+}
+
+// publicStatus reports whether listing articles in status is allowed to anyone
+func publicStatus(status string) bool {
+	return status == "" || status == model.StatusPublished
+}
+
+// published reports whether every article in data is published, or every file in data belongs to a published article
+func published(data interface{}) bool {
+	if list, ok := data.(model.MediaList); ok {
+		return list.Public
+	}
+	articles, _ := data.([]model.ArticleDs)
+	for _, article := range articles {
+		if article.Status != model.StatusPublished {
+			return false
+		}
+	}
+	return true
+}
@@ -6,15 +6,20 @@ import (
 	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/httpcache"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
 	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
 	"io/ioutil"
 
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 type Reader string
@@ -134,13 +139,13 @@ func Test_ArticleManagement_InsertArticle(t *testing.T) {
 		{
 			name: "Success",
 			setup: func() (ArticleManagementHandlerI, *http.Request) {
-				mockLogic := mock.NewMockArticleManagementLogicI(mockCtrl)
+				mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
 				article := model.Article{
 					Title:   "title",
-					Author:  "author",
 					Content: "content",
 				}
-				mockLogic.EXPECT().InsertArticle(&article).
+				identity := &model.Identity{Subject: "user-1", Roles: []string{"author"}}
+				mockPolicy.EXPECT().InsertArticle(identity, &article).
 					Return(&model.Response{
 						Status:  http.StatusCreated,
 						Message: "Success",
@@ -148,14 +153,14 @@ func Test_ArticleManagement_InsertArticle(t *testing.T) {
 					}).Times(1)
 
 				rec := &articleManagement{
-					logic: mockLogic,
+					policy: mockPolicy,
 				}
 				by, err := json.Marshal(article)
 				if err != nil {
 					return nil, nil
 				}
 				r, _ := http.NewRequest("POST", "/articles", bytes.NewBuffer(by))
-				return rec, r
+				return rec, r.WithContext(auth.WithIdentity(r.Context(), identity))
 			},
 			want: func(recorder httptest.ResponseRecorder) {
 				b, err := ioutil.ReadAll(recorder.Body)
@@ -181,14 +186,13 @@ func Test_ArticleManagement_InsertArticle(t *testing.T) {
 		{
 			name: "Failure:: Validate error",
 			setup: func() (ArticleManagementHandlerI, *http.Request) {
-				mockLogic := mock.NewMockArticleManagementLogicI(mockCtrl)
+				mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
 				article := model.Article{
-					Author:  "author",
 					Content: "content",
 				}
 
 				rec := &articleManagement{
-					logic: mockLogic,
+					policy: mockPolicy,
 				}
 				by, err := json.Marshal(article)
 				if err != nil {
@@ -221,9 +225,9 @@ func Test_ArticleManagement_InsertArticle(t *testing.T) {
 		{
 			name: "Failure::readAll error",
 			setup: func() (ArticleManagementHandlerI, *http.Request) {
-				mockLogic := mock.NewMockArticleManagementLogicI(mockCtrl)
+				mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
 				rec := &articleManagement{
-					logic: mockLogic,
+					policy: mockPolicy,
 				}
 				r, _ := http.NewRequest("POST", "/articles", Reader(""))
 				return rec, r
@@ -252,9 +256,9 @@ func Test_ArticleManagement_InsertArticle(t *testing.T) {
 		{
 			name: "Failure::json unmarshall error",
 			setup: func() (ArticleManagementHandlerI, *http.Request) {
-				mockLogic := mock.NewMockArticleManagementLogicI(mockCtrl)
+				mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
 				rec := &articleManagement{
-					logic: mockLogic,
+					policy: mockPolicy,
 				}
 				r, _ := http.NewRequest("POST", "/articles", bytes.NewBuffer([]byte("")))
 				return rec, r
@@ -303,8 +307,8 @@ func Test_ArticleManagement_GetArticleById(t *testing.T) {
 		{
 			name: "Success",
 			setup: func() (ArticleManagementHandlerI, *http.Request) {
-				mockLogic := mock.NewMockArticleManagementLogicI(mockCtrl)
-				mockLogic.EXPECT().GetArticle("1").
+				mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
+				mockPolicy.EXPECT().GetArticle(nil, "1").
 					Return(&model.Response{
 						Status:  http.StatusOK,
 						Message: "Success",
@@ -312,7 +316,7 @@ func Test_ArticleManagement_GetArticleById(t *testing.T) {
 					}).Times(1)
 
 				rec := &articleManagement{
-					logic: mockLogic,
+					policy: mockPolicy,
 				}
 				r, _ := http.NewRequest("GET", "/articles/:1", nil)
 				r = mux.SetURLVars(r, map[string]string{"id": "1"})
@@ -342,9 +346,9 @@ func Test_ArticleManagement_GetArticleById(t *testing.T) {
 		{
 			name: "Failure::invalid id",
 			setup: func() (ArticleManagementHandlerI, *http.Request) {
-				mockLogic := mock.NewMockArticleManagementLogicI(mockCtrl)
+				mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
 				rec := &articleManagement{
-					logic: mockLogic,
+					policy: mockPolicy,
 				}
 				r, _ := http.NewRequest("GET", "/articles/:1", nil)
 				return rec, r
@@ -384,6 +388,7 @@ func Test_ArticleManagement_GetArticleById(t *testing.T) {
 func Test_ArticleManagement_GetAllArticle(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
+	updated := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		name  string
@@ -393,16 +398,16 @@ func Test_ArticleManagement_GetAllArticle(t *testing.T) {
 		{
 			name: "Success",
 			setup: func() (ArticleManagementHandlerI, *http.Request) {
-				mockLogic := mock.NewMockArticleManagementLogicI(mockCtrl)
-				mockLogic.EXPECT().GetAllArticle(20, 1).
+				mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
+				mockPolicy.EXPECT().GetAllArticle(nil, 20, 1, "", nil).
 					Return(&model.Response{
 						Status:  http.StatusOK,
 						Message: "Success",
-						Data:    []model.ArticleDs{{Id: "1", Title: "title", Author: "author", Content: "content"}},
+						Data:    []model.ArticleDs{{Id: "1", Title: "title", Author: "author", Content: "content", Status: model.StatusPublished, UpdatedAt: updated}},
 					}).Times(1)
 
 				rec := &articleManagement{
-					logic: mockLogic,
+					policy: mockPolicy,
 				}
 				r, _ := http.NewRequest("GET", "/articles", nil)
 				return rec, r
@@ -418,12 +423,20 @@ func Test_ArticleManagement_GetAllArticle(t *testing.T) {
 				tempResp := &model.Response{
 					Status:  http.StatusOK,
 					Message: "Success",
-					Data:    []map[string]interface{}{{"author": "author", "content": "content", "id": "1", "title": "title"}},
+					Data:    []map[string]interface{}{{"author": "author", "author_id": "", "content": "content", "content_format": "", "created_at": "0001-01-01T00:00:00Z", "id": "1", "status": "published", "title": "title", "updated_at": "2023-01-02T00:00:00Z", "version": 0, "tags": nil, "category_id": nil, "slug": "", "comment_count": 0, "view_count": 0, "locale": "", "translations": nil}},
 				}
 				if !reflect.DeepEqual(recorder.Code, http.StatusOK) {
 					t.Errorf("Want: %v, Got: %v", http.StatusOK, recorder.Code)
 					return
 				}
+				if !reflect.DeepEqual(recorder.Header().Get("Last-Modified"), "Mon, 02 Jan 2023 00:00:00 GMT") {
+					t.Errorf("Want: %v, Got: %v", "Mon, 02 Jan 2023 00:00:00 GMT", recorder.Header().Get("Last-Modified"))
+					return
+				}
+				if !reflect.DeepEqual(recorder.Header().Get("ETag"), httpcache.ETag(b)) {
+					t.Errorf("Want: %v, Got: %v", httpcache.ETag(b), recorder.Header().Get("ETag"))
+					return
+				}
 				if !reflect.DeepEqual(response.Status, tempResp.Status) {
 					t.Errorf("Want: %v, Got: %v", tempResp.Status, response.Status)
 					return
@@ -440,6 +453,52 @@ func Test_ArticleManagement_GetAllArticle(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Success::empty draft listing is private",
+			setup: func() (ArticleManagementHandlerI, *http.Request) {
+				mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
+				mockPolicy.EXPECT().GetAllArticle(nil, 20, 1, model.StatusDraft, nil).
+					Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: []model.ArticleDs{}}).Times(1)
+
+				rec := &articleManagement{
+					policy: mockPolicy,
+				}
+				r, _ := http.NewRequest("GET", "/articles?status=draft", nil)
+				return rec, r
+			},
+			want: func(recorder httptest.ResponseRecorder) {
+				if got := recorder.Header().Get("Cache-Control"); got != "private, no-cache" {
+					t.Errorf("Want: %v, Got: %v", "private, no-cache", got)
+				}
+			},
+		},
+		{
+			name: "Success::not modified since",
+			setup: func() (ArticleManagementHandlerI, *http.Request) {
+				mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
+				mockPolicy.EXPECT().GetAllArticle(nil, 20, 1, "", nil).
+					Return(&model.Response{
+						Status:  http.StatusOK,
+						Message: "Success",
+						Data:    []model.ArticleDs{{Id: "1", Title: "title", Author: "author", Content: "content", Status: model.StatusPublished, UpdatedAt: updated}},
+					}).Times(1)
+
+				rec := &articleManagement{
+					policy: mockPolicy,
+				}
+				r, _ := http.NewRequest("GET", "/articles", nil)
+				r.Header.Set("If-Modified-Since", "Mon, 02 Jan 2023 00:00:00 GMT")
+				return rec, r
+			},
+			want: func(recorder httptest.ResponseRecorder) {
+				if !reflect.DeepEqual(recorder.Code, http.StatusNotModified) {
+					t.Errorf("Want: %v, Got: %v", http.StatusNotModified, recorder.Code)
+				}
+				if recorder.Body.Len() != 0 {
+					t.Errorf("Want: %v, Got: %v", "", recorder.Body.String())
+				}
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -450,3 +509,305 @@ func Test_ArticleManagement_GetAllArticle(t *testing.T) {
 		})
 	}
 }
+
+func Test_ArticleManagement_UpdateArticle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	identity := &model.Identity{Subject: "user-1", Roles: []string{"author"}}
+
+	tests := []struct {
+		name       string
+		body       string
+		ifMatch    string
+		setup      func(*mock.MockArticlePolicyI)
+		wantStatus int
+		wantETag   string
+	}{
+		{
+			name:    "Success",
+			body:    `{"title":"title","content":"content"}`,
+			ifMatch: `"v3"`,
+			setup: func(mockPolicy *mock.MockArticlePolicyI) {
+				mockPolicy.EXPECT().UpdateArticle(identity, "1", &model.Article{Title: "title", Content: "content", Version: 3}).
+					Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: map[string]string{"id": "1"}})
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"v4"`,
+		},
+		{
+			name:    "Failure:: version mismatch",
+			body:    `{"title":"title","content":"content"}`,
+			ifMatch: `"v3"`,
+			setup: func(mockPolicy *mock.MockArticlePolicyI) {
+				mockPolicy.EXPECT().UpdateArticle(identity, "1", gomock.Any()).
+					Return(&model.Response{Status: http.StatusPreconditionFailed, Message: codes.GetErr(codes.ErrVersionMismatch)})
+			},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "Failure:: If-Match missing",
+			body:       `{"title":"title","content":"content"}`,
+			setup:      func(mockPolicy *mock.MockArticlePolicyI) {},
+			wantStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:       "Failure:: If-Match not a version",
+			body:       `{"title":"title","content":"content"}`,
+			ifMatch:    `*`,
+			setup:      func(mockPolicy *mock.MockArticlePolicyI) {},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "Failure:: not the owner",
+			body:    `{"title":"title","content":"content"}`,
+			ifMatch: `"v3"`,
+			setup: func(mockPolicy *mock.MockArticlePolicyI) {
+				mockPolicy.EXPECT().UpdateArticle(identity, "1", gomock.Any()).
+					Return(&model.Response{Status: http.StatusForbidden, Message: codes.GetErr(codes.ErrNotArticleOwner)})
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Failure:: Validate error",
+			body:       `{"title":"title"}`,
+			ifMatch:    `"v3"`,
+			setup:      func(mockPolicy *mock.MockArticlePolicyI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
+			tt.setup(mockPolicy)
+			rec := &articleManagement{
+				policy: mockPolicy,
+			}
+			r := httptest.NewRequest(http.MethodPut, "/articles/1", bytes.NewBufferString(tt.body))
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+			r = mux.SetURLVars(r.WithContext(auth.WithIdentity(r.Context(), identity)), map[string]string{"id": "1"})
+			w := httptest.NewRecorder()
+			rec.UpdateArticle(w, r)
+			if !reflect.DeepEqual(w.Code, tt.wantStatus) {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("Want: %v, Got: %v", tt.wantETag, got)
+			}
+		})
+	}
+}
+
+func Test_ArticleManagement_TransitionArticle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	identity := &model.Identity{Subject: "user-1", Roles: []string{"editor"}}
+	mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
+	mockPolicy.EXPECT().TransitionArticle(identity, "1", "approve").
+		Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: map[string]string{"id": "1", "status": "published"}})
+	rec := &articleManagement{
+		policy: mockPolicy,
+	}
+	r := httptest.NewRequest(http.MethodPost, "/articles/1/approve", nil)
+	r = mux.SetURLVars(r.WithContext(auth.WithIdentity(r.Context(), identity)), map[string]string{"id": "1", "action": "approve"})
+	w := httptest.NewRecorder()
+	rec.TransitionArticle(w, r)
+	if !reflect.DeepEqual(w.Code, http.StatusOK) {
+		t.Errorf("Want: %v, Got: %v", http.StatusOK, w.Code)
+	}
+	var response model.Response
+	_ = json.NewDecoder(w.Body).Decode(&response)
+	want := map[string]interface{}{"id": "1", "status": "published"}
+	if !reflect.DeepEqual(response.Data, want) {
+		t.Errorf("Want: %v, Got: %v", want, response.Data)
+	}
+}
+
+func Test_ArticleManagement_ScheduleArticle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	identity := &model.Identity{Subject: "user-1", Roles: []string{"editor"}}
+	publishAt := time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		body       string
+		setup      func() *mock.MockArticlePolicyI
+		wantStatus int
+	}{
+		{
+			name: "Success:: schedule",
+			body: `{"publish_at":"2023-01-01T09:00:00Z"}`,
+			setup: func() *mock.MockArticlePolicyI {
+				mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
+				mockPolicy.EXPECT().ScheduleArticle(identity, "1", &model.ScheduleRequest{PublishAt: &publishAt}).
+					Return(&model.Response{Status: http.StatusOK, Message: "Success"})
+				return mockPolicy
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Failure:: invalid body",
+			body: `{"publish_at":"tomorrow"}`,
+			setup: func() *mock.MockArticlePolicyI {
+				return mock.NewMockArticlePolicyI(mockCtrl)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &articleManagement{
+				policy: tt.setup(),
+			}
+			r := httptest.NewRequest(http.MethodPut, "/articles/1/schedule", strings.NewReader(tt.body))
+			r = mux.SetURLVars(r.WithContext(auth.WithIdentity(r.Context(), identity)), map[string]string{"id": "1"})
+			w := httptest.NewRecorder()
+			rec.ScheduleArticle(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_ArticleManagement_GetArticleById_VersionETag(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	article := model.ArticleDs{Id: "1", Status: model.StatusPublished, Version: 2}
+	mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
+	mockPolicy.EXPECT().GetArticle(nil, "1").
+		Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: []model.ArticleDs{article}}).Times(2)
+	// a moderated comment changes the body but not the version
+	article.CommentCount = 1
+	mockPolicy.EXPECT().GetArticle(nil, "1").
+		Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: []model.ArticleDs{article}})
+	rec := &articleManagement{
+		policy: mockPolicy,
+	}
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/articles/1", nil)
+		r.Header.Set("If-None-Match", ifNoneMatch)
+		r = mux.SetURLVars(r, map[string]string{"id": "1"})
+		w := httptest.NewRecorder()
+		rec.GetArticleById(w, r)
+		return w
+	}
+	etag := get("").Header().Get("ETag")
+	if version, ok := httpcache.IfMatchVersion(etag); !ok || version != 2 {
+		t.Errorf("Want: %v, Got: %v", 2, etag)
+	}
+	if w := get(etag); w.Code != http.StatusNotModified {
+		t.Errorf("Want: %v, Got: %v", http.StatusNotModified, w.Code)
+	}
+	if w := get(etag); w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("Want: %v, Got: %v %v", "a new tag", w.Code, w.Header().Get("ETag"))
+	}
+}
+
+func Test_ArticleManagement_GetArticleById_Localized(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	article := model.ArticleDs{Id: "1", Title: "Title", Locale: "en", Status: model.StatusPublished, Version: 2,
+		Translations: []string{"en", "fr"},
+		Localized:    map[string]model.TranslationDs{"fr": {ArticleId: "1", Locale: "fr", Title: "Titre"}},
+	}
+	mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
+	mockPolicy.EXPECT().GetArticle(nil, "1").
+		Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: []model.ArticleDs{article}}).Times(3)
+	rec := &articleManagement{
+		policy: mockPolicy,
+	}
+	for _, tt := range []struct {
+		url            string
+		acceptLanguage string
+		wantLanguage   string
+		wantTitle      string
+		wantTranslated bool
+	}{
+		{url: "/articles/1", acceptLanguage: "fr-CA, en;q=0.5", wantLanguage: "fr", wantTitle: "Titre", wantTranslated: true},
+		{url: "/articles/1?lang=de", acceptLanguage: "fr", wantLanguage: "en", wantTitle: "Title"},
+		{url: "/articles/1", wantLanguage: "en", wantTitle: "Title"},
+	} {
+		r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+		r.Header.Set("Accept-Language", tt.acceptLanguage)
+		r = mux.SetURLVars(r, map[string]string{"id": "1"})
+		w := httptest.NewRecorder()
+		rec.GetArticleById(w, r)
+		var got struct {
+			Data []model.ArticleDs `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || len(got.Data) != 1 || got.Data[0].Title != tt.wantTitle {
+			t.Errorf("Want: %v, Got: %v", tt.wantTitle, w.Body.String())
+		}
+		if lang := w.Header().Get("Content-Language"); lang != tt.wantLanguage {
+			t.Errorf("Want: %v, Got: %v", tt.wantLanguage, lang)
+		}
+		if etag := w.Header().Get("ETag"); !strings.HasPrefix(etag, `"v2-`) || strings.HasSuffix(etag, `-fr"`) != tt.wantTranslated {
+			t.Errorf("Want: %v, Got: %v", tt.wantTranslated, etag)
+		}
+	}
+}
+
+func Test_selectContent(t *testing.T) {
+	articles := []model.ArticleDs{{Id: "1", Content: "*hi*", ContentHtml: "<p><em>hi</em></p>"}}
+	tests := []struct {
+		render string
+		want   interface{}
+	}{
+		{render: "", want: articles},
+		{render: "html", want: []model.ArticleDs{{Id: "1", ContentHtml: "<p><em>hi</em></p>"}}},
+		{render: "raw", want: []model.ArticleDs{{Id: "1", Content: "*hi*"}}},
+		{render: "html", want: []model.RelatedArticle{{ArticleDs: model.ArticleDs{Id: "1", ContentHtml: "<p><em>hi</em></p>"}, Score: 0.5}}},
+	}
+	for _, tt := range tests {
+		var data interface{} = articles
+		if _, ok := tt.want.([]model.RelatedArticle); ok {
+			data = []model.RelatedArticle{{ArticleDs: articles[0], Score: 0.5}}
+		}
+		if got := selectContent(data, tt.render); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Want: %v, Got: %v", tt.want, got)
+		}
+	}
+	if articles[0].Content == "" || articles[0].ContentHtml == "" {
+		t.Errorf("Want: %v, Got: %v", "articles left untouched", articles)
+	}
+}
+
+func Test_tagFilter(t *testing.T) {
+	tests := []struct {
+		query string
+		want  *model.TagFilter
+	}{
+		{query: "", want: nil},
+		{query: "tag=go&tag=db", want: &model.TagFilter{Tags: []string{"go", "db"}}},
+		{query: "tag=go,db&match=all", want: &model.TagFilter{Tags: []string{"go", "db"}, All: true}},
+	}
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		if got := tagFilter(query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Want: %v, Got: %v", tt.want, got)
+		}
+	}
+}
+
+func Test_ArticleManagement_GetArticleBySlug_Redirect(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
+	mockPolicy.EXPECT().GetArticleBySlug(nil, "old-title").Return(&model.Response{
+		Status:  http.StatusMovedPermanently,
+		Message: "Moved Permanently",
+		Data:    []model.ArticleDs{{Id: "1", Slug: "new-title"}},
+	})
+	r := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/articles/by-slug/old-title", nil), map[string]string{"slug": "old-title"})
+	w := httptest.NewRecorder()
+	(&articleManagement{policy: mockPolicy}).GetArticleBySlug(w, r)
+	if w.Code != http.StatusMovedPermanently {
+		t.Errorf("Want: %v, Got: %v", http.StatusMovedPermanently, w.Code)
+	}
+	if got := w.Header().Get("Location"); got != "/articles/by-slug/new-title" {
+		t.Errorf("Want: %v, Got: %v", "/articles/by-slug/new-title", got)
+	}
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/authz"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/blob"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/httpcache"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/media"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"io"
+	"io/ioutil"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_media_handler.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/handler MediaHandlerI
+
+const (
+	// uploadField is the multipart form field holding the uploaded file
+	uploadField = "file"
+	// MultipartOverhead is allowed on top of the file for the boundaries and headers of the form
+	MultipartOverhead = 64 << 10
+	// Stored files never change, a published one may be kept by any cache until its article could have been withdrawn
+	publicMediaCacheControl = "public, max-age=86400, immutable"
+)
+
+var errBodyTooLarge = errors.New("request body too large")
+
+type MediaHandlerI interface {
+	UploadMedia(w http.ResponseWriter, r *http.Request)
+	DeleteMedia(w http.ResponseWriter, r *http.Request)
+	GetArticleMedia(w http.ResponseWriter, r *http.Request)
+	GetMediaFile(w http.ResponseWriter, r *http.Request)
+	GetMediaThumbnail(w http.ResponseWriter, r *http.Request)
+}
+
+type mediaManagement struct {
+	policy   authz.MediaPolicyI
+	maxBytes int64
+}
+
+func NewMediaHandlerI(ds datasource.MediaDataSourceI, articles datasource.DataSourceI, revisions datasource.RevisionDataSourceI, store blob.StoreI, processor media.ProcessorI) MediaHandlerI {
+	return &mediaManagement{
+		policy:   authz.NewMediaPolicyI(logic.NewMediaLogicI(ds, articles, store, processor), logic.NewArticleManagementLogicI(articles, revisions)),
+		maxBytes: processor.MaxBytes(),
+	}
+}
+
+// UploadMedia attaches the file sent in the file field of a multipart form to the article
+func (svc mediaManagement) UploadMedia(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	upload, resp := readUpload(r, svc.maxBytes)
+	if resp != nil {
+		writeResponse(w, resp)
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	writeResponse(w, svc.policy.UploadMedia(identity, id, upload))
+}
+
+func (svc mediaManagement) DeleteMedia(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	mediaId, hasMedia := vars["media"]
+	if !ok || !hasMedia {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	writeResponse(w, svc.policy.DeleteMedia(identity, id, mediaId))
+}
+
+func (svc mediaManagement) GetArticleMedia(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	writeWithValidators(w, r, svc.policy.GetArticleMedia(identity, id), 0)
+}
+
+func (svc mediaManagement) GetMediaFile(w http.ResponseWriter, r *http.Request) {
+	svc.serve(w, r, false)
+}
+
+func (svc mediaManagement) GetMediaThumbnail(w http.ResponseWriter, r *http.Request) {
+	svc.serve(w, r, true)
+}
+
+// serve writes the stored file, or its thumbnail, validated by its checksum. Browsers are told not to sniff it and
+// to run nothing it may contain, and only images are shown inline.
+func (svc mediaManagement) serve(w http.ResponseWriter, r *http.Request, thumbnail bool) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	resp := svc.policy.OpenMedia(identity, id, thumbnail)
+	file, ok := resp.Data.(*model.MediaFile)
+	if resp.Status != http.StatusOK || !ok {
+		writeResponse(w, resp)
+		return
+	}
+	defer file.Body.Close()
+
+	original := !thumbnail || file.Media.ThumbnailKey == ""
+	etag := `"` + file.Media.Checksum + `"`
+	if !original {
+		etag = `"` + file.Media.Checksum + `-thumbnail"`
+	}
+	lastModified := httpcache.LastModified(file.Media.CreatedAt)
+	if file.Media.Public {
+		w.Header().Set("Cache-Control", publicMediaCacheControl)
+	} else {
+		w.Header().Set("Cache-Control", "private, no-cache")
+	}
+	if httpcache.NotModified(r, etag, lastModified) {
+		httpcache.WriteNotModified(w, etag, lastModified)
+		return
+	}
+	disposition := "attachment"
+	if strings.HasPrefix(file.ContentType, "image/") {
+		disposition = "inline"
+	}
+	h := w.Header()
+	h.Set("Content-Type", file.ContentType)
+	h.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": file.Media.Filename}))
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("Content-Security-Policy", "default-src 'none'; sandbox")
+	h.Set("ETag", etag)
+	if lastModified != "" {
+		h.Set("Last-Modified", lastModified)
+	}
+	if original {
+		h.Set("Content-Length", strconv.FormatInt(file.Media.Size, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := io.Copy(w, file.Body); err != nil {
+		log.Print(err)
+	}
+}
+
+// readUpload reads the file from the multipart form of r, refusing files larger than maxBytes with 413
+func readUpload(r *http.Request, maxBytes int64) (*model.MediaUpload, *model.Response) {
+	r.Body = &boundedBody{ReadCloser: r.Body, left: maxBytes + MultipartOverhead}
+	reader, err := r.MultipartReader()
+	if err != nil {
+		log.Print(err)
+		return nil, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrMissingUploadFile),
+			Data:    nil,
+		}
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, &model.Response{
+				Status:  http.StatusBadRequest,
+				Message: codes.GetErr(codes.ErrMissingUploadFile),
+				Data:    nil,
+			}
+		}
+		if err != nil {
+			return nil, uploadReadError(err)
+		}
+		if part.FormName() != uploadField {
+			continue
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(part, maxBytes+1))
+		if err != nil {
+			return nil, uploadReadError(err)
+		}
+		if int64(len(data)) > maxBytes {
+			return nil, uploadReadError(errBodyTooLarge)
+		}
+		if len(data) == 0 {
+			return nil, &model.Response{
+				Status:  http.StatusBadRequest,
+				Message: codes.GetErr(codes.ErrMissingUploadFile),
+				Data:    nil,
+			}
+		}
+		return &model.MediaUpload{Filename: part.FileName(), Data: data}, nil
+	}
+}
+
+func uploadReadError(err error) *model.Response {
+	log.Print(err)
+	if errors.Is(err, errBodyTooLarge) {
+		return &model.Response{
+			Status:  http.StatusRequestEntityTooLarge,
+			Message: codes.GetErr(codes.ErrMediaTooLarge),
+			Data:    nil,
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusBadRequest,
+		Message: codes.GetErr(codes.ErrReadingReqBody),
+		Data:    nil,
+	}
+}
+
+// boundedBody fails reads going past left bytes with errBodyTooLarge, so that oversized uploads are not read whole
+type boundedBody struct {
+	io.ReadCloser
+	left int64
+}
+
+func (b *boundedBody) Read(p []byte) (int, error) {
+	if b.left <= 0 {
+		// a body ending exactly at the bound is fine
+		var probe [1]byte
+		if n, err := b.ReadCloser.Read(probe[:]); n == 0 && err == io.EOF {
+			return 0, io.EOF
+		}
+		return 0, errBodyTooLarge
+	}
+	if int64(len(p)) > b.left {
+		p = p[:b.left]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.left -= int64(n)
+	return n, err
+}
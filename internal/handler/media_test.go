@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func multipartBody(t *testing.T, field string, filename string, data []byte) (*bytes.Buffer, string) {
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	_ = form.WriteField("caption", "ignored")
+	part, err := form.CreateFormFile(field, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = part.Write(data)
+	_ = form.Close()
+	return &buf, form.FormDataContentType()
+}
+
+func Test_MediaManagement_UploadMedia(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	identity := &model.Identity{Subject: "user-1"}
+	tests := []struct {
+		name       string
+		field      string
+		data       []byte
+		setup      func(*mock.MockMediaPolicyI)
+		wantStatus int
+	}{
+		{
+			name:  "Success:: file read from the form",
+			field: "file",
+			data:  []byte("0123456789"),
+			setup: func(p *mock.MockMediaPolicyI) {
+				p.EXPECT().UploadMedia(identity, "1", &model.MediaUpload{Filename: "photo.png", Data: []byte("0123456789")}).
+					Return(&model.Response{Status: http.StatusCreated, Message: "Success"})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "Failure:: too large",
+			field:      "file",
+			data:       []byte("0123456789a"),
+			setup:      func(p *mock.MockMediaPolicyI) {},
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "Failure:: no file field",
+			field:      "attachment",
+			data:       []byte("0123456789"),
+			setup:      func(p *mock.MockMediaPolicyI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPolicy := mock.NewMockMediaPolicyI(mockCtrl)
+			tt.setup(mockPolicy)
+			body, contentType := multipartBody(t, tt.field, "photo.png", tt.data)
+			r := httptest.NewRequest(http.MethodPost, "/articles/1/media", body)
+			r.Header.Set("Content-Type", contentType)
+			r = mux.SetURLVars(r.WithContext(auth.WithIdentity(r.Context(), identity)), map[string]string{"id": "1"})
+			w := httptest.NewRecorder()
+			mediaManagement{policy: mockPolicy, maxBytes: 10}.UploadMedia(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_MediaManagement_GetMediaFile(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	file := func(public bool) *model.Response {
+		return &model.Response{Status: http.StatusOK, Message: "Success", Data: &model.MediaFile{
+			Media:       model.MediaDs{Id: "m1", Filename: "photo.png", Size: 5, Checksum: "abc", CreatedAt: created, Public: public},
+			ContentType: "image/png",
+			Body:        ioutil.NopCloser(strings.NewReader("image")),
+		}}
+	}
+	tests := []struct {
+		name         string
+		public       bool
+		ifNoneMatch  string
+		wantStatus   int
+		wantBody     string
+		wantCacheCtl string
+	}{
+		{name: "Success:: published file", public: true, wantStatus: http.StatusOK, wantBody: "image", wantCacheCtl: publicMediaCacheControl},
+		{name: "Success:: draft file kept private", wantStatus: http.StatusOK, wantBody: "image", wantCacheCtl: "private, no-cache"},
+		{name: "Success:: not modified", public: true, ifNoneMatch: `"abc"`, wantStatus: http.StatusNotModified, wantCacheCtl: publicMediaCacheControl},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPolicy := mock.NewMockMediaPolicyI(mockCtrl)
+			mockPolicy.EXPECT().OpenMedia(nil, "m1", false).Return(file(tt.public))
+			r := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/media/m1", nil), map[string]string{"id": "m1"})
+			if tt.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+			mediaManagement{policy: mockPolicy}.GetMediaFile(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("Want: %v, Got: %v", tt.wantBody, w.Body.String())
+			}
+			if got := w.Header().Get("Cache-Control"); got != tt.wantCacheCtl {
+				t.Errorf("Want: %v, Got: %v", tt.wantCacheCtl, got)
+			}
+			if got := w.Header().Get("ETag"); got != `"abc"` {
+				t.Errorf("Want: %v, Got: %v", `"abc"`, got)
+			}
+			if tt.wantStatus == http.StatusOK {
+				for header, want := range map[string]string{"Content-Type": "image/png", "Content-Length": "5", "X-Content-Type-Options": "nosniff",
+					"Content-Disposition": `inline; filename=photo.png`} {
+					if got := w.Header().Get(header); got != want {
+						t.Errorf("%s: Want: %v, Got: %v", header, want, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_published_MediaList(t *testing.T) {
+	if published(model.MediaList{Media: []model.MediaDs{}}) {
+		t.Errorf("Want: %v, Got: %v", false, true)
+	}
+	if !published(model.MediaList{Public: true}) {
+		t.Errorf("Want: %v, Got: %v", true, false)
+	}
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/related"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_related_handler.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/handler RelatedHandlerI
+
+// defaultRelatedLimit is the number of related articles listed when no ?limit is given
+const defaultRelatedLimit = 5
+
+type RelatedHandlerI interface {
+	GetRelatedArticles(w http.ResponseWriter, r *http.Request)
+}
+
+type relatedManagement struct {
+	logic logic.RelatedLogicI
+}
+
+func NewRelatedHandlerI(index related.IndexI, articles datasource.DataSourceI) RelatedHandlerI {
+	return &relatedManagement{
+		logic: logic.NewRelatedLogicI(index, articles),
+	}
+}
+
+// GetRelatedArticles lists the articles most similar to the article, up to ?limit of them. Similar articles change
+// without the article changing, so the response carries no Last-Modified.
+func (svc relatedManagement) GetRelatedArticles(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	limit := defaultRelatedLimit
+	if r.URL.Query().Get("limit") != "" {
+		limit, _ = pagination(r.URL.Query())
+	}
+	writeWithValidators(w, r, svc.logic.GetRelatedArticles(id, limit), 0)
+}
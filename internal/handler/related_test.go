@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_RelatedManagement_GetRelatedArticles(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	related := &model.Response{Status: http.StatusOK, Message: "Success", Data: []model.RelatedArticle{{
+		ArticleDs: model.ArticleDs{Id: "2", Title: "Title", Locale: "en", Status: model.StatusPublished, UpdatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			Translations: []string{"en", "fr"},
+			Localized:    map[string]model.TranslationDs{"fr": {ArticleId: "2", Locale: "fr", Title: "Titre"}},
+		},
+		Score: 0.5,
+	}}}
+	tests := []struct {
+		name           string
+		target         string
+		acceptLanguage string
+		ifNoneMatch    string
+		setup          func(*mock.MockRelatedLogicI)
+		wantStatus     int
+		wantTitle      string
+	}{
+		{
+			name:   "Success:: default limit",
+			target: "/articles/1/related",
+			setup: func(l *mock.MockRelatedLogicI) {
+				l.EXPECT().GetRelatedArticles("1", defaultRelatedLimit).Return(related)
+			},
+			wantStatus: http.StatusOK,
+			wantTitle:  "Title",
+		},
+		{
+			name:   "Success:: limit",
+			target: "/articles/1/related?limit=2",
+			setup: func(l *mock.MockRelatedLogicI) {
+				l.EXPECT().GetRelatedArticles("1", 2).Return(related)
+			},
+			wantStatus: http.StatusOK,
+			wantTitle:  "Title",
+		},
+		{
+			name:           "Success:: translated",
+			target:         "/articles/1/related",
+			acceptLanguage: "fr",
+			setup: func(l *mock.MockRelatedLogicI) {
+				l.EXPECT().GetRelatedArticles("1", defaultRelatedLimit).Return(related)
+			},
+			wantStatus: http.StatusOK,
+			wantTitle:  "Titre",
+		},
+		{
+			name:   "Failure:: unknown article",
+			target: "/articles/1/related",
+			setup: func(l *mock.MockRelatedLogicI) {
+				l.EXPECT().GetRelatedArticles("1", defaultRelatedLimit).
+					Return(&model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrArticleNotFound)})
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockRelatedLogicI(mockCtrl)
+			tt.setup(mockLogic)
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.acceptLanguage != "" {
+				r.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			r = mux.SetURLVars(r, map[string]string{"id": "1"})
+			w := httptest.NewRecorder()
+			relatedManagement{logic: mockLogic}.GetRelatedArticles(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got struct {
+				Data []model.RelatedArticle `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || len(got.Data) != 1 || got.Data[0].Title != tt.wantTitle {
+				t.Errorf("Want: %v, Got: %v", tt.wantTitle, w.Body.String())
+			}
+			// related articles change without the articles changing
+			if lastModified := w.Header().Get("Last-Modified"); lastModified != "" {
+				t.Errorf("Want: %v, Got: %v", "", lastModified)
+			}
+		})
+	}
+}
+
+func Test_RelatedManagement_GetRelatedArticles_NotModified(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockLogic := mock.NewMockRelatedLogicI(mockCtrl)
+	mockLogic.EXPECT().GetRelatedArticles("1", defaultRelatedLimit).
+		Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: []model.RelatedArticle{{ArticleDs: model.ArticleDs{Id: "2", Status: model.StatusPublished}, Score: 0.5}}}).
+		Times(3)
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/articles/1/related", nil)
+		r.Header.Set("If-None-Match", ifNoneMatch)
+		w := httptest.NewRecorder()
+		relatedManagement{logic: mockLogic}.GetRelatedArticles(w, mux.SetURLVars(r, map[string]string{"id": "1"}))
+		return w
+	}
+	etag := get("").Header().Get("ETag")
+	if w := get(etag); w.Code != http.StatusNotModified {
+		t.Errorf("Want: %v, Got: %v", http.StatusNotModified, w.Code)
+	}
+	if w := get(`"stale"`); w.Code != http.StatusOK {
+		t.Errorf("Want: %v, Got: %v", http.StatusOK, w.Code)
+	}
+}
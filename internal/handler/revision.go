@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/httpcache"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"net/http"
+	"strconv"
+)
+
+// GetRevisions lists the revisions of an article e.g. GET /articles/{id}/revisions
+func (svc articleManagement) GetRevisions(w http.ResponseWriter, r *http.Request) {
+	identity, _ := auth.IdentityFrom(r.Context())
+	resp := svc.policy.GetRevisions(identity, mux.Vars(r)["id"])
+	writeResponse(w, resp)
+}
+
+// GetRevision returns a single revision e.g. GET /articles/{id}/revisions/{rev}
+func (svc articleManagement) GetRevision(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	rev, ok := revision(w, vars["rev"])
+	if !ok {
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	resp := svc.policy.GetRevision(identity, vars["id"], rev)
+	writeResponse(w, resp)
+}
+
+// DiffRevisions returns a unified diff between two revisions e.g. GET /articles/{id}/revisions/diff?from=1&to=3
+func (svc articleManagement) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	from, ok := revision(w, query.Get("from"))
+	if !ok {
+		return
+	}
+	to, ok := revision(w, query.Get("to"))
+	if !ok {
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	resp := svc.policy.DiffRevisions(identity, mux.Vars(r)["id"], from, to)
+	writeResponse(w, resp)
+}
+
+// RestoreRevision makes an earlier revision the current version e.g. POST /articles/{id}/revisions/{rev}/restore
+func (svc articleManagement) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	rev, ok := revision(w, vars["rev"])
+	if !ok {
+		return
+	}
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	resp := svc.policy.RestoreRevision(identity, vars["id"], rev, version)
+	if resp.Status == http.StatusOK {
+		w.Header().Set("ETag", httpcache.VersionETag(version+1))
+	}
+	writeResponse(w, resp)
+}
+
+// revision parses a revision number, writing the error response when it is not a positive integer
+func revision(w http.ResponseWriter, s string) (int, bool) {
+	rev, err := strconv.Atoi(s)
+	if err != nil || rev < 1 {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrInvalidRevision),
+			Data:    nil,
+		})
+		return 0, false
+	}
+	return rev, true
+}
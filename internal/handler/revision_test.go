@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_ArticleManagement_DiffRevisions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	identity := &model.Identity{Subject: "user-1", Roles: []string{"editor"}}
+	tests := []struct {
+		name       string
+		target     string
+		setup      func() *mock.MockArticlePolicyI
+		wantStatus int
+	}{
+		{
+			name:   "Success:: diff",
+			target: "/articles/1/revisions/diff?from=1&to=2",
+			setup: func() *mock.MockArticlePolicyI {
+				mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
+				mockPolicy.EXPECT().DiffRevisions(identity, "1", 1, 2).
+					Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: model.RevisionDiff{From: 1, To: 2}})
+				return mockPolicy
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "Failure:: invalid revision",
+			target: "/articles/1/revisions/diff?from=0&to=2",
+			setup: func() *mock.MockArticlePolicyI {
+				return mock.NewMockArticlePolicyI(mockCtrl)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &articleManagement{
+				policy: tt.setup(),
+			}
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			r = mux.SetURLVars(r.WithContext(auth.WithIdentity(r.Context(), identity)), map[string]string{"id": "1"})
+			w := httptest.NewRecorder()
+			rec.DiffRevisions(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_ArticleManagement_RestoreRevision(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	identity := &model.Identity{Subject: "user-1", Roles: []string{"editor"}}
+	tests := []struct {
+		name       string
+		rev        string
+		ifMatch    string
+		setup      func(*mock.MockArticlePolicyI)
+		wantStatus int
+		wantETag   string
+	}{
+		{
+			name:    "Success:: restore",
+			rev:     "1",
+			ifMatch: `"v3"`,
+			setup: func(mockPolicy *mock.MockArticlePolicyI) {
+				mockPolicy.EXPECT().RestoreRevision(identity, "1", 1, 3).
+					Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: map[string]interface{}{"id": "1", "restored": 1}})
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   `"v4"`,
+		},
+		{
+			name:    "Failure:: version mismatch",
+			rev:     "1",
+			ifMatch: `"v3"`,
+			setup: func(mockPolicy *mock.MockArticlePolicyI) {
+				mockPolicy.EXPECT().RestoreRevision(identity, "1", 1, 3).
+					Return(&model.Response{Status: http.StatusPreconditionFailed, Message: codes.GetErr(codes.ErrVersionMismatch)})
+			},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "Failure:: If-Match missing",
+			rev:        "1",
+			setup:      func(mockPolicy *mock.MockArticlePolicyI) {},
+			wantStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:       "Failure:: If-Match not a version",
+			rev:        "1",
+			ifMatch:    `*`,
+			setup:      func(mockPolicy *mock.MockArticlePolicyI) {},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "Failure:: invalid revision",
+			rev:        "x",
+			ifMatch:    `"v3"`,
+			setup:      func(mockPolicy *mock.MockArticlePolicyI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
+			tt.setup(mockPolicy)
+			rec := &articleManagement{
+				policy: mockPolicy,
+			}
+			r := httptest.NewRequest(http.MethodPost, "/articles/1/revisions/"+tt.rev+"/restore", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+			r = mux.SetURLVars(r.WithContext(auth.WithIdentity(r.Context(), identity)), map[string]string{"id": "1", "rev": tt.rev})
+			w := httptest.NewRecorder()
+			rec.RestoreRevision(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("Want: %v, Got: %v", tt.wantETag, got)
+			}
+		})
+	}
+}
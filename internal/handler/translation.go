@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/go-playground/validator"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/authz"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"io/ioutil"
+	"log"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_translation_handler.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/handler TranslationHandlerI
+
+type TranslationHandlerI interface {
+	PutTranslation(w http.ResponseWriter, r *http.Request)
+	DeleteTranslation(w http.ResponseWriter, r *http.Request)
+}
+
+type translationManagement struct {
+	policy authz.TranslationPolicyI
+}
+
+func NewTranslationHandlerI(ds datasource.TranslationDataSourceI, articles datasource.DataSourceI, revisions datasource.RevisionDataSourceI) TranslationHandlerI {
+	return &translationManagement{
+		policy: authz.NewTranslationPolicyI(logic.NewTranslationLogicI(ds, articles), logic.NewArticleManagementLogicI(articles, revisions)),
+	}
+}
+
+// PutTranslation adds or replaces the translation of the article into the {lang} of the path
+func (svc translationManagement) PutTranslation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	locale, hasLocale := vars["lang"]
+	if !ok || !hasLocale {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	bytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrReadingReqBody),
+			Data:    nil,
+		})
+		return
+	}
+	var req model.TranslationRequest
+	err = json.Unmarshal(bytes, &req)
+	if err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrUnmarshall),
+			Data:    nil,
+		})
+		return
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		log.Print(err)
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+			Data:    nil,
+		})
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	writeResponse(w, svc.policy.PutTranslation(identity, id, locale, &req))
+}
+
+func (svc translationManagement) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	locale, hasLocale := vars["lang"]
+	if !ok || !hasLocale {
+		writeResponse(w, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAssertid),
+			Data:    nil,
+		})
+		return
+	}
+	identity, _ := auth.IdentityFrom(r.Context())
+	writeResponse(w, svc.policy.DeleteTranslation(identity, id, locale))
+}
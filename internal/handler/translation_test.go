@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/authz"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_TranslationManagement_PutTranslation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	draft := &model.Response{Status: http.StatusOK, Message: "Success", Data: []model.ArticleDs{{Id: "1", AuthorId: "user-1", Status: model.StatusDraft}}}
+	published := &model.Response{Status: http.StatusOK, Message: "Success", Data: []model.ArticleDs{{Id: "1", AuthorId: "user-1", Status: model.StatusPublished}}}
+	saved := &model.Response{Status: http.StatusOK, Message: "Success", Data: map[string]string{"id": "1", "locale": "fr"}}
+	tests := []struct {
+		name       string
+		identity   *model.Identity
+		body       string
+		setup      func(*mock.MockTranslationLogicI, *mock.MockArticleManagementLogicI)
+		wantStatus int
+	}{
+		{
+			name:     "Success:: author translates own draft",
+			identity: &model.Identity{Subject: "user-1", Roles: []string{authz.RoleAuthor}},
+			body:     `{"title":"Titre","content":"Contenu","content_format":"markdown"}`,
+			setup: func(l *mock.MockTranslationLogicI, a *mock.MockArticleManagementLogicI) {
+				a.EXPECT().GetArticle("1").Return(draft)
+				l.EXPECT().PutTranslation("1", "fr", &model.TranslationRequest{Title: "Titre", Content: "Contenu", ContentFormat: "markdown"}).Return(saved)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:     "Success:: editor translates any article",
+			identity: &model.Identity{Subject: "user-2", Roles: []string{authz.RoleEditor}},
+			body:     `{"title":"Titre","content":"Contenu"}`,
+			setup: func(l *mock.MockTranslationLogicI, a *mock.MockArticleManagementLogicI) {
+				l.EXPECT().PutTranslation("1", "fr", gomock.Any()).Return(saved)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:     "Failure:: locale of the article",
+			identity: &model.Identity{Subject: "user-2", Roles: []string{authz.RoleEditor}},
+			body:     `{"title":"Titre","content":"Contenu"}`,
+			setup: func(l *mock.MockTranslationLogicI, a *mock.MockArticleManagementLogicI) {
+				l.EXPECT().PutTranslation("1", "fr", gomock.Any()).
+					Return(&model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrTranslationLocale)})
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Failure:: without credentials",
+			body:       `{"title":"Titre","content":"Contenu"}`,
+			setup:      func(l *mock.MockTranslationLogicI, a *mock.MockArticleManagementLogicI) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Failure:: reader",
+			identity:   &model.Identity{Subject: "user-1", Roles: []string{authz.RoleReader}},
+			body:       `{"title":"Titre","content":"Contenu"}`,
+			setup:      func(l *mock.MockTranslationLogicI, a *mock.MockArticleManagementLogicI) {},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:     "Failure:: author translates someone else's article",
+			identity: &model.Identity{Subject: "user-3", Roles: []string{authz.RoleAuthor}},
+			body:     `{"title":"Titre","content":"Contenu"}`,
+			setup: func(l *mock.MockTranslationLogicI, a *mock.MockArticleManagementLogicI) {
+				a.EXPECT().GetArticle("1").Return(draft)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:     "Failure:: author translates own published article",
+			identity: &model.Identity{Subject: "user-1", Roles: []string{authz.RoleAuthor}},
+			body:     `{"title":"Titre","content":"Contenu"}`,
+			setup: func(l *mock.MockTranslationLogicI, a *mock.MockArticleManagementLogicI) {
+				a.EXPECT().GetArticle("1").Return(published)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Failure:: missing content",
+			identity:   &model.Identity{Subject: "user-2", Roles: []string{authz.RoleEditor}},
+			body:       `{"title":"Titre"}`,
+			setup:      func(l *mock.MockTranslationLogicI, a *mock.MockArticleManagementLogicI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Failure:: unknown format",
+			identity:   &model.Identity{Subject: "user-2", Roles: []string{authz.RoleEditor}},
+			body:       `{"title":"Titre","content":"Contenu","content_format":"rtf"}`,
+			setup:      func(l *mock.MockTranslationLogicI, a *mock.MockArticleManagementLogicI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Failure:: invalid json",
+			identity:   &model.Identity{Subject: "user-2", Roles: []string{authz.RoleEditor}},
+			body:       `{"title":`,
+			setup:      func(l *mock.MockTranslationLogicI, a *mock.MockArticleManagementLogicI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockTranslationLogicI(mockCtrl)
+			mockArticles := mock.NewMockArticleManagementLogicI(mockCtrl)
+			tt.setup(mockLogic, mockArticles)
+			r := httptest.NewRequest(http.MethodPut, "/articles/1/translations/fr", strings.NewReader(tt.body))
+			if tt.identity != nil {
+				r = r.WithContext(auth.WithIdentity(r.Context(), tt.identity))
+			}
+			r = mux.SetURLVars(r, map[string]string{"id": "1", "lang": "fr"})
+			w := httptest.NewRecorder()
+			translationManagement{policy: authz.NewTranslationPolicyI(mockLogic, mockArticles)}.PutTranslation(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_TranslationManagement_DeleteTranslation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	editor := &model.Identity{Subject: "user-2", Roles: []string{authz.RoleEditor}}
+	tests := []struct {
+		name       string
+		identity   *model.Identity
+		vars       map[string]string
+		setup      func(*mock.MockTranslationPolicyI)
+		wantStatus int
+	}{
+		{
+			name:     "Success:: deleted",
+			identity: editor,
+			vars:     map[string]string{"id": "1", "lang": "fr"},
+			setup: func(p *mock.MockTranslationPolicyI) {
+				p.EXPECT().DeleteTranslation(editor, "1", "fr").Return(&model.Response{Status: http.StatusOK, Message: "Success"})
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:     "Failure:: unknown translation",
+			identity: editor,
+			vars:     map[string]string{"id": "1", "lang": "de"},
+			setup: func(p *mock.MockTranslationPolicyI) {
+				p.EXPECT().DeleteTranslation(editor, "1", "de").
+					Return(&model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrTranslationNotFound)})
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Failure:: without credentials",
+			vars: map[string]string{"id": "1", "lang": "fr"},
+			setup: func(p *mock.MockTranslationPolicyI) {
+				p.EXPECT().DeleteTranslation(nil, "1", "fr").
+					Return(&model.Response{Status: http.StatusUnauthorized, Message: codes.GetErr(codes.ErrUnauthorized)})
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Failure:: missing locale",
+			identity:   editor,
+			vars:       map[string]string{"id": "1"},
+			setup:      func(p *mock.MockTranslationPolicyI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPolicy := mock.NewMockTranslationPolicyI(mockCtrl)
+			tt.setup(mockPolicy)
+			r := httptest.NewRequest(http.MethodDelete, "/articles/1/translations/fr", nil)
+			if tt.identity != nil {
+				r = r.WithContext(auth.WithIdentity(r.Context(), tt.identity))
+			}
+			w := httptest.NewRecorder()
+			translationManagement{policy: mockPolicy}.DeleteTranslation(w, mux.SetURLVars(r, tt.vars))
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func Test_ArticleManagement_GetAllArticle_Localized(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	articles := []model.ArticleDs{
+		{Id: "1", Title: "Title", Locale: "en", Status: model.StatusPublished,
+			Translations: []string{"en", "fr"},
+			Localized:    map[string]model.TranslationDs{"fr": {ArticleId: "1", Locale: "fr", Title: "Titre"}},
+		},
+		{Id: "2", Title: "Other", Locale: "en", Status: model.StatusPublished, Translations: []string{"en"}},
+	}
+	mockPolicy := mock.NewMockArticlePolicyI(mockCtrl)
+	mockPolicy.EXPECT().GetAllArticle(nil, 20, 1, "", nil).
+		Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: articles}).Times(3)
+	get := func(acceptLanguage string, ifNoneMatch string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/articles", nil)
+		r.Header.Set("Accept-Language", acceptLanguage)
+		r.Header.Set("If-None-Match", ifNoneMatch)
+		w := httptest.NewRecorder()
+		(&articleManagement{policy: mockPolicy}).GetAllArticle(w, r)
+		return w
+	}
+	w := get("fr", "")
+	var got struct {
+		Data []model.ArticleDs `json:"data"`
+	}
+	// articles without a translation stay in their own language
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || len(got.Data) != 2 || got.Data[0].Title != "Titre" || got.Data[1].Title != "Other" {
+		t.Errorf("Want: %v, Got: %v", "Titre, Other", w.Body.String())
+	}
+	// a list is in several languages
+	if lang := w.Header().Get("Content-Language"); lang != "" {
+		t.Errorf("Want: %v, Got: %v", "", lang)
+	}
+	// the tag of the translated list does not validate the list in another language
+	if w := get("en", w.Header().Get("ETag")); w.Code != http.StatusOK {
+		t.Errorf("Want: %v, Got: %v", http.StatusOK, w.Code)
+	}
+	if w := get("fr", w.Header().Get("ETag")); w.Code != http.StatusNotModified {
+		t.Errorf("Want: %v, Got: %v", http.StatusNotModified, w.Code)
+	}
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/views"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_view_handler.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/handler ViewHandlerI
+
+type ViewHandlerI interface {
+	GetPopularArticles(w http.ResponseWriter, r *http.Request)
+}
+
+type viewManagement struct {
+	logic logic.ViewLogicI
+}
+
+func NewViewHandlerI(counter views.CounterI, articles datasource.DataSourceI) ViewHandlerI {
+	return &viewManagement{
+		logic: logic.NewViewLogicI(counter, articles),
+	}
+}
+
+// GetPopularArticles lists the most viewed articles of the last ?window=24h|7d|30d, up to ?limit of them.
+// The ranking changes without the articles changing, so the response carries no Last-Modified.
+func (svc viewManagement) GetPopularArticles(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	window := queryParams.Get("window")
+	if window == "" {
+		window = logic.DefaultViewWindow
+	}
+	limit, _ := pagination(queryParams)
+	writeWithValidators(w, r, svc.logic.GetPopularArticles(window, limit), 0)
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_ViewManagement_GetPopularArticles(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	popular := &model.Response{Status: http.StatusOK, Message: "Success", Data: []model.PopularArticle{{
+		ArticleDs: model.ArticleDs{Id: "1", Title: "Title", Locale: "en", Status: model.StatusPublished, UpdatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			Translations: []string{"en", "fr"},
+			Localized:    map[string]model.TranslationDs{"fr": {ArticleId: "1", Locale: "fr", Title: "Titre"}},
+		},
+		WindowViews: 10,
+	}}}
+	tests := []struct {
+		name           string
+		target         string
+		acceptLanguage string
+		setup          func(*mock.MockViewLogicI)
+		wantStatus     int
+		wantTitle      string
+	}{
+		{
+			name:   "Success:: default window and limit",
+			target: "/articles/popular",
+			setup: func(l *mock.MockViewLogicI) {
+				l.EXPECT().GetPopularArticles(logic.DefaultViewWindow, 20).Return(popular)
+			},
+			wantStatus: http.StatusOK,
+			wantTitle:  "Title",
+		},
+		{
+			name:   "Success:: window and limit",
+			target: "/articles/popular?window=7d&limit=3",
+			setup: func(l *mock.MockViewLogicI) {
+				l.EXPECT().GetPopularArticles("7d", 3).Return(popular)
+			},
+			wantStatus: http.StatusOK,
+			wantTitle:  "Title",
+		},
+		{
+			name:           "Success:: translated",
+			target:         "/articles/popular",
+			acceptLanguage: "fr-CA, en;q=0.5",
+			setup: func(l *mock.MockViewLogicI) {
+				l.EXPECT().GetPopularArticles(logic.DefaultViewWindow, 20).Return(popular)
+			},
+			wantStatus: http.StatusOK,
+			wantTitle:  "Titre",
+		},
+		{
+			name:   "Failure:: unknown window",
+			target: "/articles/popular?window=1y",
+			setup: func(l *mock.MockViewLogicI) {
+				l.EXPECT().GetPopularArticles("1y", 20).
+					Return(&model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrInvalidViewWindow)})
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockViewLogicI(mockCtrl)
+			tt.setup(mockLogic)
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.acceptLanguage != "" {
+				r.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+			viewManagement{logic: mockLogic}.GetPopularArticles(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got struct {
+				Data []model.PopularArticle `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || len(got.Data) != 1 || got.Data[0].Title != tt.wantTitle {
+				t.Errorf("Want: %v, Got: %v", tt.wantTitle, w.Body.String())
+			}
+			// the ranking changes without the articles changing
+			if lastModified := w.Header().Get("Last-Modified"); lastModified != "" {
+				t.Errorf("Want: %v, Got: %v", "", lastModified)
+			}
+		})
+	}
+}
+
+func Test_ViewManagement_GetPopularArticles_NotModified(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockLogic := mock.NewMockViewLogicI(mockCtrl)
+	gomock.InOrder(
+		mockLogic.EXPECT().GetPopularArticles(logic.DefaultViewWindow, 20).
+			Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: []model.PopularArticle{{ArticleDs: model.ArticleDs{Id: "1", Status: model.StatusPublished}, WindowViews: 10}}}).
+			Times(2),
+		// one more view changes the ranking and so the tag
+		mockLogic.EXPECT().GetPopularArticles(logic.DefaultViewWindow, 20).
+			Return(&model.Response{Status: http.StatusOK, Message: "Success", Data: []model.PopularArticle{{ArticleDs: model.ArticleDs{Id: "1", Status: model.StatusPublished}, WindowViews: 11}}}),
+	)
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/articles/popular", nil)
+		r.Header.Set("If-None-Match", ifNoneMatch)
+		w := httptest.NewRecorder()
+		viewManagement{logic: mockLogic}.GetPopularArticles(w, r)
+		return w
+	}
+	etag := get("").Header().Get("ETag")
+	if w := get(etag); w.Code != http.StatusNotModified {
+		t.Errorf("Want: %v, Got: %v", http.StatusNotModified, w.Code)
+	}
+	if w := get(etag); w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("Want: %v, Got: %v %v", "a new tag", w.Code, w.Header().Get("ETag"))
+	}
+}
@@ -0,0 +1,169 @@
+package httpcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ETag returns a strong entity tag derived from the sha256 of the response body
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// VersionETag returns the strong entity tag of the given article version
+func VersionETag(version int) string {
+	return fmt.Sprintf(`"v%d"`, version)
+}
+
+// VersionedETag returns the strong entity tag of body, a representation of the given article version e.g. "v3-1f2e…".
+// The hash tells apart representations of one version, which also show counters, the author's profile and
+// the ?render variant, while IfMatchVersion still reads the version from the tag.
+func VersionedETag(version int, body []byte) string {
+	sum := sha256.Sum256(body)
+	return fmt.Sprintf(`"v%d-%s"`, version, hex.EncodeToString(sum[:16]))
+}
+
+// IfMatchVersion returns the version named by an If-Match header holding a single tag made by VersionETag or
+// VersionedETag, the tag of a compressed representation is accepted too. Weak tags never match, as required for If-Match.
+func IfMatchVersion(header string) (int, bool) {
+	header = strings.TrimSpace(header)
+	if strings.HasPrefix(header, "W/") || strings.Contains(header, ",") {
+		return 0, false
+	}
+	tag := strings.Trim(baseETag(header), `"`)
+	if !strings.HasPrefix(tag, "v") {
+		return 0, false
+	}
+	tag, hash, hashed := strings.Cut(tag[1:], "-")
+	if hashed && !isHash(hash) {
+		return 0, false
+	}
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
+
+// isHash reports whether s is the hex encoded hash of a VersionedETag
+func isHash(s string) bool {
+	if len(s) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// LastModified formats t for the Last-Modified header, returning an empty string for the zero time
+func LastModified(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(http.TimeFormat)
+}
+
+// NotModified reports whether the conditional headers of r are satisfied by the given validators,
+// in which case the caller should answer with 304. If-None-Match takes precedence over If-Modified-Since.
+func NotModified(r *http.Request, etag string, lastModified string) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etag != "" && etagMatches(inm, etag)
+	}
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified == "" {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+// etagMatches performs the weak comparison required for If-None-Match against a list of tags.
+// Tags of compressed representations (see EncodedETag) match the tag of the uncompressed one.
+func etagMatches(header string, etag string) bool {
+	etag = baseETag(etag)
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if baseETag(candidate) == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodedETag derives the tag of a compressed representation, e.g. "abc" becomes "abc-gzip",
+// so that every representation carries a distinct strong validator
+func EncodedETag(etag string, encoding string) string {
+	if etag == "" || encoding == "" || encoding == "identity" || !strings.HasSuffix(etag, `"`) {
+		return etag
+	}
+	return strings.TrimSuffix(etag, `"`) + "-" + encoding + `"`
+}
+
+// LocalizedETag derives the tag of a translation of a representation, e.g. "v3" becomes "v3-fr", so that every
+// language carries a distinct strong validator. Such tags never satisfy IfMatchVersion: translations are not edited
+// through their article.
+func LocalizedETag(etag string, locale string) string {
+	if etag == "" || locale == "" || !strings.HasSuffix(etag, `"`) {
+		return etag
+	}
+	return strings.TrimSuffix(etag, `"`) + "-" + locale + `"`
+}
+
+func baseETag(etag string) string {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, suffix := range []string{`-gzip"`, `-br"`} {
+		if strings.HasSuffix(etag, suffix) {
+			return strings.TrimSuffix(etag, suffix) + `"`
+		}
+	}
+	return etag
+}
+
+// WriteNotModified writes a 304 carrying the validators, as required by RFC 7232
+func WriteNotModified(w http.ResponseWriter, etag string, lastModified string) {
+	h := w.Header()
+	h.Del("Content-Type")
+	h.Del("Content-Length")
+	if etag != "" {
+		h.Set("ETag", etag)
+	}
+	if lastModified != "" {
+		h.Set("Last-Modified", lastModified)
+	}
+	w.WriteHeader(http.StatusNotModified)
+}
+
+// CacheControl parses a Cache-Control header into its directives, lower-casing names and unquoting values
+func CacheControl(header string) map[string]string {
+	directives := map[string]string{}
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value := part, ""
+		if i := strings.Index(part, "="); i >= 0 {
+			name, value = part[:i], strings.Trim(strings.TrimSpace(part[i+1:]), `"`)
+		}
+		directives[strings.ToLower(strings.TrimSpace(name))] = value
+	}
+	return directives
+}
@@ -0,0 +1,119 @@
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNotModified(t *testing.T) {
+	lastModified := LastModified(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name    string
+		method  string
+		headers map[string]string
+		etag    string
+		want    bool
+	}{
+		{
+			name:    "Success::If-None-Match matches",
+			method:  http.MethodGet,
+			headers: map[string]string{"If-None-Match": `"x", "abc"`},
+			etag:    `"abc"`,
+			want:    true,
+		},
+		{
+			name:    "Success::If-None-Match weak comparison",
+			method:  http.MethodGet,
+			headers: map[string]string{"If-None-Match": `W/"abc"`},
+			etag:    `"abc"`,
+			want:    true,
+		},
+		{
+			name:    "Success::If-None-Match takes precedence",
+			method:  http.MethodGet,
+			headers: map[string]string{"If-None-Match": `"x"`, "If-Modified-Since": lastModified},
+			etag:    `"abc"`,
+			want:    false,
+		},
+		{
+			name:    "Success::If-Modified-Since not modified",
+			method:  http.MethodGet,
+			headers: map[string]string{"If-Modified-Since": lastModified},
+			want:    true,
+		},
+		{
+			name:    "Success::If-Modified-Since modified",
+			method:  http.MethodGet,
+			headers: map[string]string{"If-Modified-Since": "Sun, 01 Jan 2023 00:00:00 GMT"},
+			want:    false,
+		},
+		{
+			name:    "Failure::invalid If-Modified-Since",
+			method:  http.MethodGet,
+			headers: map[string]string{"If-Modified-Since": "yesterday"},
+			want:    false,
+		},
+		{
+			name:    "Success::If-None-Match with compressed representation tag",
+			method:  http.MethodGet,
+			headers: map[string]string{"If-None-Match": `"abc-gzip"`},
+			etag:    `"abc"`,
+			want:    true,
+		},
+		{
+			name:    "Failure::not a GET",
+			method:  http.MethodPost,
+			headers: map[string]string{"If-None-Match": "*"},
+			etag:    `"abc"`,
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/articles", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			if got := NotModified(r, tt.etag, lastModified); got != tt.want {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestETag(t *testing.T) {
+	if ETag([]byte("a")) == ETag([]byte("b")) {
+		t.Errorf("Want: different tags, Got: %v", ETag([]byte("a")))
+	}
+	if ETag([]byte("a")) != ETag([]byte("a")) {
+		t.Errorf("Want: stable tag, Got: %v", ETag([]byte("a")))
+	}
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		header string
+		want   int
+		ok     bool
+	}{
+		{header: VersionETag(3), want: 3, ok: true},
+		{header: EncodedETag(VersionETag(3), "gzip"), want: 3, ok: true},
+		{header: LocalizedETag(VersionETag(3), "fr")},
+		{header: VersionedETag(3, []byte("body")), want: 3, ok: true},
+		{header: EncodedETag(VersionedETag(3, []byte("body")), "br"), want: 3, ok: true},
+		{header: LocalizedETag(VersionedETag(3, []byte("body")), "fr")},
+		{header: `W/"v3"`},
+		{header: `"v3", "v4"`},
+		{header: `*`},
+		{header: `"abc"`},
+		{header: ``},
+	}
+	for _, tt := range tests {
+		got, ok := IfMatchVersion(tt.header)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Want: %v %v, Got: %v %v", tt.want, tt.ok, got, ok)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package i18n negotiates the language articles are served in. Locales are BCP 47 language tags in their
+// canonical form, e.g. en, pt-BR or zh-Hant-TW.
+package i18n
+
+import (
+	"golang.org/x/text/language"
+	"net/http"
+	"strings"
+)
+
+// DefaultLocale is the language of articles written without one
+const DefaultLocale = "en"
+
+// multiple is the tag the Accept-Language wildcard parses to
+var multiple = language.Make("mul")
+
+// maxPreferences bounds the locales taken from a request, they end up in cache keys
+const maxPreferences = 8
+
+// Normalize returns the canonical form of a language tag, reporting whether it is one. The undetermined and multiple
+// languages tags, the latter being what the Accept-Language wildcard parses to, are not.
+func Normalize(locale string) (string, bool) {
+	locale = strings.TrimSpace(locale)
+	if locale == "" {
+		return "", false
+	}
+	tag, err := language.Parse(locale)
+	if err != nil || tag == language.Und || tag == multiple {
+		return "", false
+	}
+	return tag.String(), true
+}
+
+// Preferences returns the locales the request asks for, most preferred first: the comma separated ?lang, or else
+// the Accept-Language header by weight. Each locale is followed by its language alone, so that a request for fr-CA
+// falls back to fr before the next locale. Tags that do not parse are skipped.
+func Preferences(r *http.Request) []string {
+	var requested []string
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		requested = strings.Split(lang, ",")
+	} else if header := r.Header.Get("Accept-Language"); header != "" {
+		tags, _, err := language.ParseAcceptLanguage(header)
+		if err != nil {
+			return nil
+		}
+		for _, tag := range tags {
+			requested = append(requested, tag.String())
+		}
+	}
+	var preferences []string
+	seen := map[string]bool{}
+	add := func(locale string) {
+		if !seen[locale] && len(preferences) < maxPreferences {
+			seen[locale] = true
+			preferences = append(preferences, locale)
+		}
+	}
+	for _, locale := range requested {
+		locale, ok := Normalize(locale)
+		if !ok {
+			continue
+		}
+		add(locale)
+		if i := strings.IndexByte(locale, '-'); i > 0 {
+			add(locale[:i])
+		}
+	}
+	return preferences
+}
+
+// Select returns the first of preferences among the available locales, reporting whether there was one
+func Select(preferences []string, available []string) (string, bool) {
+	for _, preferred := range preferences {
+		for _, locale := range available {
+			if strings.EqualFold(preferred, locale) {
+				return locale, true
+			}
+		}
+	}
+	return "", false
+}
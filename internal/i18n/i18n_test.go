@@ -0,0 +1,68 @@
+package i18n
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestPreferences(t *testing.T) {
+	tests := []struct {
+		name           string
+		target         string
+		acceptLanguage string
+		want           []string
+	}{
+		{name: "none", target: "/articles/1", want: nil},
+		{name: "query", target: "/articles/1?lang=pt-br", want: []string{"pt-BR", "pt"}},
+		{name: "query wins over header", target: "/articles/1?lang=de", acceptLanguage: "fr", want: []string{"de"}},
+		{name: "query list", target: "/articles/1?lang=fr-CA,en,bogus-tag-!", want: []string{"fr-CA", "fr", "en"}},
+		{name: "header by weight", target: "/articles/1", acceptLanguage: "en;q=0.5, fr-CA, de;q=0.8", want: []string{"fr-CA", "fr", "de", "en"}},
+		{name: "header wildcard", target: "/articles/1", acceptLanguage: "*", want: nil},
+		{name: "header malformed", target: "/articles/1", acceptLanguage: "en;q=x;;", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			if tt.acceptLanguage != "" {
+				r.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			if got := Preferences(r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	available := []string{"en", "fr", "pt-BR"}
+	tests := []struct {
+		preferences []string
+		want        string
+		found       bool
+	}{
+		{preferences: []string{"fr-CA", "fr", "en"}, want: "fr", found: true},
+		{preferences: []string{"pt-BR", "pt"}, want: "pt-BR", found: true},
+		{preferences: []string{"de"}, found: false},
+		{preferences: nil, found: false},
+	}
+	for _, tt := range tests {
+		got, found := Select(tt.preferences, available)
+		if got != tt.want || found != tt.found {
+			t.Errorf("Want: %v %v, Got: %v %v", tt.want, tt.found, got, found)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for locale, want := range map[string]string{"EN": "en", "pt-br": "pt-BR", "zh-hant-tw": "zh-Hant-TW", " de ": "de"} {
+		if got, ok := Normalize(locale); !ok || got != want {
+			t.Errorf("Want: %v, Got: %v %v", want, got, ok)
+		}
+	}
+	for _, locale := range []string{"", "und", "not a tag", "e"} {
+		if got, ok := Normalize(locale); ok {
+			t.Errorf("Want: invalid, Got: %v", got)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package logic
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"log"
+	"net/http"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_apikey_logic.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/logic ApiKeyLogicI
+
+type ApiKeyLogicI interface {
+	CreateApiKey(req *model.ApiKeyRequest) *model.Response
+	ListApiKeys() *model.Response
+	RotateApiKey(id string, req *model.ApiKeyRotateRequest) *model.Response
+	RevokeApiKey(id string) *model.Response
+}
+
+type ApiKeyLogic struct {
+	DsSvc datasource.ApiKeyDataSourceI
+}
+
+func NewApiKeyLogicI(ds datasource.ApiKeyDataSourceI) ApiKeyLogicI {
+	return &ApiKeyLogic{
+		DsSvc: ds,
+	}
+}
+
+func (l ApiKeyLogic) CreateApiKey(req *model.ApiKeyRequest) *model.Response {
+	expiresAt, resp := expiry(req.ExpiresIn)
+	if resp != nil {
+		return resp
+	}
+	return l.issue(model.ApiKeyDs{
+		Id:        uuid.NewString(),
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: expiresAt,
+	}, http.StatusCreated, l.DsSvc.InsertApiKey)
+}
+
+func (l ApiKeyLogic) ListApiKeys() *model.Response {
+	keys, err := l.DsSvc.GetApiKeys(nil)
+	if err != nil {
+		log.Print(codes.GetErr(codes.ErrDataSource), err)
+		return &model.Response{
+			Status:  http.StatusInternalServerError,
+			Message: codes.GetErr(codes.ErrDataSource),
+			Data:    nil,
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    keys,
+	}
+}
+
+// RotateApiKey issues a new key with the same name and scopes and revokes the live key with the given id in one
+// transaction. The new key expires when the old one would have, or after req.ExpiresIn when given.
+func (l ApiKeyLogic) RotateApiKey(id string, req *model.ApiKeyRotateRequest) *model.Response {
+	old, resp := l.get(id)
+	if resp != nil {
+		return resp
+	}
+	if old.RevokedAt != nil || (old.ExpiresAt != nil && !old.ExpiresAt.After(time.Now())) {
+		log.Print(codes.GetErr(codes.ErrApiKeyInactive))
+		return &model.Response{
+			Status:  http.StatusConflict,
+			Message: codes.GetErr(codes.ErrApiKeyInactive),
+			Data:    nil,
+		}
+	}
+	expiresAt := old.ExpiresAt
+	if req.ExpiresIn != "" {
+		if expiresAt, resp = expiry(req.ExpiresIn); resp != nil {
+			return resp
+		}
+	}
+	return l.issue(model.ApiKeyDs{
+		Id:        uuid.NewString(),
+		Name:      old.Name,
+		Scopes:    old.Scopes,
+		ExpiresAt: expiresAt,
+	}, http.StatusCreated, func(key model.ApiKeyDs) error {
+		return l.DsSvc.ReplaceApiKey(id, key)
+	})
+}
+
+func (l ApiKeyLogic) RevokeApiKey(id string) *model.Response {
+	key, resp := l.get(id)
+	if resp != nil {
+		return resp
+	}
+	if key.RevokedAt == nil {
+		err := l.DsSvc.UpdateApiKey(id, map[string]interface{}{"revoked_at": time.Now()})
+		if err != nil {
+			log.Print(codes.GetErr(codes.ErrDataSource), err)
+			return &model.Response{
+				Status:  http.StatusInternalServerError,
+				Message: codes.GetErr(codes.ErrDataSource),
+				Data:    nil,
+			}
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]string{"id": id},
+	}
+}
+
+// get returns the key with the given id, or the response to send when it cannot be loaded
+func (l ApiKeyLogic) get(id string) (*model.ApiKeyDs, *model.Response) {
+	keys, err := l.DsSvc.GetApiKeys(map[string]interface{}{"id": id})
+	if err != nil {
+		log.Print(codes.GetErr(codes.ErrDataSource), err)
+		return nil, &model.Response{
+			Status:  http.StatusInternalServerError,
+			Message: codes.GetErr(codes.ErrDataSource),
+			Data:    nil,
+		}
+	}
+	if len(keys) == 0 {
+		log.Print(codes.GetErr(codes.ErrApiKeyNotFound))
+		return nil, &model.Response{
+			Status:  http.StatusNotFound,
+			Message: codes.GetErr(codes.ErrApiKeyNotFound),
+			Data:    nil,
+		}
+	}
+	return &keys[0], nil
+}
+
+// expiry parses expiresIn, keys without one never expire
+func expiry(expiresIn string) (*time.Time, *model.Response) {
+	if expiresIn == "" {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(expiresIn)
+	if err != nil || d <= 0 {
+		log.Print(codes.GetErr(codes.ErrInvalidExpiry), err)
+		return nil, &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrInvalidExpiry),
+			Data:    nil,
+		}
+	}
+	t := time.Now().Add(d)
+	return &t, nil
+}
+
+// issue generates the secret of key, stores its hash with store and returns the plaintext key
+func (l ApiKeyLogic) issue(key model.ApiKeyDs, status int, store func(model.ApiKeyDs) error) *model.Response {
+	plain, prefix, hash, err := auth.GenerateApiKey()
+	if err != nil {
+		log.Print(err)
+		return &model.Response{
+			Status:  http.StatusInternalServerError,
+			Message: http.StatusText(http.StatusInternalServerError),
+			Data:    nil,
+		}
+	}
+	key.Prefix = prefix
+	key.KeyHash = hash
+	key.CreatedAt = time.Now()
+	err = store(key)
+	if errors.Is(err, datasource.ErrApiKeyInactive) {
+		// the key to rotate was revoked or expired since it was read
+		log.Print(codes.GetErr(codes.ErrApiKeyInactive), err)
+		return &model.Response{
+			Status:  http.StatusConflict,
+			Message: codes.GetErr(codes.ErrApiKeyInactive),
+			Data:    nil,
+		}
+	}
+	if err != nil {
+		log.Print(codes.GetErr(codes.ErrDataSource), err)
+		return &model.Response{
+			Status:  http.StatusInternalServerError,
+			Message: codes.GetErr(codes.ErrDataSource),
+			Data:    nil,
+		}
+	}
+	return &model.Response{
+		Status:  status,
+		Message: "Success",
+		Data:    model.ApiKeyCreated{ApiKeyDs: key, Key: plain},
+	}
+}
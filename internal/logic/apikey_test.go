@@ -0,0 +1,174 @@
+package logic
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestApiKeyLogic_CreateApiKey(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name       string
+		give       *model.ApiKeyRequest
+		setupFunc  func(*mock.MockApiKeyDataSourceI)
+		wantStatus int
+	}{
+		{
+			name: "Success",
+			give: &model.ApiKeyRequest{Name: "ingest", Scopes: []string{"articles:write"}, ExpiresIn: "720h"},
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().InsertApiKey(gomock.Any()).DoAndReturn(func(key model.ApiKeyDs) error {
+					if key.Name != "ingest" || key.KeyHash == "" || key.ExpiresAt == nil {
+						t.Errorf("Want: %v, Got: %v", "hashed key with expiry", key)
+					}
+					return nil
+				})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "Failure:: invalid expiry",
+			give:       &model.ApiKeyRequest{Name: "ingest", ExpiresIn: "soon"},
+			setupFunc:  func(ds *mock.MockApiKeyDataSourceI) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Failure:: Datasource Error",
+			give: &model.ApiKeyRequest{Name: "ingest"},
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().InsertApiKey(gomock.Any()).Return(errors.New("error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := mock.NewMockApiKeyDataSourceI(mockCtrl)
+			tt.setupFunc(ds)
+			resp := NewApiKeyLogicI(ds).CreateApiKey(tt.give)
+			if resp.Status != tt.wantStatus {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, resp.Status)
+			}
+			if created, ok := resp.Data.(model.ApiKeyCreated); ok && created.Key == "" {
+				t.Errorf("Want: %v, Got: %v", "plaintext key", created.Key)
+			}
+		})
+	}
+}
+
+func TestApiKeyLogic_RotateAndRevoke(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	earlier, later := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	tests := []struct {
+		name      string
+		rotate    bool
+		expiresIn string
+		setupFunc func(*mock.MockApiKeyDataSourceI)
+		want      *model.Response
+	}{
+		{
+			name: "Success:: Revoke",
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().GetApiKeys(map[string]interface{}{"id": "1"}).Return([]model.ApiKeyDs{{Id: "1"}}, nil)
+				ds.EXPECT().UpdateApiKey("1", gomock.Any()).Return(nil)
+			},
+			want: &model.Response{Status: http.StatusOK, Message: "Success", Data: map[string]string{"id": "1"}},
+		},
+		{
+			name: "Failure:: Revoke:: not found",
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().GetApiKeys(map[string]interface{}{"id": "1"}).Return(nil, nil)
+			},
+			want: &model.Response{Status: http.StatusNotFound, Message: codes.GetErr(codes.ErrApiKeyNotFound)},
+		},
+		{
+			name:   "Success:: Rotate",
+			rotate: true,
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().GetApiKeys(map[string]interface{}{"id": "1"}).Return([]model.ApiKeyDs{{Id: "1", Name: "ingest", ExpiresAt: &later}}, nil)
+				ds.EXPECT().ReplaceApiKey("1", gomock.Any()).DoAndReturn(func(_ string, key model.ApiKeyDs) error {
+					if key.Id == "1" || key.Name != "ingest" || key.ExpiresAt != &later {
+						t.Errorf("Want: %v, Got: %v", "new key named ingest keeping the expiry", key)
+					}
+					return nil
+				})
+			},
+			want: &model.Response{Status: http.StatusCreated, Message: "Success"},
+		},
+		{
+			name:      "Success:: Rotate:: new lifetime",
+			rotate:    true,
+			expiresIn: "1h",
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().GetApiKeys(map[string]interface{}{"id": "1"}).Return([]model.ApiKeyDs{{Id: "1", Name: "ingest"}}, nil)
+				ds.EXPECT().ReplaceApiKey("1", gomock.Any()).DoAndReturn(func(_ string, key model.ApiKeyDs) error {
+					if key.ExpiresAt == nil || key.ExpiresAt.After(time.Now().Add(time.Hour)) {
+						t.Errorf("Want: %v, Got: %v", "expiry within an hour", key.ExpiresAt)
+					}
+					return nil
+				})
+			},
+			want: &model.Response{Status: http.StatusCreated, Message: "Success"},
+		},
+		{
+			name:   "Failure:: Rotate:: revoked",
+			rotate: true,
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().GetApiKeys(map[string]interface{}{"id": "1"}).Return([]model.ApiKeyDs{{Id: "1", RevokedAt: &earlier}}, nil)
+			},
+			want: &model.Response{Status: http.StatusConflict, Message: codes.GetErr(codes.ErrApiKeyInactive)},
+		},
+		{
+			name:   "Failure:: Rotate:: expired",
+			rotate: true,
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().GetApiKeys(map[string]interface{}{"id": "1"}).Return([]model.ApiKeyDs{{Id: "1", ExpiresAt: &earlier}}, nil)
+			},
+			want: &model.Response{Status: http.StatusConflict, Message: codes.GetErr(codes.ErrApiKeyInactive)},
+		},
+		{
+			name:   "Failure:: Rotate:: revoked meanwhile",
+			rotate: true,
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().GetApiKeys(map[string]interface{}{"id": "1"}).Return([]model.ApiKeyDs{{Id: "1"}}, nil)
+				ds.EXPECT().ReplaceApiKey("1", gomock.Any()).Return(datasource.ErrApiKeyInactive)
+			},
+			want: &model.Response{Status: http.StatusConflict, Message: codes.GetErr(codes.ErrApiKeyInactive)},
+		},
+		{
+			name:      "Failure:: Rotate:: invalid lifetime",
+			rotate:    true,
+			expiresIn: "-1h",
+			setupFunc: func(ds *mock.MockApiKeyDataSourceI) {
+				ds.EXPECT().GetApiKeys(map[string]interface{}{"id": "1"}).Return([]model.ApiKeyDs{{Id: "1"}}, nil)
+			},
+			want: &model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrInvalidExpiry)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := mock.NewMockApiKeyDataSourceI(mockCtrl)
+			tt.setupFunc(ds)
+			l := NewApiKeyLogicI(ds)
+			if tt.rotate {
+				if resp := l.RotateApiKey("1", &model.ApiKeyRotateRequest{ExpiresIn: tt.expiresIn}); resp.Status != tt.want.Status || resp.Message != tt.want.Message {
+					t.Errorf("Want: %v, Got: %v", tt.want, resp)
+				}
+				return
+			}
+			if resp := l.RevokeApiKey("1"); !reflect.DeepEqual(tt.want, resp) {
+				t.Errorf("Want: %v, Got: %v", tt.want, resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package logic
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"log"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_author_logic.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/logic AuthorLogicI
+
+type AuthorLogicI interface {
+	GetAuthors() *model.Response
+	GetAuthor(id string) *model.Response
+	InsertAuthor(req *model.AuthorRequest) *model.Response
+	UpdateAuthor(id string, req *model.AuthorRequest) *model.Response
+	// GetAuthorArticles lists the published articles of an author
+	GetAuthorArticles(id string, limit int, page int) *model.Response
+	// MigrateAuthors moves the author names stored on articles into author profiles
+	MigrateAuthors() *model.Response
+}
+
+type AuthorLogic struct {
+	DsSvc        datasource.AuthorDataSourceI
+	ArticleDsSvc datasource.DataSourceI
+}
+
+func NewAuthorLogicI(ds datasource.AuthorDataSourceI, articles datasource.DataSourceI) AuthorLogicI {
+	return &AuthorLogic{
+		DsSvc:        ds,
+		ArticleDsSvc: articles,
+	}
+}
+
+func (l AuthorLogic) GetAuthors() *model.Response {
+	authors, err := l.DsSvc.GetAuthors(nil)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if authors == nil {
+		authors = []model.AuthorDs{}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    authors,
+	}
+}
+
+func (l AuthorLogic) GetAuthor(id string) *model.Response {
+	authors, err := l.DsSvc.GetAuthors(map[string]interface{}{"id": id})
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if len(authors) == 0 {
+		log.Print(codes.GetErr(codes.ErrAuthorNotFound))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrAuthorNotFound),
+			Data:    nil,
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    authors[0],
+	}
+}
+
+// InsertAuthor creates the profile of an author who has not written through the API, such as a guest author
+func (l AuthorLogic) InsertAuthor(req *model.AuthorRequest) *model.Response {
+	author := model.AuthorDs{
+		Id:        uuid.NewString(),
+		Name:      req.Name,
+		Bio:       req.Bio,
+		AvatarUrl: req.AvatarUrl,
+	}
+	err := l.DsSvc.InsertAuthor(author)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusCreated,
+		Message: "Success",
+		Data:    map[string]string{"id": author.Id},
+	}
+}
+
+func (l AuthorLogic) UpdateAuthor(id string, req *model.AuthorRequest) *model.Response {
+	if resp := l.GetAuthor(id); resp.Status != http.StatusOK {
+		return resp
+	}
+	err := l.DsSvc.UpdateAuthor(id, map[string]interface{}{"name": req.Name, "bio": req.Bio, "avatar_url": req.AvatarUrl})
+	if err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]string{"id": id},
+	}
+}
+
+func (l AuthorLogic) GetAuthorArticles(id string, limit int, page int) *model.Response {
+	if resp := l.GetAuthor(id); resp.Status != http.StatusOK {
+		return resp
+	}
+	articles, err := l.ArticleDsSvc.Get(map[string]interface{}{"author_id": id, "status": model.StatusPublished}, limit, (page-1)*limit)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    articles,
+	}
+}
+
+func (l AuthorLogic) MigrateAuthors() *model.Response {
+	n, err := l.DsSvc.MigrateAuthors()
+	if errors.Is(err, datasource.ErrAmbiguousAuthor) {
+		log.Print(codes.GetErr(codes.ErrAmbiguousAuthor), err)
+		return &model.Response{
+			Status:  http.StatusConflict,
+			Message: codes.GetErr(codes.ErrAmbiguousAuthor),
+			Data:    map[string]string{"error": err.Error()},
+		}
+	}
+	if err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]int64{"articles_linked": n},
+	}
+}
@@ -0,0 +1,62 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAuthorLogic_GetAuthorArticles(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name  string
+		setup func(*mock.MockAuthorDataSourceI, *mock.MockDataSourceI)
+		want  *model.Response
+	}{
+		{
+			name: "Success:: published articles of the author",
+			setup: func(ds *mock.MockAuthorDataSourceI, articles *mock.MockDataSourceI) {
+				ds.EXPECT().GetAuthors(map[string]interface{}{"id": "user-1"}).Return([]model.AuthorDs{{Id: "user-1"}}, nil)
+				articles.EXPECT().Get(map[string]interface{}{"author_id": "user-1", "status": model.StatusPublished}, 10, 10).
+					Return([]model.ArticleDs{{Id: "1", Author: "Jane Doe"}}, nil)
+			},
+			want: &model.Response{Status: http.StatusOK, Message: "Success", Data: []model.ArticleDs{{Id: "1", Author: "Jane Doe"}}},
+		},
+		{
+			name: "Failure:: unknown author",
+			setup: func(ds *mock.MockAuthorDataSourceI, articles *mock.MockDataSourceI) {
+				ds.EXPECT().GetAuthors(map[string]interface{}{"id": "user-1"}).Return(nil, nil)
+			},
+			want: &model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrAuthorNotFound)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDs := mock.NewMockAuthorDataSourceI(mockCtrl)
+			mockArticles := mock.NewMockDataSourceI(mockCtrl)
+			tt.setup(mockDs, mockArticles)
+			got := NewAuthorLogicI(mockDs, mockArticles).GetAuthorArticles("user-1", 10, 2)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAuthorLogic_MigrateAuthors(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockDs := mock.NewMockAuthorDataSourceI(mockCtrl)
+	mockDs.EXPECT().MigrateAuthors().Return(int64(0), fmt.Errorf("%w: jane doe", datasource.ErrAmbiguousAuthor))
+	got := NewAuthorLogicI(mockDs, nil).MigrateAuthors()
+	if got.Status != http.StatusConflict || got.Message != codes.GetErr(codes.ErrAmbiguousAuthor) {
+		t.Errorf("Want: %v, Got: %v", http.StatusConflict, got)
+	}
+}
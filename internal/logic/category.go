@@ -0,0 +1,274 @@
+package logic
+
+import (
+	"github.com/google/uuid"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"log"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_category_logic.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/logic CategoryLogicI
+
+type CategoryLogicI interface {
+	GetCategories() *model.Response
+	GetCategory(id string) *model.Response
+	InsertCategory(req *model.CategoryRequest) *model.Response
+	UpdateCategory(id string, req *model.CategoryRequest) *model.Response
+	DeleteCategory(id string) *model.Response
+	AssignCategory(articleId string, req *model.AssignCategoryRequest) *model.Response
+	// GetCategoryArticles lists the published articles of a category and, with descendants, of its subcategories
+	GetCategoryArticles(id string, limit int, page int, descendants bool) *model.Response
+}
+
+type CategoryLogic struct {
+	DsSvc        datasource.CategoryDataSourceI
+	ArticleDsSvc datasource.DataSourceI
+}
+
+func NewCategoryLogicI(ds datasource.CategoryDataSourceI, articles datasource.DataSourceI) CategoryLogicI {
+	return &CategoryLogic{
+		DsSvc:        ds,
+		ArticleDsSvc: articles,
+	}
+}
+
+func (l CategoryLogic) GetCategories() *model.Response {
+	categories, err := l.DsSvc.GetCategories(nil)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if categories == nil {
+		categories = []model.CategoryDs{}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    categories,
+	}
+}
+
+func (l CategoryLogic) GetCategory(id string) *model.Response {
+	categories, err := l.DsSvc.GetCategories(map[string]interface{}{"id": id})
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if len(categories) == 0 {
+		return categoryNotFound()
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    categories[0],
+	}
+}
+
+func (l CategoryLogic) InsertCategory(req *model.CategoryRequest) *model.Response {
+	if req.ParentId != nil {
+		if resp := l.GetCategory(*req.ParentId); resp.Status != http.StatusOK {
+			return resp
+		}
+	}
+	category := model.CategoryDs{
+		Id:       uuid.NewString(),
+		Name:     req.Name,
+		ParentId: req.ParentId,
+	}
+	err := l.DsSvc.InsertCategory(category)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusCreated,
+		Message: "Success",
+		Data:    map[string]string{"id": category.Id},
+	}
+}
+
+// UpdateCategory renames a category and moves it under another parent, refusing to move it below itself
+func (l CategoryLogic) UpdateCategory(id string, req *model.CategoryRequest) *model.Response {
+	categories, err := l.DsSvc.GetCategories(nil)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	tree := newCategoryTree(categories)
+	if _, ok := tree.byId[id]; !ok {
+		return categoryNotFound()
+	}
+	if req.ParentId != nil {
+		if _, ok := tree.byId[*req.ParentId]; !ok {
+			return categoryNotFound()
+		}
+		if tree.isAncestor(id, *req.ParentId) {
+			log.Print(codes.GetErr(codes.ErrCategoryCycle))
+			return &model.Response{
+				Status:  http.StatusConflict,
+				Message: codes.GetErr(codes.ErrCategoryCycle),
+				Data:    nil,
+			}
+		}
+	}
+	err = l.DsSvc.UpdateCategory(id, map[string]interface{}{"name": req.Name, "parent_id": req.ParentId})
+	if err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]string{"id": id},
+	}
+}
+
+// DeleteCategory removes a category that has neither subcategories nor articles
+func (l CategoryLogic) DeleteCategory(id string) *model.Response {
+	if resp := l.GetCategory(id); resp.Status != http.StatusOK {
+		return resp
+	}
+	children, err := l.DsSvc.GetCategories(map[string]interface{}{"parent_id": id})
+	if err != nil {
+		return dataSourceError(err)
+	}
+	articles, err := l.ArticleDsSvc.Get(map[string]interface{}{"category_id": id}, 1, 0)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if len(children) > 0 || len(articles) > 0 {
+		log.Print(codes.GetErr(codes.ErrCategoryInUse))
+		return &model.Response{
+			Status:  http.StatusConflict,
+			Message: codes.GetErr(codes.ErrCategoryInUse),
+			Data:    nil,
+		}
+	}
+	err = l.DsSvc.DeleteCategory(id)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]string{"id": id},
+	}
+}
+
+func (l CategoryLogic) AssignCategory(articleId string, req *model.AssignCategoryRequest) *model.Response {
+	articles, err := l.ArticleDsSvc.Get(map[string]interface{}{"id": articleId}, 1, 0)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if len(articles) == 0 {
+		log.Print(codes.GetErr(codes.ErrArticleNotFound))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrArticleNotFound),
+			Data:    nil,
+		}
+	}
+	if req.CategoryId != nil {
+		if resp := l.GetCategory(*req.CategoryId); resp.Status != http.StatusOK {
+			return resp
+		}
+	}
+	err = l.ArticleDsSvc.Update(articleId, map[string]interface{}{"category_id": req.CategoryId})
+	if err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]interface{}{"id": articleId, "category_id": req.CategoryId},
+	}
+}
+
+func (l CategoryLogic) GetCategoryArticles(id string, limit int, page int, descendants bool) *model.Response {
+	categories, err := l.DsSvc.GetCategories(nil)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	tree := newCategoryTree(categories)
+	if _, ok := tree.byId[id]; !ok {
+		return categoryNotFound()
+	}
+	ids := []string{id}
+	if descendants {
+		ids = tree.descendants(id)
+	}
+	filter := map[string]interface{}{"status": model.StatusPublished, model.FilterCategories: ids}
+	articles, err := l.ArticleDsSvc.Get(filter, limit, (page-1)*limit)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    articles,
+	}
+}
+
+// categoryTree indexes the categories by id and by parent
+type categoryTree struct {
+	byId     map[string]model.CategoryDs
+	children map[string][]string
+}
+
+func newCategoryTree(categories []model.CategoryDs) categoryTree {
+	t := categoryTree{byId: map[string]model.CategoryDs{}, children: map[string][]string{}}
+	for _, c := range categories {
+		t.byId[c.Id] = c
+		if c.ParentId != nil {
+			t.children[*c.ParentId] = append(t.children[*c.ParentId], c.Id)
+		}
+	}
+	return t
+}
+
+// isAncestor reports whether ancestor is id itself or one of its parents
+func (t categoryTree) isAncestor(ancestor string, id string) bool {
+	seen := map[string]bool{}
+	for !seen[id] {
+		if id == ancestor {
+			return true
+		}
+		seen[id] = true
+		c, ok := t.byId[id]
+		if !ok || c.ParentId == nil {
+			return false
+		}
+		id = *c.ParentId
+	}
+	return false
+}
+
+// descendants returns id followed by the ids of every category below it
+func (t categoryTree) descendants(id string) []string {
+	ids := []string{id}
+	seen := map[string]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range t.children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
+
+func categoryNotFound() *model.Response {
+	log.Print(codes.GetErr(codes.ErrCategoryNotFound))
+	return &model.Response{
+		Status:  http.StatusBadRequest,
+		Message: codes.GetErr(codes.ErrCategoryNotFound),
+		Data:    nil,
+	}
+}
+
+func dataSourceError(err error) *model.Response {
+	log.Print(codes.GetErr(codes.ErrDataSource), err)
+	return &model.Response{
+		Status:  http.StatusInternalServerError,
+		Message: codes.GetErr(codes.ErrDataSource),
+		Data:    nil,
+	}
+}
@@ -0,0 +1,121 @@
+package logic
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// tree is news > tech > go, with sport as a second root
+func categoryTreeFixture() []model.CategoryDs {
+	news, tech := "news", "tech"
+	return []model.CategoryDs{
+		{Id: "news", Name: "News"},
+		{Id: "tech", Name: "Tech", ParentId: &news},
+		{Id: "go", Name: "Go", ParentId: &tech},
+		{Id: "sport", Name: "Sport"},
+	}
+}
+
+func TestCategoryLogic_UpdateCategory(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	parent := func(id string) *string { return &id }
+	tests := []struct {
+		name  string
+		id    string
+		req   model.CategoryRequest
+		setup func(*mock.MockCategoryDataSourceI)
+		want  int
+	}{
+		{
+			name: "Success:: moved under another root",
+			id:   "tech",
+			req:  model.CategoryRequest{Name: "Tech", ParentId: parent("sport")},
+			setup: func(ds *mock.MockCategoryDataSourceI) {
+				ds.EXPECT().UpdateCategory("tech", map[string]interface{}{"name": "Tech", "parent_id": parent("sport")}).Return(nil)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name:  "Failure:: moved below its own descendant",
+			id:    "news",
+			req:   model.CategoryRequest{Name: "News", ParentId: parent("go")},
+			setup: func(ds *mock.MockCategoryDataSourceI) {},
+			want:  http.StatusConflict,
+		},
+		{
+			name:  "Failure:: parent of itself",
+			id:    "go",
+			req:   model.CategoryRequest{Name: "Go", ParentId: parent("go")},
+			setup: func(ds *mock.MockCategoryDataSourceI) {},
+			want:  http.StatusConflict,
+		},
+		{
+			name:  "Failure:: unknown parent",
+			id:    "go",
+			req:   model.CategoryRequest{Name: "Go", ParentId: parent("missing")},
+			setup: func(ds *mock.MockCategoryDataSourceI) {},
+			want:  http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDs := mock.NewMockCategoryDataSourceI(mockCtrl)
+			mockDs.EXPECT().GetCategories(nil).Return(categoryTreeFixture(), nil)
+			tt.setup(mockDs)
+			got := NewCategoryLogicI(mockDs, mock.NewMockDataSourceI(mockCtrl)).UpdateCategory(tt.id, &tt.req)
+			if got.Status != tt.want {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCategoryLogic_DeleteCategory_InUse(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDs := mock.NewMockCategoryDataSourceI(mockCtrl)
+	mockDs.EXPECT().GetCategories(map[string]interface{}{"id": "go"}).Return(categoryTreeFixture()[2:3], nil)
+	mockDs.EXPECT().GetCategories(map[string]interface{}{"parent_id": "go"}).Return(nil, nil)
+	mockArticles := mock.NewMockDataSourceI(mockCtrl)
+	mockArticles.EXPECT().Get(map[string]interface{}{"category_id": "go"}, 1, 0).Return([]model.ArticleDs{{Id: "1"}}, nil)
+
+	got := NewCategoryLogicI(mockDs, mockArticles).DeleteCategory("go")
+	want := &model.Response{Status: http.StatusConflict, Message: codes.GetErr(codes.ErrCategoryInUse)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestCategoryLogic_GetCategoryArticles(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name        string
+		descendants bool
+		ids         []string
+	}{
+		{name: "Success:: category only", ids: []string{"news"}},
+		{name: "Success:: with descendants", descendants: true, ids: []string{"news", "tech", "go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDs := mock.NewMockCategoryDataSourceI(mockCtrl)
+			mockDs.EXPECT().GetCategories(nil).Return(categoryTreeFixture(), nil)
+			mockArticles := mock.NewMockDataSourceI(mockCtrl)
+			filter := map[string]interface{}{"status": model.StatusPublished, model.FilterCategories: tt.ids}
+			mockArticles.EXPECT().Get(filter, 10, 10).Return([]model.ArticleDs{{Id: "1"}}, nil)
+
+			got := NewCategoryLogicI(mockDs, mockArticles).GetCategoryArticles("news", 10, 2, tt.descendants)
+			if got.Status != http.StatusOK {
+				t.Errorf("Want: %v, Got: %v", http.StatusOK, got)
+			}
+		})
+	}
+}
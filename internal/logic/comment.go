@@ -0,0 +1,194 @@
+package logic
+
+import (
+	"github.com/google/uuid"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"log"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_comment_logic.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/logic CommentLogicI
+
+// Moderation actions on comments
+const (
+	ActionApproveComment = "approve"
+	ActionRejectComment  = "reject"
+)
+
+var moderation = map[string]string{
+	ActionApproveComment: model.CommentApproved,
+	ActionRejectComment:  model.CommentRejected,
+}
+
+type CommentLogicI interface {
+	// PostComment adds a comment, or a reply when req has a parent, to a published article. It awaits moderation.
+	PostComment(articleId string, req *model.CommentRequest) *model.Response
+	// GetComments lists a page of the approved top-level comments of a published article with their approved replies
+	GetComments(articleId string, limit int, page int) *model.Response
+	// GetModerationQueue lists the comments in the given status across all articles, oldest first
+	GetModerationQueue(status string, limit int, page int) *model.Response
+	// ModerateComment approves or rejects a comment of the article
+	ModerateComment(articleId string, commentId string, action string) *model.Response
+}
+
+type CommentLogic struct {
+	DsSvc        datasource.CommentDataSourceI
+	ArticleDsSvc datasource.DataSourceI
+}
+
+func NewCommentLogicI(ds datasource.CommentDataSourceI, articles datasource.DataSourceI) CommentLogicI {
+	return &CommentLogic{
+		DsSvc:        ds,
+		ArticleDsSvc: articles,
+	}
+}
+
+func (l CommentLogic) PostComment(articleId string, req *model.CommentRequest) *model.Response {
+	if resp := l.publishedArticle(articleId); resp != nil {
+		return resp
+	}
+	if req.ParentId != nil {
+		parents, err := l.DsSvc.GetComments(map[string]interface{}{"id": *req.ParentId}, 1, 0)
+		if err != nil {
+			return dataSourceError(err)
+		}
+		if len(parents) == 0 || parents[0].ArticleId != articleId || parents[0].ParentId != nil || parents[0].Status != model.CommentApproved {
+			log.Print(codes.GetErr(codes.ErrInvalidCommentParent))
+			return &model.Response{
+				Status:  http.StatusBadRequest,
+				Message: codes.GetErr(codes.ErrInvalidCommentParent),
+				Data:    nil,
+			}
+		}
+	}
+	comment := model.CommentDs{
+		Id:        uuid.NewString(),
+		ArticleId: articleId,
+		ParentId:  req.ParentId,
+		AuthorId:  req.AuthorId,
+		Author:    req.Author,
+		Body:      req.Body,
+		Status:    model.CommentPending,
+	}
+	err := l.DsSvc.InsertComment(comment)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusCreated,
+		Message: "Success",
+		Data:    map[string]string{"id": comment.Id, "status": comment.Status},
+	}
+}
+
+func (l CommentLogic) GetComments(articleId string, limit int, page int) *model.Response {
+	if resp := l.publishedArticle(articleId); resp != nil {
+		return resp
+	}
+	filter := map[string]interface{}{"article_id": articleId, "status": model.CommentApproved, model.FilterTopLevel: true}
+	comments, err := l.DsSvc.GetComments(filter, limit, (page-1)*limit)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if comments == nil {
+		comments = []model.CommentDs{}
+	}
+	index := make(map[string]int, len(comments))
+	ids := make([]string, 0, len(comments))
+	for i, comment := range comments {
+		index[comment.Id] = i
+		ids = append(ids, comment.Id)
+	}
+	if len(ids) > 0 {
+		replies, err := l.DsSvc.GetComments(map[string]interface{}{"status": model.CommentApproved, model.FilterParents: ids}, 0, 0)
+		if err != nil {
+			return dataSourceError(err)
+		}
+		for _, reply := range replies {
+			if i, ok := index[*reply.ParentId]; ok {
+				comments[i].Replies = append(comments[i].Replies, reply)
+			}
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    comments,
+	}
+}
+
+func (l CommentLogic) GetModerationQueue(status string, limit int, page int) *model.Response {
+	if !model.ValidCommentStatus(status) {
+		log.Print(codes.GetErr(codes.ErrInvalidCommentStatus))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrInvalidCommentStatus),
+			Data:    nil,
+		}
+	}
+	comments, err := l.DsSvc.GetComments(map[string]interface{}{"status": status}, limit, (page-1)*limit)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if comments == nil {
+		comments = []model.CommentDs{}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    comments,
+	}
+}
+
+func (l CommentLogic) ModerateComment(articleId string, commentId string, action string) *model.Response {
+	status, ok := moderation[action]
+	if !ok {
+		log.Print(codes.GetErr(codes.ErrUnknownTransition))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrUnknownTransition),
+			Data:    nil,
+		}
+	}
+	comments, err := l.DsSvc.GetComments(map[string]interface{}{"id": commentId, "article_id": articleId}, 1, 0)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if len(comments) == 0 {
+		log.Print(codes.GetErr(codes.ErrCommentNotFound))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrCommentNotFound),
+			Data:    nil,
+		}
+	}
+	err = l.DsSvc.UpdateComment(commentId, map[string]interface{}{"status": status})
+	if err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]string{"id": commentId, "status": status},
+	}
+}
+
+// publishedArticle returns the response to send when the article does not exist or is not published,
+// comments are neither shown nor accepted on articles the public cannot read
+func (l CommentLogic) publishedArticle(id string) *model.Response {
+	articles, err := l.ArticleDsSvc.Get(map[string]interface{}{"id": id, "status": model.StatusPublished}, 1, 0)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if len(articles) == 0 {
+		log.Print(codes.GetErr(codes.ErrArticleNotFound))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrArticleNotFound),
+			Data:    nil,
+		}
+	}
+	return nil
+}
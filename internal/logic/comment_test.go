@@ -0,0 +1,118 @@
+package logic
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func publishedArticleFixture(ds *mock.MockDataSourceI) {
+	ds.EXPECT().Get(map[string]interface{}{"id": "1", "status": model.StatusPublished}, 1, 0).Return([]model.ArticleDs{{Id: "1"}}, nil)
+}
+
+func TestCommentLogic_PostComment(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	parent, nested := "c1", "r1"
+	tests := []struct {
+		name  string
+		req   model.CommentRequest
+		setup func(*mock.MockCommentDataSourceI)
+		want  int
+	}{
+		{
+			name: "Success:: comment awaits moderation",
+			req:  model.CommentRequest{Body: "Nice", AuthorId: "user-1", Author: "Jane"},
+			setup: func(ds *mock.MockCommentDataSourceI) {
+				ds.EXPECT().InsertComment(gomock.Any()).DoAndReturn(func(c model.CommentDs) error {
+					if c.Status != model.CommentPending || c.AuthorId != "user-1" || c.ArticleId != "1" {
+						t.Errorf("Want: %v, Got: %v", "pending comment by user-1", c)
+					}
+					return nil
+				})
+			},
+			want: http.StatusCreated,
+		},
+		{
+			name: "Success:: reply to a top-level comment",
+			req:  model.CommentRequest{Body: "Agreed", ParentId: &parent},
+			setup: func(ds *mock.MockCommentDataSourceI) {
+				ds.EXPECT().GetComments(map[string]interface{}{"id": "c1"}, 1, 0).
+					Return([]model.CommentDs{{Id: "c1", ArticleId: "1", Status: model.CommentApproved}}, nil)
+				ds.EXPECT().InsertComment(gomock.Any()).Return(nil)
+			},
+			want: http.StatusCreated,
+		},
+		{
+			name: "Failure:: reply to a reply",
+			req:  model.CommentRequest{Body: "Agreed", ParentId: &nested},
+			setup: func(ds *mock.MockCommentDataSourceI) {
+				ds.EXPECT().GetComments(map[string]interface{}{"id": "r1"}, 1, 0).
+					Return([]model.CommentDs{{Id: "r1", ArticleId: "1", ParentId: &parent, Status: model.CommentApproved}}, nil)
+			},
+			want: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDs := mock.NewMockCommentDataSourceI(mockCtrl)
+			mockArticles := mock.NewMockDataSourceI(mockCtrl)
+			publishedArticleFixture(mockArticles)
+			tt.setup(mockDs)
+			got := NewCommentLogicI(mockDs, mockArticles).PostComment("1", &tt.req)
+			if got.Status != tt.want {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCommentLogic_GetComments(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	parent := "c1"
+	reply := model.CommentDs{Id: "r1", ArticleId: "1", ParentId: &parent, Status: model.CommentApproved}
+
+	mockDs := mock.NewMockCommentDataSourceI(mockCtrl)
+	mockDs.EXPECT().GetComments(map[string]interface{}{"article_id": "1", "status": model.CommentApproved, model.FilterTopLevel: true}, 10, 10).
+		Return([]model.CommentDs{{Id: "c1", ArticleId: "1", Status: model.CommentApproved}, {Id: "c2", ArticleId: "1", Status: model.CommentApproved}}, nil)
+	mockDs.EXPECT().GetComments(map[string]interface{}{"status": model.CommentApproved, model.FilterParents: []string{"c1", "c2"}}, 0, 0).
+		Return([]model.CommentDs{reply}, nil)
+	mockArticles := mock.NewMockDataSourceI(mockCtrl)
+	publishedArticleFixture(mockArticles)
+
+	got := NewCommentLogicI(mockDs, mockArticles).GetComments("1", 10, 2)
+	want := &model.Response{Status: http.StatusOK, Message: "Success", Data: []model.CommentDs{
+		{Id: "c1", ArticleId: "1", Status: model.CommentApproved, Replies: []model.CommentDs{reply}},
+		{Id: "c2", ArticleId: "1", Status: model.CommentApproved},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestCommentLogic_ModerateComment(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDs := mock.NewMockCommentDataSourceI(mockCtrl)
+	mockDs.EXPECT().GetComments(map[string]interface{}{"id": "c1", "article_id": "1"}, 1, 0).Return([]model.CommentDs{{Id: "c1", ArticleId: "1"}}, nil)
+	mockDs.EXPECT().UpdateComment("c1", map[string]interface{}{"status": model.CommentRejected}).Return(nil)
+	mockDs.EXPECT().GetComments(map[string]interface{}{"id": "c9", "article_id": "1"}, 1, 0).Return(nil, nil)
+	rec := NewCommentLogicI(mockDs, mock.NewMockDataSourceI(mockCtrl))
+
+	got := rec.ModerateComment("1", "c1", ActionRejectComment)
+	want := &model.Response{Status: http.StatusOK, Message: "Success", Data: map[string]string{"id": "c1", "status": model.CommentRejected}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+	got = rec.ModerateComment("1", "c9", ActionApproveComment)
+	want = &model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrCommentNotFound)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
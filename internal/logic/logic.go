@@ -1,12 +1,16 @@
 package logic
 
 import (
+	"errors"
 	"github.com/google/uuid"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/i18n"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/render"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
 	"log"
 	"net/http"
+	"time"
 )
 
 //go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_logic.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/logic ArticleManagementLogicI
@@ -14,27 +18,105 @@ import (
 type ArticleManagementLogicI interface {
 	InsertArticle(req *model.Article) *model.Response
 	GetArticle(id string) *model.Response
-	GetAllArticle(limit int, page int) *model.Response
+	// GetArticleBySlug returns the article at slug, or 301 with the article when slug is one of its former slugs
+	GetArticleBySlug(slug string) *model.Response
+	GetAllArticle(limit int, page int, filter map[string]interface{}) *model.Response
+	UpdateArticle(id string, req *model.Article) *model.Response
+	TransitionArticle(id string, action string) *model.Response
+	ScheduleArticle(id string, req *model.ScheduleRequest) *model.Response
+	GetRevisions(id string) *model.Response
+	GetRevision(id string, rev int) *model.Response
+	DiffRevisions(id string, from int, to int) *model.Response
+	RestoreRevision(id string, rev int, version int, editor string) *model.Response
+	GetTags() *model.Response
+	// RunSchedule publishes and withdraws the articles due at now and returns the ids it changed
+	RunSchedule(now time.Time) ([]string, error)
+}
+
+// Workflow actions moving an article between statuses
+const (
+	ActionSubmit  = "submit"
+	ActionApprove = "approve"
+	ActionReject  = "reject"
+	ActionPublish = "publish"
+	ActionArchive = "archive"
+)
+
+type transition struct {
+	from []string
+	to   string
+}
+
+// transitions lists the statuses each workflow action may be taken from and the status it leads to.
+// Approving an article scheduled for later leaves it approved, the scheduler publishes it in time.
+// Only reviewed articles are published, archived ones are submitted for review again.
+var transitions = map[string]transition{
+	ActionSubmit:  {from: []string{model.StatusDraft, model.StatusArchived}, to: model.StatusInReview},
+	ActionApprove: {from: []string{model.StatusInReview}, to: model.StatusPublished},
+	ActionReject:  {from: []string{model.StatusInReview, model.StatusApproved}, to: model.StatusDraft},
+	ActionPublish: {from: []string{model.StatusApproved}, to: model.StatusPublished},
+	ActionArchive: {from: []string{model.StatusPublished}, to: model.StatusArchived},
 }
 
 type ArticleManagementLogic struct {
-	DsSvc datasource.DataSourceI
+	DsSvc       datasource.DataSourceI
+	RevisionSvc datasource.RevisionDataSourceI
 }
 
-func NewArticleManagementLogicI(ds datasource.DataSourceI) ArticleManagementLogicI {
+func NewArticleManagementLogicI(ds datasource.DataSourceI, revisions datasource.RevisionDataSourceI) ArticleManagementLogicI {
 	return &ArticleManagementLogic{
-		DsSvc: ds,
+		DsSvc:       ds,
+		RevisionSvc: revisions,
 	}
 }
 
 func (l ArticleManagementLogic) InsertArticle(req *model.Article) *model.Response {
+	tags, ok := NormalizeTags(req.Tags)
+	if !ok {
+		return invalidTags()
+	}
+	locale := i18n.DefaultLocale
+	if req.Locale != "" {
+		if locale, ok = i18n.Normalize(req.Locale); !ok {
+			return invalidLocale()
+		}
+	}
+	format := req.ContentFormat
+	if format == "" {
+		format = render.FormatPlain
+	}
 	article := model.ArticleDs{
-		Id:      uuid.NewString(),
-		Title:   req.Title,
-		Author:  req.Author,
-		Content: req.Content,
+		Id:            uuid.NewString(),
+		Title:         req.Title,
+		Author:        req.Author,
+		AuthorId:      req.AuthorId,
+		Content:       req.Content,
+		ContentFormat: format,
+		ContentHtml:   render.Html(format, req.Content),
+		Locale:        locale,
+		Status:        model.StatusDraft,
+		Tags:          tags,
+	}
+	var err error
+	taken := map[string]bool{}
+	for attempt := 1; ; attempt++ {
+		article.Slug, err = l.uniqueSlug(article.Title, article.Id, taken)
+		if err == nil {
+			err = l.DsSvc.Insert(article, model.RevisionDs{
+				ArticleId:     article.Id,
+				Title:         article.Title,
+				Content:       article.Content,
+				ContentFormat: format,
+				Author:        article.Author,
+				Editor:        req.Editor,
+			})
+		}
+		// a concurrent write took the slug after it was picked, the next suffix is tried
+		if !errors.Is(err, datasource.ErrSlugTaken) || attempt == maxSlugAttempts {
+			break
+		}
+		taken[article.Slug] = true
 	}
-	err := l.DsSvc.Insert(article)
 	if err != nil {
 		log.Print(codes.GetErr(codes.ErrDataSource), err)
 		return &model.Response{
@@ -46,7 +128,7 @@ func (l ArticleManagementLogic) InsertArticle(req *model.Article) *model.Respons
 	return &model.Response{
 		Status:  http.StatusCreated,
 		Message: "Success",
-		Data:    map[string]string{"id": article.Id},
+		Data:    map[string]string{"id": article.Id, "slug": article.Slug},
 	}
 }
 
@@ -75,9 +157,16 @@ func (l ArticleManagementLogic) GetArticle(id string) *model.Response {
 	}
 }
 
-func (l ArticleManagementLogic) GetAllArticle(limit int, page int) *model.Response {
+func (l ArticleManagementLogic) GetAllArticle(limit int, page int, filter map[string]interface{}) *model.Response {
+	if f, ok := filter[model.FilterTags].(model.TagFilter); ok {
+		tags, ok := NormalizeTags(f.Tags)
+		if !ok {
+			return invalidTags()
+		}
+		filter[model.FilterTags] = model.TagFilter{Tags: tags, All: f.All}
+	}
 	offset := (page - 1) * limit
-	articles, err := l.DsSvc.Get(nil, limit, offset)
+	articles, err := l.DsSvc.Get(filter, limit, offset)
 	if err != nil {
 		log.Print(codes.GetErr(codes.ErrDataSource), err)
 		return &model.Response{
@@ -92,3 +181,197 @@ func (l ArticleManagementLogic) GetAllArticle(limit int, page int) *model.Respon
 		Data:    articles,
 	}
 }
+
+// UpdateArticle replaces the title and content of an article and records the result as a new revision in the
+// same transaction, its author is left unchanged. When req carries a version the article must still be at that version.
+// A new title gives the article a new slug, the old one is kept so that it redirects to the new one.
+func (l ArticleManagementLogic) UpdateArticle(id string, req *model.Article) *model.Response {
+	tags, ok := NormalizeTags(req.Tags)
+	if !ok {
+		return invalidTags()
+	}
+	resp := l.GetArticle(id)
+	if resp.Status != http.StatusOK {
+		return resp
+	}
+	article := resp.Data.([]model.ArticleDs)[0]
+	format := req.ContentFormat
+	if format == "" {
+		format = article.ContentFormat
+	}
+	if format == "" {
+		format = render.FormatPlain
+	}
+	fields := map[string]interface{}{"title": req.Title, "content": req.Content, "content_format": format, "content_html": render.Html(format, req.Content)}
+	if req.Locale != "" {
+		locale, ok := i18n.Normalize(req.Locale)
+		if !ok {
+			return invalidLocale()
+		}
+		fields["locale"] = locale
+	}
+	edit := model.ArticleEdit{
+		Fields:  fields,
+		Version: req.Version,
+		Tags:    tags,
+		Revision: model.RevisionDs{
+			ArticleId:     id,
+			Title:         req.Title,
+			Content:       req.Content,
+			ContentFormat: format,
+			Author:        article.Author,
+			Editor:        req.Editor,
+		},
+	}
+	var (
+		updated bool
+		err     error
+	)
+	taken := map[string]bool{}
+	for attempt := 1; ; attempt++ {
+		var s string
+		if s, err = l.setSlug(&edit, article, req.Title, taken); err != nil {
+			return dataSourceError(err)
+		}
+		updated, err = l.DsSvc.Edit(id, edit)
+		// a concurrent write took the slug after it was picked, the next suffix is tried
+		if !errors.Is(err, datasource.ErrSlugTaken) || attempt == maxSlugAttempts {
+			break
+		}
+		taken[s] = true
+	}
+	if err != nil {
+		log.Print(codes.GetErr(codes.ErrDataSource), err)
+		return &model.Response{
+			Status:  http.StatusInternalServerError,
+			Message: codes.GetErr(codes.ErrDataSource),
+			Data:    nil,
+		}
+	}
+	if !updated {
+		log.Print(codes.GetErr(codes.ErrVersionMismatch))
+		return &model.Response{
+			Status:  http.StatusPreconditionFailed,
+			Message: codes.GetErr(codes.ErrVersionMismatch),
+			Data:    map[string]interface{}{"id": id, "version": article.Version},
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]string{"id": id},
+	}
+}
+
+// TransitionArticle applies a workflow action to an article if its current status allows it. The status is
+// checked again by the update, so of two concurrent actions only the first applies.
+func (l ArticleManagementLogic) TransitionArticle(id string, action string) *model.Response {
+	t, ok := transitions[action]
+	if !ok {
+		log.Print(codes.GetErr(codes.ErrUnknownTransition))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrUnknownTransition),
+			Data:    nil,
+		}
+	}
+	resp := l.GetArticle(id)
+	if resp.Status != http.StatusOK {
+		return resp
+	}
+	article := resp.Data.([]model.ArticleDs)[0]
+	allowed := false
+	for _, from := range t.from {
+		allowed = allowed || article.Status == from
+	}
+	if !allowed {
+		log.Print(codes.GetErr(codes.ErrInvalidTransition))
+		return &model.Response{
+			Status:  http.StatusConflict,
+			Message: codes.GetErr(codes.ErrInvalidTransition),
+			Data:    map[string]string{"id": id, "status": article.Status},
+		}
+	}
+	to := t.to
+	if action == ActionApprove && article.PublishAt != nil && article.PublishAt.After(time.Now()) {
+		to = model.StatusApproved
+	}
+	updated, err := l.DsSvc.UpdateIfStatus(id, article.Status, map[string]interface{}{"status": to})
+	if err != nil {
+		log.Print(codes.GetErr(codes.ErrDataSource), err)
+		return &model.Response{
+			Status:  http.StatusInternalServerError,
+			Message: codes.GetErr(codes.ErrDataSource),
+			Data:    nil,
+		}
+	}
+	if !updated {
+		log.Print(codes.GetErr(codes.ErrStatusChanged))
+		return &model.Response{
+			Status:  http.StatusConflict,
+			Message: codes.GetErr(codes.ErrStatusChanged),
+			Data:    map[string]string{"id": id},
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]string{"id": id, "status": to},
+	}
+}
+
+// ScheduleArticle sets when an article goes live and, optionally, when it is withdrawn again.
+// A nil time clears that side of the schedule.
+func (l ArticleManagementLogic) ScheduleArticle(id string, req *model.ScheduleRequest) *model.Response {
+	if req.PublishAt != nil && req.UnpublishAt != nil && !req.UnpublishAt.After(*req.PublishAt) {
+		log.Print(codes.GetErr(codes.ErrInvalidSchedule))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrInvalidSchedule),
+			Data:    nil,
+		}
+	}
+	resp := l.GetArticle(id)
+	if resp.Status != http.StatusOK {
+		return resp
+	}
+	err := l.DsSvc.Update(id, map[string]interface{}{"publish_at": req.PublishAt, "unpublish_at": req.UnpublishAt})
+	if err != nil {
+		log.Print(codes.GetErr(codes.ErrDataSource), err)
+		return &model.Response{
+			Status:  http.StatusInternalServerError,
+			Message: codes.GetErr(codes.ErrDataSource),
+			Data:    nil,
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]interface{}{"id": id, "publish_at": req.PublishAt, "unpublish_at": req.UnpublishAt},
+	}
+}
+
+// RunSchedule publishes approved articles whose publish_at has passed and archives published
+// articles whose unpublish_at has passed. The consumed time is cleared so a later manual
+// transition is not undone by the next run. Articles whose status changed since they were read are left alone.
+func (l ArticleManagementLogic) RunSchedule(now time.Time) ([]string, error) {
+	due, err := l.DsSvc.GetDue(now)
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	for _, article := range due {
+		fields := map[string]interface{}{"status": model.StatusPublished, "publish_at": nil}
+		if article.Status == model.StatusPublished {
+			fields = map[string]interface{}{"status": model.StatusArchived, "unpublish_at": nil}
+		}
+		updated, err := l.DsSvc.UpdateIfStatus(article.Id, article.Status, fields)
+		if err != nil {
+			return changed, err
+		}
+		if updated {
+			changed = append(changed, article.Id)
+		}
+	}
+	return changed, nil
+}
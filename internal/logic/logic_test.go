@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestArticleManagementLogic_InsertArticle(t *testing.T) {
@@ -27,13 +28,19 @@ func TestArticleManagementLogic_InsertArticle(t *testing.T) {
 			setup: func() datasource.DataSourceI {
 				mockDs := mock.NewMockDataSourceI(mockCtrl)
 				x := model.ArticleDs{
-					Id:      "1",
-					Title:   "title",
-					Content: "content",
-					Author:  "author",
+					Id:            "1",
+					Title:         "title",
+					Content:       "content",
+					ContentFormat: "plain",
+					ContentHtml:   "<p>content</p>\n",
+					Locale:        "en",
+					Author:        "author",
+					Status:        model.StatusDraft,
+					Slug:          "title",
 				}
-				mockDs.EXPECT().Insert(gomock.Any()).Times(1).
-					DoAndReturn(func(article model.ArticleDs) *model.Response {
+				mockDs.EXPECT().GetSlugOwners("title").Return(nil, nil)
+				mockDs.EXPECT().Insert(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(article model.ArticleDs, revision model.RevisionDs) error {
 						article.Id = "1"
 						if !reflect.DeepEqual(article, x) {
 							t.Logf("Want: %v, Got: %v", x, article)
@@ -59,12 +66,18 @@ func TestArticleManagementLogic_InsertArticle(t *testing.T) {
 			setup: func() datasource.DataSourceI {
 				mockDs := mock.NewMockDataSourceI(mockCtrl)
 				x := model.ArticleDs{
-					Title:   "title",
-					Content: "content",
-					Author:  "author",
+					Title:         "title",
+					Content:       "content",
+					ContentFormat: "plain",
+					ContentHtml:   "<p>content</p>\n",
+					Locale:        "en",
+					Author:        "author",
+					Status:        model.StatusDraft,
+					Slug:          "title",
 				}
-				mockDs.EXPECT().Insert(gomock.Any()).Times(1).
-					DoAndReturn(func(article model.ArticleDs) *model.Response {
+				mockDs.EXPECT().GetSlugOwners("title").Return(nil, nil)
+				mockDs.EXPECT().Insert(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(article model.ArticleDs, revision model.RevisionDs) error {
 						article.Id = ""
 						if !reflect.DeepEqual(article, x) {
 							t.Logf("Want: %v, Got: %v", x, article)
@@ -87,7 +100,7 @@ func TestArticleManagementLogic_InsertArticle(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			rec := NewArticleManagementLogicI(tt.setup())
+			rec := NewArticleManagementLogicI(tt.setup(), nil)
 			got := rec.InsertArticle(tt.give)
 			if !reflect.DeepEqual(got.Status, tt.want.Status) {
 				t.Logf("Want: %v, Got: %v", tt.want.Status, got.Status)
@@ -164,7 +177,7 @@ func TestArticleManagementLogic_GetArticle(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			rec := NewArticleManagementLogicI(tt.setup())
+			rec := NewArticleManagementLogicI(tt.setup(), nil)
 			got := rec.GetArticle("1")
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Logf("Want: %v, Got: %v", tt.want, got)
@@ -200,7 +213,7 @@ func TestArticleManagementLogic_GetAllArticle(t *testing.T) {
 					Content: "content",
 					Author:  "author",
 				}
-				mockDs.EXPECT().Get(nil, 5, 0).Times(1).Return([]model.ArticleDs{x, y}, nil)
+				mockDs.EXPECT().Get(map[string]interface{}{"status": model.StatusPublished}, 5, 0).Times(1).Return([]model.ArticleDs{x, y}, nil)
 				return mockDs
 			},
 			want: &model.Response{
@@ -224,7 +237,60 @@ func TestArticleManagementLogic_GetAllArticle(t *testing.T) {
 			name: "Failure:: Datasource Error",
 			setup: func() datasource.DataSourceI {
 				mockDs := mock.NewMockDataSourceI(mockCtrl)
-				mockDs.EXPECT().Get(nil, 5, 0).Times(1).Return(nil, errors.New(""))
+				mockDs.EXPECT().Get(map[string]interface{}{"status": model.StatusPublished}, 5, 0).Times(1).Return(nil, errors.New(""))
+				return mockDs
+			},
+			want: &model.Response{
+				Status:  http.StatusInternalServerError,
+				Message: codes.GetErr(codes.ErrDataSource),
+				Data:    nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewArticleManagementLogicI(tt.setup(), nil)
+			got := rec.GetAllArticle(5, 1, map[string]interface{}{"status": model.StatusPublished})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Logf("Want: %v, Got: %v", tt.want, got)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestArticleManagementLogic_UpdateArticle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name  string
+		setup func() datasource.DataSourceI
+		want  *model.Response
+	}{
+		{
+			name: "Success",
+			setup: func() datasource.DataSourceI {
+				mockDs := mock.NewMockDataSourceI(mockCtrl)
+				mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Title: "title", Slug: "title", Author: "author"}}, nil)
+				mockDs.EXPECT().Edit("1", model.ArticleEdit{
+					Fields:   map[string]interface{}{"title": "title", "content": "content", "content_format": "plain", "content_html": "<p>content</p>\n"},
+					Revision: model.RevisionDs{ArticleId: "1", Title: "title", Content: "content", ContentFormat: "plain", Author: "author", Editor: "user-1"},
+				}).Times(1).Return(true, nil)
+				return mockDs
+			},
+			want: &model.Response{
+				Status:  http.StatusOK,
+				Message: "Success",
+				Data:    map[string]string{"id": "1"},
+			},
+		},
+		{
+			name: "Failure:: Datasource Error",
+			setup: func() datasource.DataSourceI {
+				mockDs := mock.NewMockDataSourceI(mockCtrl)
+				mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Title: "title", Slug: "title", Author: "author"}}, nil)
+				mockDs.EXPECT().Edit("1", gomock.Any()).Times(1).Return(false, errors.New(""))
 				return mockDs
 			},
 			want: &model.Response{
@@ -236,8 +302,8 @@ func TestArticleManagementLogic_GetAllArticle(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			rec := NewArticleManagementLogicI(tt.setup())
-			got := rec.GetAllArticle(5, 1)
+			rec := NewArticleManagementLogicI(tt.setup(), nil)
+			got := rec.UpdateArticle("1", &model.Article{Title: "title", Content: "content", Editor: "user-1"})
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Logf("Want: %v, Got: %v", tt.want, got)
 				t.Fail()
@@ -245,3 +311,244 @@ func TestArticleManagementLogic_GetAllArticle(t *testing.T) {
 		})
 	}
 }
+
+func TestArticleManagementLogic_TransitionArticle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tests := []struct {
+		name   string
+		action string
+		setup  func() datasource.DataSourceI
+		want   *model.Response
+	}{
+		{
+			name:   "Success:: submit draft",
+			action: ActionSubmit,
+			setup: func() datasource.DataSourceI {
+				mockDs := mock.NewMockDataSourceI(mockCtrl)
+				mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Status: model.StatusDraft}}, nil)
+				mockDs.EXPECT().UpdateIfStatus("1", model.StatusDraft, map[string]interface{}{"status": model.StatusInReview}).Return(true, nil)
+				return mockDs
+			},
+			want: &model.Response{
+				Status:  http.StatusOK,
+				Message: "Success",
+				Data:    map[string]string{"id": "1", "status": model.StatusInReview},
+			},
+		},
+		{
+			name:   "Success:: approve article scheduled for later",
+			action: ActionApprove,
+			setup: func() datasource.DataSourceI {
+				later := time.Now().Add(time.Hour)
+				mockDs := mock.NewMockDataSourceI(mockCtrl)
+				mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Status: model.StatusInReview, PublishAt: &later}}, nil)
+				mockDs.EXPECT().UpdateIfStatus("1", model.StatusInReview, map[string]interface{}{"status": model.StatusApproved}).Return(true, nil)
+				return mockDs
+			},
+			want: &model.Response{
+				Status:  http.StatusOK,
+				Message: "Success",
+				Data:    map[string]string{"id": "1", "status": model.StatusApproved},
+			},
+		},
+		{
+			name:   "Failure:: approve draft",
+			action: ActionApprove,
+			setup: func() datasource.DataSourceI {
+				mockDs := mock.NewMockDataSourceI(mockCtrl)
+				mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Status: model.StatusDraft}}, nil)
+				return mockDs
+			},
+			want: &model.Response{
+				Status:  http.StatusConflict,
+				Message: codes.GetErr(codes.ErrInvalidTransition),
+				Data:    map[string]string{"id": "1", "status": model.StatusDraft},
+			},
+		},
+		{
+			name:   "Failure:: rejected meanwhile",
+			action: ActionPublish,
+			setup: func() datasource.DataSourceI {
+				mockDs := mock.NewMockDataSourceI(mockCtrl)
+				mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Status: model.StatusApproved}}, nil)
+				mockDs.EXPECT().UpdateIfStatus("1", model.StatusApproved, map[string]interface{}{"status": model.StatusPublished}).Return(false, nil)
+				return mockDs
+			},
+			want: &model.Response{
+				Status:  http.StatusConflict,
+				Message: codes.GetErr(codes.ErrStatusChanged),
+				Data:    map[string]string{"id": "1"},
+			},
+		},
+		{
+			name:   "Failure:: publish draft without review",
+			action: ActionPublish,
+			setup: func() datasource.DataSourceI {
+				mockDs := mock.NewMockDataSourceI(mockCtrl)
+				mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Status: model.StatusDraft}}, nil)
+				return mockDs
+			},
+			want: &model.Response{
+				Status:  http.StatusConflict,
+				Message: codes.GetErr(codes.ErrInvalidTransition),
+				Data:    map[string]string{"id": "1", "status": model.StatusDraft},
+			},
+		},
+		{
+			name:   "Success:: resubmit archived article",
+			action: ActionSubmit,
+			setup: func() datasource.DataSourceI {
+				mockDs := mock.NewMockDataSourceI(mockCtrl)
+				mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Status: model.StatusArchived}}, nil)
+				mockDs.EXPECT().UpdateIfStatus("1", model.StatusArchived, map[string]interface{}{"status": model.StatusInReview}).Return(true, nil)
+				return mockDs
+			},
+			want: &model.Response{
+				Status:  http.StatusOK,
+				Message: "Success",
+				Data:    map[string]string{"id": "1", "status": model.StatusInReview},
+			},
+		},
+		{
+			name:   "Failure:: unknown action",
+			action: "delete",
+			setup: func() datasource.DataSourceI {
+				return mock.NewMockDataSourceI(mockCtrl)
+			},
+			want: &model.Response{
+				Status:  http.StatusBadRequest,
+				Message: codes.GetErr(codes.ErrUnknownTransition),
+				Data:    nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewArticleManagementLogicI(tt.setup(), nil)
+			got := rec.TransitionArticle("1", tt.action)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Logf("Want: %v, Got: %v", tt.want, got)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestArticleManagementLogic_ScheduleArticle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	publishAt := time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC)
+	unpublishAt := publishAt.Add(24 * time.Hour)
+
+	tests := []struct {
+		name  string
+		req   *model.ScheduleRequest
+		setup func() datasource.DataSourceI
+		want  *model.Response
+	}{
+		{
+			name: "Success:: schedule article",
+			req:  &model.ScheduleRequest{PublishAt: &publishAt, UnpublishAt: &unpublishAt},
+			setup: func() datasource.DataSourceI {
+				mockDs := mock.NewMockDataSourceI(mockCtrl)
+				mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Status: model.StatusDraft}}, nil)
+				mockDs.EXPECT().Update("1", map[string]interface{}{"publish_at": &publishAt, "unpublish_at": &unpublishAt}).Return(nil)
+				return mockDs
+			},
+			want: &model.Response{
+				Status:  http.StatusOK,
+				Message: "Success",
+				Data:    map[string]interface{}{"id": "1", "publish_at": &publishAt, "unpublish_at": &unpublishAt},
+			},
+		},
+		{
+			name: "Failure:: unpublish before publish",
+			req:  &model.ScheduleRequest{PublishAt: &unpublishAt, UnpublishAt: &publishAt},
+			setup: func() datasource.DataSourceI {
+				return mock.NewMockDataSourceI(mockCtrl)
+			},
+			want: &model.Response{
+				Status:  http.StatusBadRequest,
+				Message: codes.GetErr(codes.ErrInvalidSchedule),
+				Data:    nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := NewArticleManagementLogicI(tt.setup(), nil)
+			got := rec.ScheduleArticle("1", tt.req)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Logf("Want: %v, Got: %v", tt.want, got)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestArticleManagementLogic_RunSchedule(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	now := time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	mockDs := mock.NewMockDataSourceI(mockCtrl)
+	mockDs.EXPECT().GetDue(now).Return([]model.ArticleDs{{Id: "1", Status: model.StatusApproved}, {Id: "2", Status: model.StatusPublished}, {Id: "3", Status: model.StatusApproved}}, nil)
+	mockDs.EXPECT().UpdateIfStatus("1", model.StatusApproved, map[string]interface{}{"status": model.StatusPublished, "publish_at": nil}).Return(true, nil)
+	mockDs.EXPECT().UpdateIfStatus("2", model.StatusPublished, map[string]interface{}{"status": model.StatusArchived, "unpublish_at": nil}).Return(true, nil)
+	// rejected by an editor since it was read
+	mockDs.EXPECT().UpdateIfStatus("3", model.StatusApproved, map[string]interface{}{"status": model.StatusPublished, "publish_at": nil}).Return(false, nil)
+
+	got, err := NewArticleManagementLogicI(mockDs, nil).RunSchedule(now)
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	if want := []string{"1", "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestArticleManagementLogic_UpdateArticle_Version(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDs := mock.NewMockDataSourceI(mockCtrl)
+	mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Title: "title", Slug: "title", Author: "author", Version: 4}}, nil)
+	mockDs.EXPECT().Edit("1", gomock.Any()).DoAndReturn(func(id string, edit model.ArticleEdit) (bool, error) {
+		if edit.Version != 3 {
+			t.Errorf("Want: %v, Got: %v", 3, edit.Version)
+		}
+		return false, nil
+	})
+
+	got := NewArticleManagementLogicI(mockDs, mock.NewMockRevisionDataSourceI(mockCtrl)).
+		UpdateArticle("1", &model.Article{Title: "title", Content: "content", Version: 3})
+	want := &model.Response{
+		Status:  http.StatusPreconditionFailed,
+		Message: codes.GetErr(codes.ErrVersionMismatch),
+		Data:    map[string]interface{}{"id": "1", "version": 4},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestArticleManagementLogic_UpdateArticle_KeepsContentFormat(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDs := mock.NewMockDataSourceI(mockCtrl)
+	mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Title: "title", Slug: "title", ContentFormat: "markdown", Version: 3}}, nil)
+	mockDs.EXPECT().Edit("1", model.ArticleEdit{
+		Fields:   map[string]interface{}{"title": "title", "content": "*new*", "content_format": "markdown", "content_html": "<p><em>new</em></p>\n"},
+		Version:  3,
+		Revision: model.RevisionDs{ArticleId: "1", Title: "title", Content: "*new*", ContentFormat: "markdown"},
+	}).Return(true, nil)
+
+	got := NewArticleManagementLogicI(mockDs, nil).
+		UpdateArticle("1", &model.Article{Title: "title", Content: "*new*", Version: 3})
+	if got.Status != http.StatusOK {
+		t.Errorf("Want: %v, Got: %v", http.StatusOK, got)
+	}
+}
@@ -0,0 +1,256 @@
+package logic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/blob"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/media"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"log"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_media_logic.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/logic MediaLogicI
+
+const maxFilenameLength = 255
+
+type MediaLogicI interface {
+	// UploadMedia stores a file attached to the article, together with the thumbnail of images
+	UploadMedia(articleId string, upload *model.MediaUpload) *model.Response
+	// GetArticleMedia lists the files attached to the article, oldest first
+	GetArticleMedia(articleId string) *model.Response
+	// GetMedia returns the record of the file with the given id without opening it
+	GetMedia(id string) *model.Response
+	// OpenMedia opens the stored file, or its thumbnail. Files without a thumbnail are their own thumbnail.
+	OpenMedia(m model.MediaDs, thumbnail bool) *model.Response
+	// DeleteMedia removes a file attached to the article
+	DeleteMedia(articleId string, mediaId string) *model.Response
+}
+
+type MediaLogic struct {
+	DsSvc        datasource.MediaDataSourceI
+	ArticleDsSvc datasource.DataSourceI
+	Store        blob.StoreI
+	Processor    media.ProcessorI
+}
+
+func NewMediaLogicI(ds datasource.MediaDataSourceI, articles datasource.DataSourceI, store blob.StoreI, processor media.ProcessorI) MediaLogicI {
+	return &MediaLogic{
+		DsSvc:        ds,
+		ArticleDsSvc: articles,
+		Store:        store,
+		Processor:    processor,
+	}
+}
+
+// UploadMedia checks the file against the accepted types, which are sniffed from its bytes, and stores it under a key
+// of its own so that stored files never change and may be cached for good
+func (l MediaLogic) UploadMedia(articleId string, upload *model.MediaUpload) *model.Response {
+	articles, err := l.ArticleDsSvc.Get(map[string]interface{}{"id": articleId}, 1, 0)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if len(articles) == 0 {
+		log.Print(codes.GetErr(codes.ErrArticleNotFound))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrArticleNotFound),
+			Data:    nil,
+		}
+	}
+	processed, err := l.Processor.Process(upload.Data)
+	if err != nil {
+		return mediaError(err)
+	}
+	checksum := sha256.Sum256(upload.Data)
+	m := model.MediaDs{
+		Id:          uuid.NewString(),
+		ArticleId:   articleId,
+		ContentType: processed.ContentType,
+		Size:        int64(len(upload.Data)),
+		Width:       processed.Width,
+		Height:      processed.Height,
+		Checksum:    hex.EncodeToString(checksum[:]),
+		Uploader:    upload.Uploader,
+	}
+	m.Filename = cleanFilename(upload.Filename, m.ContentType)
+	m.Key = "articles/" + articleId + "/" + m.Id + media.Extension(m.ContentType)
+	if err = l.Store.Put(m.Key, upload.Data, m.ContentType); err != nil {
+		return dataSourceError(err)
+	}
+	if processed.Thumbnail != nil {
+		m.ThumbnailKey = "articles/" + articleId + "/" + m.Id + "-thumbnail" + media.Extension(processed.ThumbnailType)
+		if err = l.Store.Put(m.ThumbnailKey, processed.Thumbnail, processed.ThumbnailType); err != nil {
+			l.removeFiles(m)
+			return dataSourceError(err)
+		}
+	}
+	if err = l.DsSvc.InsertMedia(m); err != nil {
+		l.removeFiles(m)
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusCreated,
+		Message: "Success",
+		Data:    withUrls(m),
+	}
+}
+
+func (l MediaLogic) GetArticleMedia(articleId string) *model.Response {
+	found, err := l.DsSvc.GetMedia(map[string]interface{}{"article_id": articleId})
+	if err != nil {
+		return dataSourceError(err)
+	}
+	list := make([]model.MediaDs, 0, len(found))
+	for _, m := range found {
+		list = append(list, withUrls(m))
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    list,
+	}
+}
+
+func (l MediaLogic) GetMedia(id string) *model.Response {
+	found, err := l.DsSvc.GetMedia(map[string]interface{}{"id": id})
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if len(found) == 0 {
+		return mediaNotFound()
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    []model.MediaDs{withUrls(found[0])},
+	}
+}
+
+func (l MediaLogic) OpenMedia(m model.MediaDs, thumbnail bool) *model.Response {
+	key, contentType := m.Key, m.ContentType
+	if thumbnail && m.ThumbnailKey != "" {
+		key, contentType = m.ThumbnailKey, thumbnailType(m.ThumbnailKey)
+	}
+	body, err := l.Store.Get(key)
+	if err == blob.ErrNotFound {
+		return mediaNotFound()
+	}
+	if err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    &model.MediaFile{Media: m, ContentType: contentType, Body: body},
+	}
+}
+
+// DeleteMedia forgets the file before removing it from the store, a file left behind by a failed removal is
+// unreachable and only takes up space
+func (l MediaLogic) DeleteMedia(articleId string, mediaId string) *model.Response {
+	found, err := l.DsSvc.GetMedia(map[string]interface{}{"id": mediaId, "article_id": articleId})
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if len(found) == 0 {
+		return mediaNotFound()
+	}
+	if err = l.DsSvc.DeleteMedia(mediaId); err != nil {
+		return dataSourceError(err)
+	}
+	l.removeFiles(found[0])
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]string{"id": mediaId},
+	}
+}
+
+// removeFiles deletes the stored file and thumbnail of m, logging failures
+func (l MediaLogic) removeFiles(m model.MediaDs) {
+	for _, key := range []string{m.Key, m.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+		if err := l.Store.Delete(key); err != nil {
+			log.Print(err)
+		}
+	}
+}
+
+// withUrls sets the paths the file and its thumbnail are served at
+func withUrls(m model.MediaDs) model.MediaDs {
+	m.Url = "/media/" + m.Id
+	if m.ThumbnailKey != "" {
+		m.ThumbnailUrl = "/media/" + m.Id + "/thumbnail"
+	}
+	return m
+}
+
+// thumbnailType is the content type of a thumbnail, which is a jpeg or a png as told by its key
+func thumbnailType(key string) string {
+	if strings.HasSuffix(key, media.Extension("image/jpeg")) {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+// cleanFilename keeps the last element of the name the client gave the file, without control characters, naming
+// the file after its content type when nothing is left
+func cleanFilename(name string, contentType string) string {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name))
+	if runes := []rune(name); len(runes) > maxFilenameLength {
+		name = string(runes[:maxFilenameLength])
+	}
+	if name == "" || name == "." || name == ".." {
+		name = "file" + media.Extension(contentType)
+	}
+	return name
+}
+
+func mediaNotFound() *model.Response {
+	log.Print(codes.GetErr(codes.ErrMediaNotFound))
+	return &model.Response{
+		Status:  http.StatusBadRequest,
+		Message: codes.GetErr(codes.ErrMediaNotFound),
+		Data:    nil,
+	}
+}
+
+// mediaError returns the response to send for a file the processor turned down
+func mediaError(err error) *model.Response {
+	log.Print(err)
+	switch err {
+	case media.ErrTooLarge:
+		return &model.Response{
+			Status:  http.StatusRequestEntityTooLarge,
+			Message: codes.GetErr(codes.ErrMediaTooLarge),
+			Data:    nil,
+		}
+	case media.ErrUnsupportedType:
+		return &model.Response{
+			Status:  http.StatusUnsupportedMediaType,
+			Message: codes.GetErr(codes.ErrUnsupportedMediaType),
+			Data:    nil,
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusBadRequest,
+		Message: codes.GetErr(codes.ErrInvalidImage),
+		Data:    nil,
+	}
+}
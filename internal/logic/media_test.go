@@ -0,0 +1,152 @@
+package logic
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/blob"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/media"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMediaLogic_UploadMedia(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	data := []byte("image")
+	tests := []struct {
+		name  string
+		setup func(*mock.MockMediaDataSourceI, *mock.MockStoreI, *mock.MockProcessorI)
+		want  int
+	}{
+		{
+			name: "Success:: image stored with its thumbnail",
+			setup: func(ds *mock.MockMediaDataSourceI, store *mock.MockStoreI, p *mock.MockProcessorI) {
+				p.EXPECT().Process(data).Return(&media.Processed{ContentType: "image/png", Width: 640, Height: 480, Thumbnail: []byte("thumb"), ThumbnailType: "image/png"}, nil)
+				store.EXPECT().Put(gomock.Any(), data, "image/png").Return(nil)
+				store.EXPECT().Put(gomock.Any(), []byte("thumb"), "image/png").Return(nil)
+				ds.EXPECT().InsertMedia(gomock.Any()).DoAndReturn(func(m model.MediaDs) error {
+					if !strings.HasPrefix(m.Key, "articles/1/") || !strings.HasSuffix(m.ThumbnailKey, "-thumbnail.png") ||
+						m.Filename != "photo.png" || m.Uploader != "user-1" || m.Width != 640 || len(m.Checksum) != 64 {
+						t.Errorf("Want: %v, Got: %v", "photo.png by user-1 with its thumbnail", m)
+					}
+					return nil
+				})
+			},
+			want: http.StatusCreated,
+		},
+		{
+			name: "Failure:: unsupported type",
+			setup: func(ds *mock.MockMediaDataSourceI, store *mock.MockStoreI, p *mock.MockProcessorI) {
+				p.EXPECT().Process(data).Return(nil, media.ErrUnsupportedType)
+			},
+			want: http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "Failure:: stored files removed when the record fails",
+			setup: func(ds *mock.MockMediaDataSourceI, store *mock.MockStoreI, p *mock.MockProcessorI) {
+				p.EXPECT().Process(data).Return(&media.Processed{ContentType: "application/pdf"}, nil)
+				store.EXPECT().Put(gomock.Any(), data, "application/pdf").Return(nil)
+				ds.EXPECT().InsertMedia(gomock.Any()).Return(errors.New("db down"))
+				store.EXPECT().Delete(gomock.Any()).Return(nil)
+			},
+			want: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDs := mock.NewMockMediaDataSourceI(mockCtrl)
+			mockArticles := mock.NewMockDataSourceI(mockCtrl)
+			mockStore := mock.NewMockStoreI(mockCtrl)
+			mockProcessor := mock.NewMockProcessorI(mockCtrl)
+			mockArticles.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1"}}, nil)
+			tt.setup(mockDs, mockStore, mockProcessor)
+			got := NewMediaLogicI(mockDs, mockArticles, mockStore, mockProcessor).
+				UploadMedia("1", &model.MediaUpload{Filename: `C:\photos\photo.png`, Data: data, Uploader: "user-1"})
+			if got.Status != tt.want {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMediaLogic_OpenMedia(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	withThumbnail := model.MediaDs{Id: "m1", ContentType: "image/jpeg", Key: "articles/1/m1.jpg", ThumbnailKey: "articles/1/m1-thumbnail.jpg"}
+	withoutThumbnail := model.MediaDs{Id: "m2", ContentType: "image/png", Key: "articles/1/m2.png"}
+	tests := []struct {
+		name            string
+		media           model.MediaDs
+		thumbnail       bool
+		key             string
+		err             error
+		want            int
+		wantContentType string
+	}{
+		{name: "Success:: thumbnail", media: withThumbnail, thumbnail: true, key: withThumbnail.ThumbnailKey, want: http.StatusOK, wantContentType: "image/jpeg"},
+		{name: "Success:: file is its own thumbnail", media: withoutThumbnail, thumbnail: true, key: withoutThumbnail.Key, want: http.StatusOK, wantContentType: "image/png"},
+		{name: "Failure:: missing from the store", media: withoutThumbnail, key: withoutThumbnail.Key, err: blob.ErrNotFound, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := mock.NewMockStoreI(mockCtrl)
+			if tt.err != nil {
+				mockStore.EXPECT().Get(tt.key).Return(nil, tt.err)
+			} else {
+				mockStore.EXPECT().Get(tt.key).Return(ioutil.NopCloser(strings.NewReader("bytes")), nil)
+			}
+			got := NewMediaLogicI(nil, nil, mockStore, nil).OpenMedia(tt.media, tt.thumbnail)
+			if got.Status != tt.want {
+				t.Fatalf("Want: %v, Got: %v", tt.want, got)
+			}
+			if file, ok := got.Data.(*model.MediaFile); ok && file.ContentType != tt.wantContentType {
+				t.Errorf("Want: %v, Got: %v", tt.wantContentType, file.ContentType)
+			}
+		})
+	}
+}
+
+func TestMediaLogic_DeleteMedia(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockDs := mock.NewMockMediaDataSourceI(mockCtrl)
+	mockStore := mock.NewMockStoreI(mockCtrl)
+	mockDs.EXPECT().GetMedia(map[string]interface{}{"id": "m1", "article_id": "1"}).
+		Return([]model.MediaDs{{Id: "m1", Key: "articles/1/m1.jpg", ThumbnailKey: "articles/1/m1-thumbnail.jpg"}}, nil)
+	mockDs.EXPECT().DeleteMedia("m1").Return(nil)
+	mockStore.EXPECT().Delete("articles/1/m1.jpg").Return(nil)
+	mockStore.EXPECT().Delete("articles/1/m1-thumbnail.jpg").Return(errors.New("unreachable"))
+
+	got := NewMediaLogicI(mockDs, nil, mockStore, nil).DeleteMedia("1", "m1")
+	want := &model.Response{Status: http.StatusOK, Message: "Success", Data: map[string]string{"id": "m1"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+
+	mockDs.EXPECT().GetMedia(map[string]interface{}{"id": "m1", "article_id": "2"}).Return(nil, nil)
+	if got = NewMediaLogicI(mockDs, nil, mockStore, nil).DeleteMedia("2", "m1"); got.Status != http.StatusBadRequest {
+		t.Errorf("Want: %v, Got: %v", http.StatusBadRequest, got)
+	}
+}
+
+func Test_cleanFilename(t *testing.T) {
+	tests := map[string]string{
+		"photo.png":              "photo.png",
+		"../../etc/passwd":       "passwd",
+		`C:\Users\me\cat.jpg`:    "cat.jpg",
+		"in\"jection\r\n.png":    "injection.png",
+		"   ":                    "file.png",
+		"dir/":                   "file.png",
+		strings.Repeat("a", 300): strings.Repeat("a", 255),
+	}
+	for name, want := range tests {
+		if got := cleanFilename(name, "image/png"); got != want {
+			t.Errorf("Want: %v, Got: %v", want, got)
+		}
+	}
+}
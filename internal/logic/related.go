@@ -0,0 +1,79 @@
+package logic
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/related"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"log"
+	"math"
+	"net/http"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_related_logic.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/logic RelatedLogicI
+
+type RelatedLogicI interface {
+	// GetRelatedArticles lists up to limit published articles similar to the published article with the given id
+	GetRelatedArticles(id string, limit int) *model.Response
+}
+
+type RelatedLogic struct {
+	Index        related.IndexI
+	ArticleDsSvc datasource.DataSourceI
+}
+
+func NewRelatedLogicI(index related.IndexI, articles datasource.DataSourceI) RelatedLogicI {
+	return &RelatedLogic{
+		Index:        index,
+		ArticleDsSvc: articles,
+	}
+}
+
+func (l RelatedLogic) GetRelatedArticles(id string, limit int) *model.Response {
+	articles, err := l.ArticleDsSvc.Get(map[string]interface{}{"id": id, "status": model.StatusPublished}, 1, 0)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if len(articles) == 0 {
+		log.Print(codes.GetErr(codes.ErrArticleNotFound))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrArticleNotFound),
+			Data:    nil,
+		}
+	}
+	// the index is rebuilt in the background, articles unpublished since are dropped below and made up for
+	matches := l.Index.Related(articles[0], 2*limit)
+	relatedArticles := []model.RelatedArticle{}
+	if len(matches) == 0 {
+		return &model.Response{Status: http.StatusOK, Message: "Success", Data: relatedArticles}
+	}
+	ids := make([]string, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.Id)
+	}
+	found, err := l.ArticleDsSvc.Get(map[string]interface{}{model.FilterIds: ids, "status": model.StatusPublished}, 0, 0)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	byId := make(map[string]model.ArticleDs, len(found))
+	for _, article := range found {
+		byId[article.Id] = article
+	}
+	for _, match := range matches {
+		article, ok := byId[match.Id]
+		if !ok {
+			continue
+		}
+		// rounded so that the ETag of the response does not change with floating point noise
+		relatedArticles = append(relatedArticles, model.RelatedArticle{ArticleDs: article, Score: math.Round(match.Score*1000) / 1000})
+		if len(relatedArticles) == limit {
+			break
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    relatedArticles,
+	}
+}
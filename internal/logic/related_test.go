@@ -0,0 +1,63 @@
+package logic
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/related"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestRelatedLogic_GetRelatedArticles(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	source := model.ArticleDs{Id: "1", Title: "title"}
+	tests := []struct {
+		name  string
+		setup func(*mock.MockIndexI, *mock.MockDataSourceI)
+		want  *model.Response
+	}{
+		{
+			name: "Success:: most similar first, unpublished articles left out",
+			setup: func(x *mock.MockIndexI, ds *mock.MockDataSourceI) {
+				ds.EXPECT().Get(map[string]interface{}{"id": "1", "status": model.StatusPublished}, 1, 0).Return([]model.ArticleDs{source}, nil)
+				x.EXPECT().Related(source, 4).Return([]related.Match{{Id: "3", Score: 0.9}, {Id: "2", Score: 0.66666}, {Id: "4", Score: 0.1}})
+				ds.EXPECT().Get(map[string]interface{}{model.FilterIds: []string{"3", "2", "4"}, "status": model.StatusPublished}, 0, 0).
+					Return([]model.ArticleDs{{Id: "4"}, {Id: "2"}}, nil)
+			},
+			want: &model.Response{Status: http.StatusOK, Message: "Success", Data: []model.RelatedArticle{
+				{ArticleDs: model.ArticleDs{Id: "2"}, Score: 0.667},
+				{ArticleDs: model.ArticleDs{Id: "4"}, Score: 0.1},
+			}},
+		},
+		{
+			name: "Success:: nothing related",
+			setup: func(x *mock.MockIndexI, ds *mock.MockDataSourceI) {
+				ds.EXPECT().Get(map[string]interface{}{"id": "1", "status": model.StatusPublished}, 1, 0).Return([]model.ArticleDs{source}, nil)
+				x.EXPECT().Related(source, 4).Return(nil)
+			},
+			want: &model.Response{Status: http.StatusOK, Message: "Success", Data: []model.RelatedArticle{}},
+		},
+		{
+			name: "Failure:: article not published",
+			setup: func(x *mock.MockIndexI, ds *mock.MockDataSourceI) {
+				ds.EXPECT().Get(map[string]interface{}{"id": "1", "status": model.StatusPublished}, 1, 0).Return(nil, nil)
+			},
+			want: &model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrArticleNotFound)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockIndex := mock.NewMockIndexI(mockCtrl)
+			mockDs := mock.NewMockDataSourceI(mockCtrl)
+			tt.setup(mockIndex, mockDs)
+			got := NewRelatedLogicI(mockIndex, mockDs).GetRelatedArticles("1", 2)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
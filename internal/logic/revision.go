@@ -0,0 +1,111 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"log"
+	"net/http"
+)
+
+// GetRevisions lists the revisions of an article, newest first
+func (l ArticleManagementLogic) GetRevisions(id string) *model.Response {
+	revisions, err := l.RevisionSvc.GetRevisions(map[string]interface{}{"article_id": id})
+	if err != nil {
+		log.Print(codes.GetErr(codes.ErrDataSource), err)
+		return &model.Response{
+			Status:  http.StatusInternalServerError,
+			Message: codes.GetErr(codes.ErrDataSource),
+			Data:    nil,
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    revisions,
+	}
+}
+
+func (l ArticleManagementLogic) GetRevision(id string, rev int) *model.Response {
+	revisions, err := l.RevisionSvc.GetRevisions(map[string]interface{}{"article_id": id, "rev": rev})
+	if err != nil {
+		log.Print(codes.GetErr(codes.ErrDataSource), err)
+		return &model.Response{
+			Status:  http.StatusInternalServerError,
+			Message: codes.GetErr(codes.ErrDataSource),
+			Data:    nil,
+		}
+	}
+	if len(revisions) == 0 {
+		log.Print(codes.GetErr(codes.ErrRevisionNotFound))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrRevisionNotFound),
+			Data:    nil,
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    revisions[0],
+	}
+}
+
+// DiffRevisions returns a unified diff turning revision from into revision to, the title is diffed as the first line
+func (l ArticleManagementLogic) DiffRevisions(id string, from int, to int) *model.Response {
+	resp := l.GetRevision(id, from)
+	if resp.Status != http.StatusOK {
+		return resp
+	}
+	a := resp.Data.(model.RevisionDs)
+	resp = l.GetRevision(id, to)
+	if resp.Status != http.StatusOK {
+		return resp
+	}
+	b := resp.Data.(model.RevisionDs)
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(revisionText(a)),
+		B:        difflib.SplitLines(revisionText(b)),
+		FromFile: fmt.Sprintf("rev/%d", from),
+		ToFile:   fmt.Sprintf("rev/%d", to),
+		Context:  3,
+	})
+	if err != nil {
+		log.Print(err)
+		return &model.Response{
+			Status:  http.StatusInternalServerError,
+			Message: http.StatusText(http.StatusInternalServerError),
+			Data:    nil,
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    model.RevisionDiff{From: from, To: to, Diff: diff},
+	}
+}
+
+// RestoreRevision copies the title, content and format of an earlier revision back onto the article at the given
+// version.
+// The restore is itself recorded as a new revision, history is never rewritten.
+func (l ArticleManagementLogic) RestoreRevision(id string, rev int, version int, editor string) *model.Response {
+	resp := l.GetRevision(id, rev)
+	if resp.Status != http.StatusOK {
+		return resp
+	}
+	revision := resp.Data.(model.RevisionDs)
+	resp = l.UpdateArticle(id, &model.Article{Title: revision.Title, Content: revision.Content, ContentFormat: revision.ContentFormat, Editor: editor, Version: version})
+	if resp.Status != http.StatusOK {
+		return resp
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]interface{}{"id": id, "restored": rev},
+	}
+}
+
+func revisionText(r model.RevisionDs) string {
+	return r.Title + "\n\n" + r.Content
+}
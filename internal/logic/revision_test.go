@@ -0,0 +1,64 @@
+package logic
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestArticleManagementLogic_DiffRevisions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRevisions := mock.NewMockRevisionDataSourceI(mockCtrl)
+	mockRevisions.EXPECT().GetRevisions(map[string]interface{}{"article_id": "1", "rev": 1}).
+		Return([]model.RevisionDs{{ArticleId: "1", Rev: 1, Title: "title", Content: "one\ntwo"}}, nil).Times(2)
+	mockRevisions.EXPECT().GetRevisions(map[string]interface{}{"article_id": "1", "rev": 2}).
+		Return([]model.RevisionDs{{ArticleId: "1", Rev: 2, Title: "title", Content: "one\nthree"}}, nil)
+	mockRevisions.EXPECT().GetRevisions(map[string]interface{}{"article_id": "1", "rev": 3}).Return(nil, nil)
+	rec := NewArticleManagementLogicI(mock.NewMockDataSourceI(mockCtrl), mockRevisions)
+
+	got := rec.DiffRevisions("1", 1, 2)
+	want := &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    model.RevisionDiff{From: 1, To: 2, Diff: "--- rev/1\n+++ rev/2\n@@ -1,4 +1,4 @@\n title\n \n one\n-two\n+three\n"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+
+	got = rec.DiffRevisions("1", 1, 3)
+	want = &model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrRevisionNotFound)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestArticleManagementLogic_RestoreRevision(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDs := mock.NewMockDataSourceI(mockCtrl)
+	mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Title: "title", Slug: "title", Author: "author"}}, nil)
+	mockDs.EXPECT().GetSlugOwners("old-title").Return(map[string]string{"old-title": "2"}, nil)
+	mockDs.EXPECT().Edit("1", model.ArticleEdit{
+		Fields:     map[string]interface{}{"title": "old title", "content": "old content", "content_format": "markdown", "content_html": "<p>old content</p>\n", "slug": "old-title-2"},
+		Version:    4,
+		FormerSlug: "title",
+		Revision:   model.RevisionDs{ArticleId: "1", Title: "old title", Content: "old content", ContentFormat: "markdown", Author: "author", Editor: "user-2"},
+	}).Return(true, nil)
+	mockRevisions := mock.NewMockRevisionDataSourceI(mockCtrl)
+	mockRevisions.EXPECT().GetRevisions(map[string]interface{}{"article_id": "1", "rev": 1}).
+		Return([]model.RevisionDs{{ArticleId: "1", Rev: 1, Title: "old title", Content: "old content", ContentFormat: "markdown", Author: "author"}}, nil)
+
+	got := NewArticleManagementLogicI(mockDs, mockRevisions).RestoreRevision("1", 1, 4, "user-2")
+	want := &model.Response{Status: http.StatusOK, Message: "Success", Data: map[string]interface{}{"id": "1", "restored": 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
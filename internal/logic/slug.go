@@ -0,0 +1,86 @@
+package logic
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/slug"
+	"log"
+	"net/http"
+)
+
+// GetArticleBySlug returns the article currently at slug. A former slug answers 301 Moved Permanently with the
+// article, whose Slug is the one to redirect to.
+func (l ArticleManagementLogic) GetArticleBySlug(s string) *model.Response {
+	articles, err := l.DsSvc.Get(map[string]interface{}{"slug": s}, 1, 0)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if len(articles) > 0 {
+		return &model.Response{
+			Status:  http.StatusOK,
+			Message: "Success",
+			Data:    articles,
+		}
+	}
+	id, err := l.DsSvc.GetSlugRedirect(s)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if id == "" {
+		log.Print(codes.GetErr(codes.ErrArticleNotFound))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrArticleNotFound),
+			Data:    nil,
+		}
+	}
+	resp := l.GetArticle(id)
+	if resp.Status != http.StatusOK {
+		return resp
+	}
+	resp.Status = http.StatusMovedPermanently
+	resp.Message = http.StatusText(http.StatusMovedPermanently)
+	return resp
+}
+
+// maxSlugAttempts bounds the writes of an article whose slug concurrent writes keep taking first
+const maxSlugAttempts = 5
+
+// uniqueSlug returns the slug of title for the article with the given id, suffixed when another article has or had
+// it or it is in taken. Slugs the article itself has or had are free to take again.
+func (l ArticleManagementLogic) uniqueSlug(title string, id string, taken map[string]bool) (string, error) {
+	base := slug.Make(title)
+	owners, err := l.DsSvc.GetSlugOwners(base)
+	if err != nil {
+		return "", err
+	}
+	skip := make(map[string]bool, len(owners)+len(taken))
+	for s := range taken {
+		skip[s] = true
+	}
+	for s, owner := range owners {
+		if owner != id {
+			skip[s] = true
+		}
+	}
+	return slug.Unique(base, skip), nil
+}
+
+// setSlug gives edit the slug of title when it changes the title of article or article has no slug yet, keeping
+// the former slug to redirect from, and returns the slug. Slugs in taken are skipped.
+func (l ArticleManagementLogic) setSlug(edit *model.ArticleEdit, article model.ArticleDs, title string, taken map[string]bool) (string, error) {
+	delete(edit.Fields, "slug")
+	edit.FormerSlug = ""
+	if title == article.Title && article.Slug != "" {
+		return article.Slug, nil
+	}
+	s, err := l.uniqueSlug(title, article.Id, taken)
+	if err != nil || s == article.Slug {
+		return s, err
+	}
+	edit.Fields["slug"] = s
+	if article.Slug != "" {
+		edit.FormerSlug = article.Slug
+	}
+	return s, nil
+}
@@ -0,0 +1,178 @@
+package logic
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestArticleManagementLogic_GetArticleBySlug(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	article := model.ArticleDs{Id: "1", Title: "New title", Slug: "new-title"}
+	tests := []struct {
+		name  string
+		slug  string
+		setup func(*mock.MockDataSourceI)
+		want  *model.Response
+	}{
+		{
+			name: "Success:: current slug",
+			slug: "new-title",
+			setup: func(ds *mock.MockDataSourceI) {
+				ds.EXPECT().Get(map[string]interface{}{"slug": "new-title"}, 1, 0).Return([]model.ArticleDs{article}, nil)
+			},
+			want: &model.Response{Status: http.StatusOK, Message: "Success", Data: []model.ArticleDs{article}},
+		},
+		{
+			name: "Success:: former slug redirects",
+			slug: "old-title",
+			setup: func(ds *mock.MockDataSourceI) {
+				ds.EXPECT().Get(map[string]interface{}{"slug": "old-title"}, 1, 0).Return(nil, nil)
+				ds.EXPECT().GetSlugRedirect("old-title").Return("1", nil)
+				ds.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{article}, nil)
+			},
+			want: &model.Response{Status: http.StatusMovedPermanently, Message: "Moved Permanently", Data: []model.ArticleDs{article}},
+		},
+		{
+			name: "Failure:: unknown slug",
+			slug: "unknown",
+			setup: func(ds *mock.MockDataSourceI) {
+				ds.EXPECT().Get(map[string]interface{}{"slug": "unknown"}, 1, 0).Return(nil, nil)
+				ds.EXPECT().GetSlugRedirect("unknown").Return("", nil)
+			},
+			want: &model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrArticleNotFound)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDs := mock.NewMockDataSourceI(mockCtrl)
+			tt.setup(mockDs)
+			got := NewArticleManagementLogicI(mockDs, mock.NewMockRevisionDataSourceI(mockCtrl)).GetArticleBySlug(tt.slug)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestArticleManagementLogic_uniqueSlug(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name    string
+		owners  map[string]string
+		err     error
+		taken   map[string]bool
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Success:: free slug",
+			want: "new-title",
+		},
+		{
+			name:   "Success:: slug of another article",
+			owners: map[string]string{"new-title": "2"},
+			want:   "new-title-2",
+		},
+		{
+			name:   "Success:: former slug of the article itself",
+			owners: map[string]string{"new-title": "1"},
+			want:   "new-title",
+		},
+		{
+			name:   "Success:: suffixes taken",
+			owners: map[string]string{"new-title": "2", "new-title-2": "3"},
+			want:   "new-title-3",
+		},
+		{
+			name:   "Success:: taken by a concurrent write",
+			owners: map[string]string{"new-title": "2"},
+			taken:  map[string]bool{"new-title-2": true},
+			want:   "new-title-3",
+		},
+		{
+			name:    "Failure:: datasource error",
+			err:     errors.New("error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDs := mock.NewMockDataSourceI(mockCtrl)
+			mockDs.EXPECT().GetSlugOwners("new-title").Return(tt.owners, tt.err)
+			got, err := ArticleManagementLogic{DsSvc: mockDs}.uniqueSlug("New title", "1", tt.taken)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Want: %v, Got: %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestArticleManagementLogic_InsertArticle_SlugTaken(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDs := mock.NewMockDataSourceI(mockCtrl)
+	// the concurrent write has not committed yet, so the slug does not show among the owners
+	mockDs.EXPECT().GetSlugOwners("title").Return(nil, nil).Times(2)
+	gomock.InOrder(
+		mockDs.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(article model.ArticleDs, revision model.RevisionDs) error {
+			if article.Slug != "title" {
+				t.Errorf("Want: %v, Got: %v", "title", article.Slug)
+			}
+			return datasource.ErrSlugTaken
+		}),
+		mockDs.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(article model.ArticleDs, revision model.RevisionDs) error {
+			if article.Slug != "title-2" {
+				t.Errorf("Want: %v, Got: %v", "title-2", article.Slug)
+			}
+			return nil
+		}),
+	)
+
+	got := NewArticleManagementLogicI(mockDs, nil).InsertArticle(&model.Article{Title: "title", Content: "content", Author: "author"})
+	if got.Status != http.StatusCreated {
+		t.Errorf("Want: %v, Got: %v", http.StatusCreated, got)
+	}
+	if slug := got.Data.(map[string]string)["slug"]; slug != "title-2" {
+		t.Errorf("Want: %v, Got: %v", "title-2", slug)
+	}
+}
+
+func TestArticleManagementLogic_UpdateArticle_SlugTaken(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDs := mock.NewMockDataSourceI(mockCtrl)
+	mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Title: "title", Slug: "title", Version: 3}}, nil)
+	mockDs.EXPECT().GetSlugOwners("new-title").Return(nil, nil).Times(maxSlugAttempts)
+	var slugs []interface{}
+	mockDs.EXPECT().Edit("1", gomock.Any()).DoAndReturn(func(id string, edit model.ArticleEdit) (bool, error) {
+		if edit.FormerSlug != "title" {
+			t.Errorf("Want: %v, Got: %v", "title", edit.FormerSlug)
+		}
+		slugs = append(slugs, edit.Fields["slug"])
+		return false, datasource.ErrSlugTaken
+	}).Times(maxSlugAttempts)
+
+	got := NewArticleManagementLogicI(mockDs, nil).UpdateArticle("1", &model.Article{Title: "New title", Content: "content", Version: 3})
+	want := &model.Response{Status: http.StatusInternalServerError, Message: codes.GetErr(codes.ErrDataSource)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+	// every attempt takes the next suffix, and the attempts are bounded
+	if wantSlugs := []interface{}{"new-title", "new-title-2", "new-title-3", "new-title-4", "new-title-5"}; !reflect.DeepEqual(slugs, wantSlugs) {
+		t.Errorf("Want: %v, Got: %v", wantSlugs, slugs)
+	}
+}
@@ -0,0 +1,67 @@
+package logic
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"log"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits applied to the tags of an article
+const (
+	MaxTags      = 10
+	MaxTagLength = 32
+)
+
+// NormalizeTags lower-cases the tags, joins the words of a tag with "-" and drops duplicates, keeping the first
+// occurrence. It reports false when a tag is empty or too long or when there are too many tags.
+func NormalizeTags(tags []string) ([]string, bool) {
+	if tags == nil {
+		return nil, true
+	}
+	normalized := make([]string, 0, len(tags))
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+		if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength {
+			return nil, false
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, len(normalized) <= MaxTags
+}
+
+// GetTags lists the tags of published articles with their article counts
+func (l ArticleManagementLogic) GetTags() *model.Response {
+	counts, err := l.DsSvc.GetTagCounts()
+	if err != nil {
+		log.Print(codes.GetErr(codes.ErrDataSource), err)
+		return &model.Response{
+			Status:  http.StatusInternalServerError,
+			Message: codes.GetErr(codes.ErrDataSource),
+			Data:    nil,
+		}
+	}
+	if counts == nil {
+		counts = []model.TagCount{}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    counts,
+	}
+}
+
+func invalidTags() *model.Response {
+	log.Print(codes.GetErr(codes.ErrInvalidTags))
+	return &model.Response{
+		Status:  http.StatusBadRequest,
+		Message: codes.GetErr(codes.ErrInvalidTags),
+		Data:    nil,
+	}
+}
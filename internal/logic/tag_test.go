@@ -0,0 +1,64 @@
+package logic
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name string
+		give []string
+		want []string
+		ok   bool
+	}{
+		{name: "missing", give: nil, want: nil, ok: true},
+		{name: "cleared", give: []string{}, want: []string{}, ok: true},
+		{name: "normalised", give: []string{" Go ", "go", "Cloud  Native", "GO"}, want: []string{"go", "cloud-native"}, ok: true},
+		{name: "empty tag", give: []string{"go", "  "}},
+		{name: "too long", give: []string{strings.Repeat("a", MaxTagLength+1)}},
+		{name: "too many", give: strings.Split("a b c d e f g h i j k", " ")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NormalizeTags(tt.give)
+			if ok != tt.ok || (ok && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("Want: %v %v, Got: %v %v", tt.want, tt.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestArticleManagementLogic_UpdateArticle_Tags(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDs := mock.NewMockDataSourceI(mockCtrl)
+	mockDs.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Title: "title", Slug: "title", Author: "author", Version: 3}}, nil)
+	mockDs.EXPECT().Edit("1", model.ArticleEdit{
+		Fields:   map[string]interface{}{"title": "title", "content": "content", "content_format": "plain", "content_html": "<p>content</p>\n"},
+		Version:  3,
+		Tags:     []string{"go", "cloud-native"},
+		Revision: model.RevisionDs{ArticleId: "1", Title: "title", Content: "content", ContentFormat: "plain", Author: "author"},
+	}).Return(true, nil)
+	mockRevisions := mock.NewMockRevisionDataSourceI(mockCtrl)
+
+	got := NewArticleManagementLogicI(mockDs, mockRevisions).
+		UpdateArticle("1", &model.Article{Title: "title", Content: "content", Tags: []string{"Go", "cloud native"}, Version: 3})
+	if got.Status != http.StatusOK {
+		t.Errorf("Want: %v, Got: %v", http.StatusOK, got)
+	}
+
+	got = NewArticleManagementLogicI(mockDs, mockRevisions).
+		GetAllArticle(20, 1, map[string]interface{}{model.FilterTags: model.TagFilter{Tags: []string{""}}})
+	want := &model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrInvalidTags)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
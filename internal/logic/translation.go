@@ -0,0 +1,138 @@
+package logic
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/i18n"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/render"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"log"
+	"net/http"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_translation_logic.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/logic TranslationLogicI
+
+type TranslationLogicI interface {
+	// PutTranslation adds the translation of an article into locale or replaces it
+	PutTranslation(articleId string, locale string, req *model.TranslationRequest) *model.Response
+	// DeleteTranslation removes the translation of an article into locale
+	DeleteTranslation(articleId string, locale string) *model.Response
+}
+
+type TranslationLogic struct {
+	DsSvc        datasource.TranslationDataSourceI
+	ArticleDsSvc datasource.DataSourceI
+}
+
+func NewTranslationLogicI(ds datasource.TranslationDataSourceI, articles datasource.DataSourceI) TranslationLogicI {
+	return &TranslationLogic{
+		DsSvc:        ds,
+		ArticleDsSvc: articles,
+	}
+}
+
+// PutTranslation renders the translated content like the article's own, in the article's format unless req has one
+func (l TranslationLogic) PutTranslation(articleId string, locale string, req *model.TranslationRequest) *model.Response {
+	article, locale, resp := l.translatable(articleId, locale)
+	if resp != nil {
+		return resp
+	}
+	format := req.ContentFormat
+	if format == "" {
+		format = article.ContentFormat
+	}
+	if format == "" {
+		format = render.FormatPlain
+	}
+	t := model.TranslationDs{
+		ArticleId:     articleId,
+		Locale:        locale,
+		Title:         req.Title,
+		Content:       req.Content,
+		ContentFormat: format,
+		ContentHtml:   render.Html(format, req.Content),
+	}
+	if err := l.DsSvc.PutTranslation(t); err != nil {
+		return dataSourceError(err)
+	}
+	if err := l.touch(articleId); err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]string{"id": articleId, "locale": t.Locale},
+	}
+}
+
+func (l TranslationLogic) DeleteTranslation(articleId string, locale string) *model.Response {
+	_, locale, resp := l.translatable(articleId, locale)
+	if resp != nil {
+		return resp
+	}
+	deleted, err := l.DsSvc.DeleteTranslation(articleId, locale)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	if !deleted {
+		log.Print(codes.GetErr(codes.ErrTranslationNotFound))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrTranslationNotFound),
+			Data:    nil,
+		}
+	}
+	if err = l.touch(articleId); err != nil {
+		return dataSourceError(err)
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    map[string]string{"id": articleId, "locale": locale},
+	}
+}
+
+// translatable returns the article and the normalised locale, or the response to send when locale is not a language
+// tag, the article does not exist or is written in locale
+func (l TranslationLogic) translatable(articleId string, locale string) (*model.ArticleDs, string, *model.Response) {
+	normalized, ok := i18n.Normalize(locale)
+	if !ok {
+		return nil, "", invalidLocale()
+	}
+	articles, err := l.ArticleDsSvc.Get(map[string]interface{}{"id": articleId}, 1, 0)
+	if err != nil {
+		return nil, "", dataSourceError(err)
+	}
+	if len(articles) == 0 {
+		log.Print(codes.GetErr(codes.ErrArticleNotFound))
+		return nil, "", &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrArticleNotFound),
+			Data:    nil,
+		}
+	}
+	if articles[0].Locale == normalized {
+		log.Print(codes.GetErr(codes.ErrTranslationLocale))
+		return nil, "", &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrTranslationLocale),
+			Data:    nil,
+		}
+	}
+	return &articles[0], normalized, nil
+}
+
+// touch bumps the version and update time of the article, its ETag and Last-Modified cover its translations
+func (l TranslationLogic) touch(articleId string) error {
+	return l.ArticleDsSvc.Update(articleId, map[string]interface{}{"updated_at": time.Now().UTC()})
+}
+
+func invalidLocale() *model.Response {
+	log.Print(codes.GetErr(codes.ErrInvalidLocale))
+	return &model.Response{
+		Status:  http.StatusBadRequest,
+		Message: codes.GetErr(codes.ErrInvalidLocale),
+		Data:    nil,
+	}
+}
@@ -0,0 +1,99 @@
+package logic
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"testing"
+)
+
+func TestTranslationLogic_PutTranslation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name    string
+		locale  string
+		req     model.TranslationRequest
+		setup   func(*mock.MockTranslationDataSourceI, *mock.MockDataSourceI)
+		want    int
+		message string
+	}{
+		{
+			name:   "Success:: translation in the article's format",
+			locale: "FR-ca",
+			req:    model.TranslationRequest{Title: "Titre", Content: "*Contenu*"},
+			setup: func(ds *mock.MockTranslationDataSourceI, articles *mock.MockDataSourceI) {
+				articles.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Locale: "en", ContentFormat: "markdown"}}, nil)
+				ds.EXPECT().PutTranslation(gomock.Any()).DoAndReturn(func(tr model.TranslationDs) error {
+					if tr.Locale != "fr-CA" || tr.ContentFormat != "markdown" || tr.ContentHtml != "<p><em>Contenu</em></p>\n" {
+						t.Errorf("Want: %v, Got: %v", "fr-CA markdown translation", tr)
+					}
+					return nil
+				})
+				articles.EXPECT().Update("1", gomock.Any()).Return(nil)
+			},
+			want:    http.StatusOK,
+			message: "Success",
+		},
+		{
+			name:    "Failure:: invalid locale",
+			locale:  "not a locale",
+			req:     model.TranslationRequest{Title: "Titre", Content: "Contenu"},
+			setup:   func(*mock.MockTranslationDataSourceI, *mock.MockDataSourceI) {},
+			want:    http.StatusBadRequest,
+			message: codes.GetErr(codes.ErrInvalidLocale),
+		},
+		{
+			name:   "Failure:: article's own locale",
+			locale: "en",
+			req:    model.TranslationRequest{Title: "Title", Content: "Content"},
+			setup: func(ds *mock.MockTranslationDataSourceI, articles *mock.MockDataSourceI) {
+				articles.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Locale: "en"}}, nil)
+			},
+			want:    http.StatusBadRequest,
+			message: codes.GetErr(codes.ErrTranslationLocale),
+		},
+		{
+			name:   "Failure:: article not found",
+			locale: "fr",
+			req:    model.TranslationRequest{Title: "Titre", Content: "Contenu"},
+			setup: func(ds *mock.MockTranslationDataSourceI, articles *mock.MockDataSourceI) {
+				articles.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return(nil, nil)
+			},
+			want:    http.StatusBadRequest,
+			message: codes.GetErr(codes.ErrArticleNotFound),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDs := mock.NewMockTranslationDataSourceI(mockCtrl)
+			mockArticles := mock.NewMockDataSourceI(mockCtrl)
+			tt.setup(mockDs, mockArticles)
+			got := NewTranslationLogicI(mockDs, mockArticles).PutTranslation("1", tt.locale, &tt.req)
+			if got.Status != tt.want || got.Message != tt.message {
+				t.Errorf("Want: %v %v, Got: %v", tt.want, tt.message, got)
+			}
+		})
+	}
+}
+
+func TestTranslationLogic_DeleteTranslation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockDs := mock.NewMockTranslationDataSourceI(mockCtrl)
+	mockArticles := mock.NewMockDataSourceI(mockCtrl)
+	mockArticles.EXPECT().Get(map[string]interface{}{"id": "1"}, 1, 0).Return([]model.ArticleDs{{Id: "1", Locale: "en"}}, nil).Times(2)
+	mockDs.EXPECT().DeleteTranslation("1", "fr").Return(true, nil)
+	mockDs.EXPECT().DeleteTranslation("1", "de").Return(false, nil)
+	mockArticles.EXPECT().Update("1", gomock.Any()).Return(nil)
+
+	svc := NewTranslationLogicI(mockDs, mockArticles)
+	if got := svc.DeleteTranslation("1", "fr"); got.Status != http.StatusOK {
+		t.Errorf("Want: %v, Got: %v", http.StatusOK, got)
+	}
+	if got := svc.DeleteTranslation("1", "de"); got.Message != codes.GetErr(codes.ErrTranslationNotFound) {
+		t.Errorf("Want: %v, Got: %v", codes.GetErr(codes.ErrTranslationNotFound), got)
+	}
+}
@@ -0,0 +1,81 @@
+package logic
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/views"
+	"log"
+	"net/http"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_view_logic.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/logic ViewLogicI
+
+// DefaultViewWindow is the window popular articles are ranked over when none is given
+const DefaultViewWindow = "24h"
+
+type ViewLogicI interface {
+	// GetPopularArticles lists up to limit published articles with the most views within the named window
+	GetPopularArticles(window string, limit int) *model.Response
+}
+
+type ViewLogic struct {
+	Counter      views.CounterI
+	ArticleDsSvc datasource.DataSourceI
+}
+
+func NewViewLogicI(counter views.CounterI, articles datasource.DataSourceI) ViewLogicI {
+	return &ViewLogic{
+		Counter:      counter,
+		ArticleDsSvc: articles,
+	}
+}
+
+func (l ViewLogic) GetPopularArticles(window string, limit int) *model.Response {
+	duration, ok := views.Windows[window]
+	if !ok {
+		log.Print(codes.GetErr(codes.ErrInvalidViewWindow))
+		return &model.Response{
+			Status:  http.StatusBadRequest,
+			Message: codes.GetErr(codes.ErrInvalidViewWindow),
+			Data:    nil,
+		}
+	}
+	// articles archived since they were viewed are dropped below, twice as many are ranked to make up for them
+	scores, err := l.Counter.Popular(duration, 2*limit, time.Now())
+	if err != nil {
+		return dataSourceError(err)
+	}
+	popular := []model.PopularArticle{}
+	if len(scores) == 0 {
+		return &model.Response{Status: http.StatusOK, Message: "Success", Data: popular}
+	}
+	ids := make([]string, 0, len(scores))
+	for _, score := range scores {
+		ids = append(ids, score.Id)
+	}
+	articles, err := l.ArticleDsSvc.Get(map[string]interface{}{model.FilterIds: ids, "status": model.StatusPublished}, 0, 0)
+	if err != nil {
+		return dataSourceError(err)
+	}
+	byId := make(map[string]model.ArticleDs, len(articles))
+	for _, article := range articles {
+		byId[article.Id] = article
+	}
+	for _, score := range scores {
+		article, ok := byId[score.Id]
+		if !ok {
+			continue
+		}
+		popular = append(popular, model.PopularArticle{ArticleDs: article, WindowViews: score.Views})
+		if len(popular) == limit {
+			break
+		}
+	}
+	return &model.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    popular,
+	}
+}
@@ -0,0 +1,62 @@
+package logic
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/views"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestViewLogic_GetPopularArticles(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name   string
+		window string
+		setup  func(*mock.MockCounterI, *mock.MockDataSourceI)
+		want   *model.Response
+	}{
+		{
+			name:   "Success:: ranked by views, unpublished articles left out",
+			window: "7d",
+			setup: func(c *mock.MockCounterI, ds *mock.MockDataSourceI) {
+				c.EXPECT().Popular(views.Windows["7d"], 4, gomock.Any()).Return([]views.Score{{Id: "3", Views: 9}, {Id: "1", Views: 5}, {Id: "2", Views: 2}}, nil)
+				ds.EXPECT().Get(map[string]interface{}{model.FilterIds: []string{"3", "1", "2"}, "status": model.StatusPublished}, 0, 0).
+					Return([]model.ArticleDs{{Id: "2"}, {Id: "1"}}, nil)
+			},
+			want: &model.Response{Status: http.StatusOK, Message: "Success", Data: []model.PopularArticle{
+				{ArticleDs: model.ArticleDs{Id: "1"}, WindowViews: 5},
+				{ArticleDs: model.ArticleDs{Id: "2"}, WindowViews: 2},
+			}},
+		},
+		{
+			name:   "Success:: no views yet",
+			window: "24h",
+			setup: func(c *mock.MockCounterI, ds *mock.MockDataSourceI) {
+				c.EXPECT().Popular(views.Windows["24h"], 4, gomock.Any()).Return(nil, nil)
+			},
+			want: &model.Response{Status: http.StatusOK, Message: "Success", Data: []model.PopularArticle{}},
+		},
+		{
+			name:   "Failure:: unknown window",
+			window: "1y",
+			setup:  func(c *mock.MockCounterI, ds *mock.MockDataSourceI) {},
+			want:   &model.Response{Status: http.StatusBadRequest, Message: codes.GetErr(codes.ErrInvalidViewWindow)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCounter := mock.NewMockCounterI(mockCtrl)
+			mockDs := mock.NewMockDataSourceI(mockCtrl)
+			tt.setup(mockCounter, mockDs)
+			got := NewViewLogicI(mockCounter, mockDs).GetPopularArticles(tt.window, 2)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
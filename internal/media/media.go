@@ -0,0 +1,177 @@
+// Package media inspects uploaded files: it sniffs their content type from the bytes themselves, reads the
+// dimensions of images and makes thumbnails of them.
+package media
+
+import (
+	"bytes"
+	"errors"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"golang.org/x/image/draw"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strings"
+
+	// decoders registered with image
+	_ "golang.org/x/image/webp"
+	_ "image/gif"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_media.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/media ProcessorI
+
+const (
+	DefaultMaxUploadBytes = 10 << 20
+	DefaultThumbnailSize  = 320
+	// maxPixels bounds the images that are decoded, a few bytes of compressed image may claim a huge canvas
+	maxPixels = 50_000_000
+	// jpegQuality of the thumbnails of photographs
+	jpegQuality = 82
+)
+
+// DefaultAllowedTypes are the common web image formats and pdf. svg is left out as it may carry scripts.
+var DefaultAllowedTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp", "application/pdf"}
+
+var (
+	ErrTooLarge        = errors.New("media: file too large")
+	ErrUnsupportedType = errors.New("media: unsupported content type")
+	ErrInvalidImage    = errors.New("media: invalid image")
+)
+
+// Processed describes an uploaded file. Width, Height and Thumbnail are only set for images, Thumbnail only when
+// the image is larger than a thumbnail.
+type Processed struct {
+	ContentType   string
+	Width         int
+	Height        int
+	Thumbnail     []byte
+	ThumbnailType string
+}
+
+type ProcessorI interface {
+	// Process checks the size and content type of data and reads the dimensions of images, making their thumbnail
+	Process(data []byte) (*Processed, error)
+	// MaxBytes is the size of the largest file accepted
+	MaxBytes() int64
+}
+
+type processor struct {
+	maxBytes      int64
+	allowed       map[string]bool
+	thumbnailSize int
+}
+
+func NewProcessorI(cfg config.MediaConfig) ProcessorI {
+	p := &processor{
+		maxBytes:      cfg.MaxUploadBytes,
+		allowed:       map[string]bool{},
+		thumbnailSize: cfg.ThumbnailSize,
+	}
+	if p.maxBytes <= 0 {
+		p.maxBytes = DefaultMaxUploadBytes
+	}
+	if p.thumbnailSize <= 0 {
+		p.thumbnailSize = DefaultThumbnailSize
+	}
+	allowed := cfg.AllowedTypes
+	if len(allowed) == 0 {
+		allowed = DefaultAllowedTypes
+	}
+	for _, t := range allowed {
+		p.allowed[strings.ToLower(t)] = true
+	}
+	return p
+}
+
+func (p processor) MaxBytes() int64 {
+	return p.maxBytes
+}
+
+func (p processor) Process(data []byte) (*Processed, error) {
+	if int64(len(data)) > p.maxBytes {
+		return nil, ErrTooLarge
+	}
+	contentType := sniff(data)
+	if !p.allowed[contentType] {
+		return nil, ErrUnsupportedType
+	}
+	processed := &Processed{ContentType: contentType}
+	if !strings.HasPrefix(contentType, "image/") {
+		return processed, nil
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrInvalidImage
+	}
+	processed.Width, processed.Height = cfg.Width, cfg.Height
+	if cfg.Width <= p.thumbnailSize && cfg.Height <= p.thumbnailSize {
+		return processed, nil
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	processed.Thumbnail, processed.ThumbnailType, err = thumbnail(src, contentType, p.thumbnailSize)
+	if err != nil {
+		return nil, err
+	}
+	return processed, nil
+}
+
+// sniff returns the media type of data without parameters, as detected by the algorithm browsers use
+func sniff(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return strings.TrimSpace(contentType)
+}
+
+// thumbnail scales src down to fit a size by size square, keeping its aspect ratio. Photographs are encoded as jpeg,
+// other images as png so that transparency survives.
+func thumbnail(src image.Image, contentType string, size int) ([]byte, string, error) {
+	bounds := src.Bounds()
+	width, height := size, size
+	if bounds.Dx() > bounds.Dy() {
+		height = bounds.Dy() * size / bounds.Dx()
+	} else {
+		width = bounds.Dx() * size / bounds.Dy()
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
+
+// Extension returns the file extension used for the stored files of contentType
+func Extension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	case "application/pdf":
+		return ".pdf"
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package media
+
+import (
+	"bytes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"reflect"
+	"testing"
+)
+
+func encodedImage(t *testing.T, width, height int, encode func(*bytes.Buffer, image.Image) error) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, x%height, color.RGBA{R: 200, A: 255})
+	}
+	var buf bytes.Buffer
+	if err := encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodePng(buf *bytes.Buffer, img image.Image) error {
+	return png.Encode(buf, img)
+}
+
+func encodeJpeg(buf *bytes.Buffer, img image.Image) error {
+	return jpeg.Encode(buf, img, nil)
+}
+
+func TestProcessor_Process(t *testing.T) {
+	p := NewProcessorI(config.MediaConfig{MaxUploadBytes: 1 << 20, ThumbnailSize: 100})
+	tests := []struct {
+		name          string
+		data          []byte
+		want          Processed
+		thumbnailSize image.Point
+		err           error
+	}{
+		{
+			name:          "Success::Png::Thumbnail",
+			data:          encodedImage(t, 400, 200, encodePng),
+			want:          Processed{ContentType: "image/png", Width: 400, Height: 200, ThumbnailType: "image/png"},
+			thumbnailSize: image.Pt(100, 50),
+		},
+		{
+			name:          "Success::Jpeg::Thumbnail",
+			data:          encodedImage(t, 150, 300, encodeJpeg),
+			want:          Processed{ContentType: "image/jpeg", Width: 150, Height: 300, ThumbnailType: "image/jpeg"},
+			thumbnailSize: image.Pt(50, 100),
+		},
+		{
+			name: "Success::SmallImage::NoThumbnail",
+			data: encodedImage(t, 80, 60, encodePng),
+			want: Processed{ContentType: "image/png", Width: 80, Height: 60},
+		},
+		{
+			name: "Success::Pdf",
+			data: []byte("%PDF-1.7\n1 0 obj\n"),
+			want: Processed{ContentType: "application/pdf"},
+		},
+		{
+			name: "Failure::DeclaredImageIsHtml",
+			data: []byte("<html><script>alert(1)</script></html>"),
+			err:  ErrUnsupportedType,
+		},
+		{
+			name: "Failure::Svg",
+			data: []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`),
+			err:  ErrUnsupportedType,
+		},
+		{
+			name: "Failure::CorruptPng",
+			data: append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 32)...),
+			err:  ErrInvalidImage,
+		},
+		{
+			name: "Failure::TooLarge",
+			data: append([]byte("%PDF-1.7\n"), make([]byte, 1<<20)...),
+			err:  ErrTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.Process(tt.data)
+			if err != tt.err {
+				t.Fatalf("Want: %v, Got: %v", tt.err, err)
+			}
+			if err != nil {
+				return
+			}
+			thumbnail := got.Thumbnail
+			got.Thumbnail = nil
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Want: %v, Got: %v", tt.want, *got)
+			}
+			if tt.thumbnailSize == (image.Point{}) {
+				if thumbnail != nil {
+					t.Errorf("Want: no thumbnail, Got: %d bytes", len(thumbnail))
+				}
+				return
+			}
+			cfg, _, err := image.DecodeConfig(bytes.NewReader(thumbnail))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if size := image.Pt(cfg.Width, cfg.Height); size != tt.thumbnailSize {
+				t.Errorf("Want: %v, Got: %v", tt.thumbnailSize, size)
+			}
+		})
+	}
+}
+
+func TestNewProcessorI_Defaults(t *testing.T) {
+	p := NewProcessorI(config.MediaConfig{})
+	if p.MaxBytes() != DefaultMaxUploadBytes {
+		t.Errorf("Want: %v, Got: %v", DefaultMaxUploadBytes, p.MaxBytes())
+	}
+	if _, err := p.Process([]byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;")); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
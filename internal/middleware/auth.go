@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const (
+	bearerChallenge = `Bearer realm="article-management-sys"`
+	apiKeyChallenge = `ApiKey realm="article-management-sys"`
+)
+
+// Authenticate attaches the caller identified by the request's X-API-Key or bearer token to its context.
+// Requests without credentials pass through anonymously, invalid credentials are rejected with 401.
+func (t Middleware) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get("X-API-Key"); key != "" {
+			if t.apiKeys == nil {
+				unauthorized(w, codes.GetErr(codes.ErrInvalidApiKey), apiKeyChallenge)
+				return
+			}
+			identity, err := t.apiKeys.Verify(key)
+			if err != nil {
+				log.Print(err)
+				if errors.Is(err, auth.ErrInvalidApiKey) {
+					unauthorized(w, codes.GetErr(codes.ErrInvalidApiKey), apiKeyChallenge)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(&model.Response{
+					Status:  http.StatusInternalServerError,
+					Message: codes.GetErr(codes.ErrDataSource),
+					Data:    nil,
+				})
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+			return
+		}
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		token := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+		if !strings.HasPrefix(header, "Bearer ") || token == "" || t.verifier == nil {
+			unauthorized(w, codes.GetErr(codes.ErrInvalidToken), bearerChallenge+`, error="invalid_request"`)
+			return
+		}
+		identity, err := t.verifier.Verify(token)
+		if err != nil {
+			log.Print(err)
+			unauthorized(w, codes.GetErr(codes.ErrInvalidToken), bearerChallenge+`, error="invalid_token"`)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+	})
+}
+
+// RequireAuth rejects requests that Authenticate did not attach an identity to
+func (t Middleware) RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := auth.IdentityFrom(r.Context()); !ok {
+			unauthorized(w, codes.GetErr(codes.ErrUnauthorized), bearerChallenge)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireRole rejects callers holding none of the given roles with 403
+func (t Middleware) RequireRole(roles ...string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, ok := auth.IdentityFrom(r.Context())
+			if !ok {
+				unauthorized(w, codes.GetErr(codes.ErrUnauthorized), bearerChallenge)
+				return
+			}
+			if !identity.HasRole(roles...) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				_ = json.NewEncoder(w).Encode(&model.Response{
+					Status:  http.StatusForbidden,
+					Message: codes.GetErr(codes.ErrForbidden),
+					Data:    nil,
+				})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func unauthorized(w http.ResponseWriter, message string, challenge string) {
+	w.Header().Set("WWW-Authenticate", challenge)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(&model.Response{
+		Status:  http.StatusUnauthorized,
+		Message: message,
+		Data:    nil,
+	})
+}
@@ -0,0 +1,230 @@
+package middleware
+
+import (
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestMiddleware_Authenticate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	identity := &model.Identity{Subject: "user-1", Method: "jwt"}
+	tests := []struct {
+		name      string
+		require   bool
+		setupFunc func() (*http.Request, *mock.MockTokenVerifierI)
+		validator func(*httptest.ResponseRecorder, *model.Identity)
+	}{
+		{
+			name: "SUCCESS::Authenticate::valid token",
+			setupFunc: func() (*http.Request, *mock.MockTokenVerifierI) {
+				req := httptest.NewRequest(http.MethodPost, "/articles", nil)
+				req.Header.Set("Authorization", "Bearer token")
+				verifier := mock.NewMockTokenVerifierI(mockCtrl)
+				verifier.EXPECT().Verify("token").Return(identity, nil)
+				return req, verifier
+			},
+			validator: func(res *httptest.ResponseRecorder, got *model.Identity) {
+				if !reflect.DeepEqual(identity, got) {
+					t.Errorf("Want: %v, Got: %v", identity, got)
+				}
+			},
+		},
+		{
+			name: "SUCCESS::Authenticate::anonymous read",
+			setupFunc: func() (*http.Request, *mock.MockTokenVerifierI) {
+				return httptest.NewRequest(http.MethodGet, "/articles", nil), mock.NewMockTokenVerifierI(mockCtrl)
+			},
+			validator: func(res *httptest.ResponseRecorder, got *model.Identity) {
+				if !reflect.DeepEqual(http.StatusOK, res.Code) {
+					t.Errorf("Want: %v, Got: %v", http.StatusOK, res.Code)
+				}
+				if got != nil {
+					t.Errorf("Want: %v, Got: %v", nil, got)
+				}
+			},
+		},
+		{
+			name: "Failure::Authenticate::invalid token",
+			setupFunc: func() (*http.Request, *mock.MockTokenVerifierI) {
+				req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+				req.Header.Set("Authorization", "Bearer token")
+				verifier := mock.NewMockTokenVerifierI(mockCtrl)
+				verifier.EXPECT().Verify("token").Return(nil, auth.ErrInvalidToken)
+				return req, verifier
+			},
+			validator: func(res *httptest.ResponseRecorder, got *model.Identity) {
+				if !reflect.DeepEqual(http.StatusUnauthorized, res.Code) {
+					t.Errorf("Want: %v, Got: %v", http.StatusUnauthorized, res.Code)
+				}
+				var resp model.Response
+				_ = json.NewDecoder(res.Body).Decode(&resp)
+				if !reflect.DeepEqual(codes.GetErr(codes.ErrInvalidToken), resp.Message) {
+					t.Errorf("Want: %v, Got: %v", codes.GetErr(codes.ErrInvalidToken), resp.Message)
+				}
+			},
+		},
+		{
+			name: "Failure::Authenticate::not a bearer token",
+			setupFunc: func() (*http.Request, *mock.MockTokenVerifierI) {
+				req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+				req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+				return req, mock.NewMockTokenVerifierI(mockCtrl)
+			},
+			validator: func(res *httptest.ResponseRecorder, got *model.Identity) {
+				if !reflect.DeepEqual(http.StatusUnauthorized, res.Code) {
+					t.Errorf("Want: %v, Got: %v", http.StatusUnauthorized, res.Code)
+				}
+			},
+		},
+		{
+			name:    "Failure::RequireAuth::anonymous write",
+			require: true,
+			setupFunc: func() (*http.Request, *mock.MockTokenVerifierI) {
+				return httptest.NewRequest(http.MethodPost, "/articles", nil), mock.NewMockTokenVerifierI(mockCtrl)
+			},
+			validator: func(res *httptest.ResponseRecorder, got *model.Identity) {
+				if !reflect.DeepEqual(http.StatusUnauthorized, res.Code) {
+					t.Errorf("Want: %v, Got: %v", http.StatusUnauthorized, res.Code)
+				}
+				if res.Header().Get("WWW-Authenticate") == "" {
+					t.Errorf("Want: %v, Got: %v", "challenge", "")
+				}
+			},
+		},
+		{
+			name:    "SUCCESS::RequireAuth::authenticated write",
+			require: true,
+			setupFunc: func() (*http.Request, *mock.MockTokenVerifierI) {
+				req := httptest.NewRequest(http.MethodPost, "/articles", nil)
+				req.Header.Set("Authorization", "Bearer token")
+				verifier := mock.NewMockTokenVerifierI(mockCtrl)
+				verifier.EXPECT().Verify("token").Return(identity, nil)
+				return req, verifier
+			},
+			validator: func(res *httptest.ResponseRecorder, got *model.Identity) {
+				if !reflect.DeepEqual(http.StatusOK, res.Code) {
+					t.Errorf("Want: %v, Got: %v", http.StatusOK, res.Code)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, verifier := tt.setupFunc()
+			middleware := Middleware{cfg: &config.Config{}, verifier: verifier}
+			var got *model.Identity
+			var next http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = auth.IdentityFrom(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+			if tt.require {
+				next = middleware.RequireAuth(next)
+			}
+			res := httptest.NewRecorder()
+			middleware.Authenticate(next).ServeHTTP(res, req)
+			tt.validator(res, got)
+		})
+	}
+}
+
+func TestMiddleware_AuthenticateApiKey(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	identity := &model.Identity{Subject: "apikey:1", Roles: []string{"admin"}, Method: "api_key"}
+	tests := []struct {
+		name       string
+		roles      []string
+		setupFunc  func(*mock.MockApiKeyVerifierI) *http.Request
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name: "SUCCESS::Authenticate::valid api key",
+			setupFunc: func(verifier *mock.MockApiKeyVerifierI) *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/articles", nil)
+				req.Header.Set("X-API-Key", "key")
+				verifier.EXPECT().Verify("key").Return(identity, nil)
+				return req
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Failure::Authenticate::invalid api key",
+			setupFunc: func(verifier *mock.MockApiKeyVerifierI) *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/articles", nil)
+				req.Header.Set("X-API-Key", "key")
+				verifier.EXPECT().Verify("key").Return(nil, auth.ErrInvalidApiKey)
+				return req
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantMsg:    codes.GetErr(codes.ErrInvalidApiKey),
+		},
+		{
+			name:  "SUCCESS::RequireRole::admin",
+			roles: []string{"admin"},
+			setupFunc: func(verifier *mock.MockApiKeyVerifierI) *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/admin/api-keys", nil)
+				req.Header.Set("X-API-Key", "key")
+				verifier.EXPECT().Verify("key").Return(identity, nil)
+				return req
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "Failure::RequireRole::missing role",
+			roles: []string{"editor"},
+			setupFunc: func(verifier *mock.MockApiKeyVerifierI) *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/admin/api-keys", nil)
+				req.Header.Set("X-API-Key", "key")
+				verifier.EXPECT().Verify("key").Return(identity, nil)
+				return req
+			},
+			wantStatus: http.StatusForbidden,
+			wantMsg:    codes.GetErr(codes.ErrForbidden),
+		},
+		{
+			name:  "Failure::RequireRole::anonymous",
+			roles: []string{"admin"},
+			setupFunc: func(verifier *mock.MockApiKeyVerifierI) *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/admin/api-keys", nil)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantMsg:    codes.GetErr(codes.ErrUnauthorized),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := mock.NewMockApiKeyVerifierI(mockCtrl)
+			req := tt.setupFunc(verifier)
+			middleware := Middleware{cfg: &config.Config{}, apiKeys: verifier}
+			var next http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			if tt.roles != nil {
+				next = middleware.RequireRole(tt.roles...)(next)
+			}
+			res := httptest.NewRecorder()
+			middleware.Authenticate(next).ServeHTTP(res, req)
+			if !reflect.DeepEqual(tt.wantStatus, res.Code) {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, res.Code)
+			}
+			if tt.wantMsg != "" {
+				var resp model.Response
+				_ = json.NewDecoder(res.Body).Decode(&resp)
+				if !reflect.DeepEqual(tt.wantMsg, resp.Message) {
+					t.Errorf("Want: %v, Got: %v", tt.wantMsg, resp.Message)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"net/http"
+)
+
+// LimitBody refuses request bodies larger than max bytes with 413, right away when they announce their length.
+// It goes in front of the middlewares reading the whole body, such as Idempotency.
+func (t Middleware) LimitBody(max int64) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > max {
+				writeError(w, http.StatusRequestEntityTooLarge, codes.GetErr(codes.ErrRequestTooLarge))
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, max)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
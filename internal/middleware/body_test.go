@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware_LimitBody(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := ioutil.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64
+		want          int
+	}{
+		{name: "Success::within the limit", body: "12345", contentLength: 5, want: http.StatusOK},
+		{name: "Failure::announced length over the limit", body: "123456", contentLength: 6, want: http.StatusRequestEntityTooLarge},
+		{name: "Failure::unannounced length over the limit", body: "123456", contentLength: -1, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/articles/1/media", strings.NewReader(tt.body))
+			r.ContentLength = tt.contentLength
+			w := httptest.NewRecorder()
+			Middleware{}.LimitBody(5)(next).ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("Want: %v, Got: %v", tt.want, w.Code)
+			}
+		})
+	}
+}
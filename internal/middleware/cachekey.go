@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/i18n"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/cacher"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// cacheKey builds a canonical cache key for r: the versioned prefix, the path, the whitelisted query
+// parameters sorted by name and value, the normalised values of the configured Vary headers and the
+// languages the request prefers, so responses translated into different languages never share a key.
+// e.g. ams:v1:/articles?limit=10&page=2|accept-encoding=br,gzip|lang=fr-ca,fr
+func cacheKey(cfg config.CacheConfig, r *http.Request) string {
+	var parts []string
+	if cfg.KeyPrefix != "" {
+		parts = append(parts, cfg.KeyPrefix)
+	}
+	if cfg.KeyVersion != "" {
+		parts = append(parts, cfg.KeyVersion)
+	}
+	key := r.URL.EscapedPath()
+	if key == "" {
+		key = "/"
+	}
+	if query := canonicalQuery(r.URL.Query(), cfg.QueryParams); query != "" {
+		key += "?" + query
+	}
+	if vary := canonicalVary(r.Header, cfg.VaryHeaders); vary != "" {
+		key += "|" + vary
+	}
+	if preferences := i18n.Preferences(r); len(preferences) > 0 {
+		key += "|lang=" + strings.ToLower(strings.Join(preferences, ","))
+	}
+	return strings.Join(append(parts, key), ":")
+}
+
+// PathTag names the set recording every cache key of path, whatever its query and vary values
+// e.g. ams:v1:tag:/articles/1
+func PathTag(cfg config.CacheConfig, path string) string {
+	var parts []string
+	if cfg.KeyPrefix != "" {
+		parts = append(parts, cfg.KeyPrefix)
+	}
+	if cfg.KeyVersion != "" {
+		parts = append(parts, cfg.KeyVersion)
+	}
+	if path == "" {
+		path = "/"
+	}
+	return strings.Join(append(parts, "tag", path), ":")
+}
+
+// InvalidatePaths removes every cached response of the given paths
+func InvalidatePaths(cfg config.CacheConfig, c cacher.CacherI, paths ...string) error {
+	tags := make([]string, 0, len(paths))
+	for _, path := range paths {
+		tags = append(tags, PathTag(cfg, path))
+	}
+	_, err := c.InvalidateTags(tags...)
+	return err
+}
+
+// canonicalQuery keeps the whitelisted, non-empty parameters (all of them when the whitelist is empty)
+// and encodes them sorted by name and then value
+func canonicalQuery(query url.Values, whitelist []string) string {
+	kept := url.Values{}
+	for name, values := range query {
+		if len(whitelist) > 0 && !contains(whitelist, name) {
+			continue
+		}
+		for _, v := range values {
+			if v != "" {
+				kept.Add(name, v)
+			}
+		}
+	}
+	for name := range kept {
+		sort.Strings(kept[name])
+	}
+	return kept.Encode()
+}
+
+// canonicalVary lower-cases and sorts the comma separated tokens of every vary header
+func canonicalVary(header http.Header, vary []string) string {
+	var parts []string
+	for _, name := range vary {
+		var tokens []string
+		for _, value := range header.Values(name) {
+			for _, token := range strings.Split(value, ",") {
+				if token = strings.ToLower(strings.TrimSpace(token)); token != "" {
+					tokens = append(tokens, token)
+				}
+			}
+		}
+		sort.Strings(tokens)
+		parts = append(parts, strings.ToLower(name)+"="+strings.Join(tokens, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCacheKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.CacheConfig
+		url     string
+		headers map[string]string
+		want    string
+	}{
+		{
+			name: "Success::query params sorted",
+			url:  "/articles?page=2&limit=10",
+			want: "/articles?limit=10&page=2",
+		},
+		{
+			name: "Success::unknown params dropped",
+			cfg:  config.CacheConfig{QueryParams: []string{"limit", "page"}},
+			url:  "/articles?utm_source=mail&page=2&limit=10&page=",
+			want: "/articles?limit=10&page=2",
+		},
+		{
+			name: "Success::repeated values sorted",
+			url:  "/articles?tag=go&tag=db",
+			want: "/articles?tag=db&tag=go",
+		},
+		{
+			name:    "Success::vary headers normalised",
+			cfg:     config.CacheConfig{VaryHeaders: []string{"Accept-Encoding", "Accept"}},
+			url:     "/articles/1",
+			headers: map[string]string{"Accept-Encoding": "GZIP, br"},
+			want:    "/articles/1|accept-encoding=br,gzip;accept=",
+		},
+		{
+			name:    "Success::accept-language preferences",
+			url:     "/articles/1",
+			headers: map[string]string{"Accept-Language": "en;q=0.5, fr-CA"},
+			want:    "/articles/1|lang=fr-ca,fr,en",
+		},
+		{
+			name:    "Success::lang param overrides accept-language",
+			cfg:     config.CacheConfig{QueryParams: []string{"limit"}},
+			url:     "/articles/1?lang=de",
+			headers: map[string]string{"Accept-Language": "fr"},
+			want:    "/articles/1|lang=de",
+		},
+		{
+			name: "Success::versioned prefix",
+			cfg:  config.CacheConfig{KeyPrefix: "ams", KeyVersion: "v2"},
+			url:  "/articles",
+			want: "ams:v2:/articles",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			if got := cacheKey(tt.cfg, r); got != tt.want {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/compress"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/httpcache"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// defaultEncodings is used when compression.encodings is not configured
+var defaultEncodings = []string{compress.Brotli, compress.Gzip}
+
+// compressWriter holds the response back until minSize bytes are written, then compresses it with encoding.
+// Responses that are smaller, already encoded or not compressible are passed through unchanged.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+	status   int
+	buf      []byte
+	decided  bool
+	enc      io.WriteCloser
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided || w.status != 0 {
+		return
+	}
+	w.status = code
+	if code < 200 || code == http.StatusNoContent || code == http.StatusNotModified {
+		w.passThrough()
+	}
+}
+
+func (w *compressWriter) Write(d []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.decided {
+		if w.Header().Get("Content-Encoding") != "" || !compressible(w.Header().Get("Content-Type")) {
+			w.passThrough()
+		} else {
+			w.buf = append(w.buf, d...)
+			if len(w.buf) >= w.minSize {
+				if err := w.startEncoding(); err != nil {
+					return 0, err
+				}
+			}
+			return len(d), nil
+		}
+	}
+	if w.enc != nil {
+		return w.enc.Write(d)
+	}
+	return w.ResponseWriter.Write(d)
+}
+
+// Flush forces the pending bytes out, starting compression if the response is compressible
+func (w *compressWriter) Flush() {
+	if !w.decided && len(w.buf) > 0 {
+		if err := w.startEncoding(); err != nil {
+			log.Print(err)
+		}
+	}
+	if f, ok := w.enc.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			log.Print(err)
+		}
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// close finishes the response once the wrapped handler has returned
+func (w *compressWriter) close() {
+	if !w.decided {
+		w.passThrough()
+		return
+	}
+	if w.enc != nil {
+		if err := w.enc.Close(); err != nil {
+			log.Print(err)
+		}
+	}
+}
+
+func (w *compressWriter) startEncoding() error {
+	w.decided = true
+	enc, err := compress.NewWriter(w.encoding, w.ResponseWriter)
+	if err != nil {
+		return err
+	}
+	w.enc = enc
+	h := w.Header()
+	h.Set("Content-Encoding", w.encoding)
+	h.Del("Content-Length")
+	if etag := h.Get("ETag"); etag != "" {
+		h.Set("ETag", httpcache.EncodedETag(etag, w.encoding))
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	buf := w.buf
+	w.buf = nil
+	_, err = enc.Write(buf)
+	return err
+}
+
+func (w *compressWriter) passThrough() {
+	w.decided = true
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	if len(w.buf) > 0 {
+		if _, err := w.ResponseWriter.Write(w.buf); err != nil {
+			log.Print(err)
+		}
+		w.buf = nil
+	}
+}
+
+// compressible reports whether a content type benefits from compression
+func compressible(contentType string) bool {
+	contentType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch {
+	case contentType == "", strings.HasPrefix(contentType, "text/"):
+		return true
+	case contentType == "application/json", contentType == "application/javascript", contentType == "application/xml",
+		contentType == "image/svg+xml", strings.HasSuffix(contentType, "+json"), strings.HasSuffix(contentType, "+xml"):
+		return true
+	}
+	return false
+}
+
+// Compress negotiates a content-coding from Accept-Encoding and compresses the response with it
+func (t Middleware) Compress(next http.Handler) http.Handler {
+	if !t.cfg.Compression.Enabled {
+		return next
+	}
+	encodings := t.cfg.Compression.Encodings
+	if len(encodings) == 0 {
+		encodings = defaultEncodings
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := compress.Negotiate(r.Header.Get("Accept-Encoding"), encodings)
+		if encoding == compress.Identity || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: t.cfg.Compression.MinSize}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/compress"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/cacher"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddleware_Compress(t *testing.T) {
+	body := strings.Repeat("article content ", 64)
+	tests := []struct {
+		name      string
+		config    config.CompressionConfig
+		accept    string
+		handler   http.HandlerFunc
+		validator func(*httptest.ResponseRecorder)
+	}{
+		{
+			name:   "SUCCESS::Compress::brotli preferred",
+			config: config.CompressionConfig{Enabled: true, MinSize: 32},
+			accept: "gzip, br",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("ETag", `"abc"`)
+				_, _ = w.Write([]byte(body))
+			},
+			validator: func(res *httptest.ResponseRecorder) {
+				if !reflect.DeepEqual("br", res.Header().Get("Content-Encoding")) {
+					t.Errorf("Want: %v, Got: %v", "br", res.Header().Get("Content-Encoding"))
+				}
+				if !reflect.DeepEqual(`"abc-br"`, res.Header().Get("ETag")) {
+					t.Errorf("Want: %v, Got: %v", `"abc-br"`, res.Header().Get("ETag"))
+				}
+				decoded, err := compress.Decode(compress.Brotli, res.Body.Bytes())
+				if err != nil || string(decoded) != body {
+					t.Errorf("Want: %v, Got: %v", body, string(decoded))
+				}
+			},
+		},
+		{
+			name:   "SUCCESS::Compress::gzip by q-value",
+			config: config.CompressionConfig{Enabled: true, MinSize: 32},
+			accept: "br;q=0.5, gzip",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(body[:16]))
+				_, _ = w.Write([]byte(body[16:]))
+			},
+			validator: func(res *httptest.ResponseRecorder) {
+				if !reflect.DeepEqual(http.StatusCreated, res.Code) {
+					t.Errorf("Want: %v, Got: %v", http.StatusCreated, res.Code)
+				}
+				decoded, err := compress.Decode(compress.Gzip, res.Body.Bytes())
+				if err != nil || string(decoded) != body {
+					t.Errorf("Want: %v, Got: %v", body, string(decoded))
+				}
+			},
+		},
+		{
+			name:   "SUCCESS::Compress::small body sent as is",
+			config: config.CompressionConfig{Enabled: true, MinSize: 1024},
+			accept: "gzip",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("{}"))
+			},
+			validator: func(res *httptest.ResponseRecorder) {
+				if !reflect.DeepEqual("", res.Header().Get("Content-Encoding")) {
+					t.Errorf("Want: %v, Got: %v", "", res.Header().Get("Content-Encoding"))
+				}
+				if !reflect.DeepEqual("{}", res.Body.String()) {
+					t.Errorf("Want: %v, Got: %v", "{}", res.Body.String())
+				}
+			},
+		},
+		{
+			name:   "SUCCESS::Compress::binary content sent as is",
+			config: config.CompressionConfig{Enabled: true},
+			accept: "gzip",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "image/png")
+				_, _ = w.Write([]byte(body))
+			},
+			validator: func(res *httptest.ResponseRecorder) {
+				if !reflect.DeepEqual("", res.Header().Get("Content-Encoding")) {
+					t.Errorf("Want: %v, Got: %v", "", res.Header().Get("Content-Encoding"))
+				}
+			},
+		},
+		{
+			name:   "SUCCESS::Compress::identity client",
+			config: config.CompressionConfig{Enabled: true},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(body))
+			},
+			validator: func(res *httptest.ResponseRecorder) {
+				if !reflect.DeepEqual(body, res.Body.String()) {
+					t.Errorf("Want: %v, Got: %v", body, res.Body.String())
+				}
+				if !reflect.DeepEqual("Accept-Encoding", res.Header().Get("Vary")) {
+					t.Errorf("Want: %v, Got: %v", "Accept-Encoding", res.Header().Get("Vary"))
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := Middleware{cfg: &config.Config{Compression: tt.config}}
+			req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept-Encoding", tt.accept)
+			}
+			res := httptest.NewRecorder()
+			middleware.Compress(tt.handler).ServeHTTP(res, req)
+			tt.validator(res)
+		})
+	}
+}
+
+func TestMiddleware_CompressCacher(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	middleware := Middleware{
+		cacher: cacher.NewCacher(config.CacheSvc{Rdb: redis.NewClient(&redis.Options{Addr: mr.Addr()})}),
+		cfg: &config.Config{
+			Compression: config.CompressionConfig{Enabled: true, MinSize: 10},
+			Cacher:      config.CacheConfig{KeyExpiryDuration: time.Minute},
+		},
+	}
+	handler := middleware.Compress(middleware.Cacher(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"abc"`)
+		_, _ = w.Write([]byte(strings.Repeat(`{"status":200}`, 10)))
+	})))
+	for _, tt := range []struct {
+		acceptEncoding string
+		ifNoneMatch    string
+		wantCache      string
+		wantStatus     int
+		wantETag       string
+	}{
+		{acceptEncoding: "br", wantCache: "MISS", wantStatus: http.StatusOK, wantETag: `"abc-br"`},
+		{acceptEncoding: "gzip", wantCache: "HIT", wantStatus: http.StatusOK, wantETag: `"abc-gzip"`},
+		{acceptEncoding: "br", wantCache: "HIT", wantStatus: http.StatusOK, wantETag: `"abc-br"`},
+		{acceptEncoding: "gzip", ifNoneMatch: `"abc-gzip"`, wantCache: "HIT", wantStatus: http.StatusNotModified, wantETag: `"abc-gzip"`},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+		req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+		if tt.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+		}
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+		if res.Code != tt.wantStatus || res.Header().Get("X-Cache") != tt.wantCache {
+			t.Errorf("Want: %v %v, Got: %v %v", tt.wantStatus, tt.wantCache, res.Code, res.Header().Get("X-Cache"))
+		}
+		if got := res.Header().Get("ETag"); got != tt.wantETag {
+			t.Errorf("Want: %v, Got: %v", tt.wantETag, got)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+)
+
+// defaultIdempotencyWindow is used when idempotency.window is not configured
+const defaultIdempotencyWindow = 24 * time.Hour
+
+const maxIdempotencyKeyLength = 255
+
+// idempotencyRecord is stored under an Idempotency-Key, Status is zero while the first request is in flight
+type idempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Location    string `json:"location,omitempty"`
+	// ETag and LastModified let a client retrying an update send its next If-Match
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	Body         []byte `json:"body,omitempty"`
+}
+
+// idempotencyWriter passes the response through while keeping a copy of it
+type idempotencyWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *idempotencyWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *idempotencyWriter) Write(d []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(d)
+	return w.ResponseWriter.Write(d)
+}
+
+// Idempotency replays the stored response of requests retried with the same Idempotency-Key.
+// Keys are scoped to the caller, reusing one with a different request is rejected with 422 and
+// retrying while the first request is still in flight with 409. Failed (5xx) requests may be retried.
+func (t Middleware) Idempotency(next http.Handler) http.Handler {
+	if !t.cfg.Idempotency.Enabled {
+		return next
+	}
+	window := t.cfg.Idempotency.WindowDuration
+	if window <= 0 {
+		window = defaultIdempotencyWindow
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !validIdempotencyKey(key) {
+			writeError(w, http.StatusBadRequest, codes.GetErr(codes.ErrInvalidIdempotencyKey))
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			log.Print(err)
+			writeError(w, http.StatusBadRequest, codes.GetErr(codes.ErrReadingReqBody))
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		storeKey := t.idempotencyKey(r, key)
+		fingerprint := requestFingerprint(r, body)
+		pending, _ := json.Marshal(idempotencyRecord{Fingerprint: fingerprint})
+		claimed, err := t.cacher.SetNX(storeKey, pending, window)
+		if err != nil {
+			// without the store the request can only be served without idempotency guarantees
+			log.Print(err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !claimed {
+			t.replayIdempotent(w, storeKey, fingerprint)
+			return
+		}
+
+		iw := &idempotencyWriter{ResponseWriter: w}
+		next.ServeHTTP(iw, r)
+		if iw.status == 0 || iw.status >= http.StatusInternalServerError {
+			if err := t.cacher.Delete(storeKey); err != nil {
+				log.Print(err)
+			}
+			return
+		}
+		done, err := json.Marshal(idempotencyRecord{
+			Fingerprint:  fingerprint,
+			Status:       iw.status,
+			ContentType:  w.Header().Get("Content-Type"),
+			Location:     w.Header().Get("Location"),
+			ETag:         w.Header().Get("ETag"),
+			LastModified: w.Header().Get("Last-Modified"),
+			Body:         iw.body.Bytes(),
+		})
+		if err == nil {
+			err = t.cacher.Set(storeKey, done, window)
+		}
+		if err != nil {
+			log.Print(err)
+		}
+	})
+}
+
+// replayIdempotent answers a retry from the record stored under storeKey
+func (t Middleware) replayIdempotent(w http.ResponseWriter, storeKey string, fingerprint string) {
+	var record idempotencyRecord
+	data, err := t.cacher.Get(storeKey)
+	if err == nil {
+		err = json.Unmarshal(data, &record)
+	}
+	if err != nil {
+		// the record expired or vanished between the two calls, let the client try again
+		log.Print(err)
+		w.Header().Set("Retry-After", "1")
+		writeError(w, http.StatusConflict, codes.GetErr(codes.ErrIdempotencyInProgress))
+		return
+	}
+	switch {
+	case record.Fingerprint != fingerprint:
+		writeError(w, http.StatusUnprocessableEntity, codes.GetErr(codes.ErrIdempotencyKeyReused))
+	case record.Status == 0:
+		w.Header().Set("Retry-After", "1")
+		writeError(w, http.StatusConflict, codes.GetErr(codes.ErrIdempotencyInProgress))
+	default:
+		if record.ContentType != "" {
+			w.Header().Set("Content-Type", record.ContentType)
+		}
+		if record.Location != "" {
+			w.Header().Set("Location", record.Location)
+		}
+		if record.ETag != "" {
+			w.Header().Set("ETag", record.ETag)
+		}
+		if record.LastModified != "" {
+			w.Header().Set("Last-Modified", record.LastModified)
+		}
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(record.Status)
+		_, _ = w.Write(record.Body)
+	}
+}
+
+// idempotencyKey scopes the client's key to the caller so clients cannot replay each other's responses
+func (t Middleware) idempotencyKey(r *http.Request, key string) string {
+	prefix := t.cfg.Cacher.KeyPrefix
+	if prefix == "" {
+		prefix = "article-management-sys"
+	}
+	caller := "anonymous"
+	if identity, ok := auth.IdentityFrom(r.Context()); ok {
+		caller = identity.Subject
+	}
+	return prefix + ":idempotency:" + caller + ":" + key
+}
+
+// requestFingerprint identifies the method, path, query and body of a request
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&model.Response{
+		Status:  status,
+		Message: message,
+		Data:    nil,
+	})
+}
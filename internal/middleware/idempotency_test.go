@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddleware_Idempotency(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	const (
+		storeKey     = "article-management-sys:idempotency:user-1:abc"
+		etag         = `"v2-0123456789abcdef0123456789abcdef"`
+		lastModified = "Mon, 02 Jan 2023 15:04:05 GMT"
+	)
+	body := `{"title":"title","content":"content"}`
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader(body))
+		req.Header.Set("Idempotency-Key", "abc")
+		return req.WithContext(auth.WithIdentity(req.Context(), &model.Identity{Subject: "user-1"}))
+	}
+	fingerprint := requestFingerprint(newRequest(body), []byte(body))
+	record := func(r idempotencyRecord) []byte {
+		data, _ := json.Marshal(r)
+		return data
+	}
+	tests := []struct {
+		name        string
+		req         *http.Request
+		status      int
+		setupFunc   func(*mock.MockCacherI)
+		wantStatus  int
+		wantBody    string
+		wantCalls   int
+		wantHeaders map[string]string
+	}{
+		{
+			name:   "SUCCESS::Idempotency::first request stored",
+			req:    newRequest(body),
+			status: http.StatusCreated,
+			setupFunc: func(c *mock.MockCacherI) {
+				c.EXPECT().SetNX(storeKey, record(idempotencyRecord{Fingerprint: fingerprint}), time.Hour).Return(true, nil)
+				c.EXPECT().Set(storeKey, record(idempotencyRecord{Fingerprint: fingerprint, Status: http.StatusCreated, ContentType: "application/json", ETag: etag, LastModified: lastModified, Body: []byte(`{"id":"1"}`)}), time.Hour).Return(nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"id":"1"}`,
+			wantCalls:  1,
+		},
+		{
+			name: "SUCCESS::Idempotency::retry replayed",
+			req:  newRequest(body),
+			setupFunc: func(c *mock.MockCacherI) {
+				c.EXPECT().SetNX(storeKey, gomock.Any(), time.Hour).Return(false, nil)
+				c.EXPECT().Get(storeKey).Return(record(idempotencyRecord{Fingerprint: fingerprint, Status: http.StatusCreated, ContentType: "application/json", ETag: etag, LastModified: lastModified, Body: []byte(`{"id":"1"}`)}), nil)
+			},
+			wantStatus:  http.StatusCreated,
+			wantBody:    `{"id":"1"}`,
+			wantHeaders: map[string]string{"Idempotent-Replayed": "true", "Content-Type": "application/json", "ETag": etag, "Last-Modified": lastModified},
+		},
+		{
+			name: "Failure::Idempotency::key reused with another body",
+			req:  newRequest(`{"title":"other","content":"content"}`),
+			setupFunc: func(c *mock.MockCacherI) {
+				c.EXPECT().SetNX(storeKey, gomock.Any(), time.Hour).Return(false, nil)
+				c.EXPECT().Get(storeKey).Return(record(idempotencyRecord{Fingerprint: fingerprint, Status: http.StatusCreated}), nil)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Failure::Idempotency::first request in flight",
+			req:  newRequest(body),
+			setupFunc: func(c *mock.MockCacherI) {
+				c.EXPECT().SetNX(storeKey, gomock.Any(), time.Hour).Return(false, nil)
+				c.EXPECT().Get(storeKey).Return(record(idempotencyRecord{Fingerprint: fingerprint}), nil)
+			},
+			wantStatus:  http.StatusConflict,
+			wantHeaders: map[string]string{"Retry-After": "1"},
+		},
+		{
+			name:   "SUCCESS::Idempotency::server error released for retry",
+			req:    newRequest(body),
+			status: http.StatusInternalServerError,
+			setupFunc: func(c *mock.MockCacherI) {
+				c.EXPECT().SetNX(storeKey, gomock.Any(), time.Hour).Return(true, nil)
+				c.EXPECT().Delete(storeKey).Return(nil)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantCalls:  1,
+		},
+		{
+			name:   "SUCCESS::Idempotency::store unavailable",
+			req:    newRequest(body),
+			status: http.StatusCreated,
+			setupFunc: func(c *mock.MockCacherI) {
+				c.EXPECT().SetNX(storeKey, gomock.Any(), time.Hour).Return(false, errors.New("error"))
+			},
+			wantStatus: http.StatusCreated,
+			wantCalls:  1,
+		},
+		{
+			name: "Failure::Idempotency::invalid key",
+			req: func() *http.Request {
+				req := newRequest(body)
+				req.Header.Set("Idempotency-Key", "a b")
+				return req
+			}(),
+			setupFunc:  func(c *mock.MockCacherI) {},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   codes.GetErr(codes.ErrInvalidIdempotencyKey),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacher := mock.NewMockCacherI(mockCtrl)
+			tt.setupFunc(cacher)
+			middleware := Middleware{
+				cfg:    &config.Config{Idempotency: config.IdempotencyConfig{Enabled: true, WindowDuration: time.Hour}},
+				cacher: cacher,
+			}
+			calls := 0
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				var buf bytes.Buffer
+				_, _ = buf.ReadFrom(r.Body)
+				if buf.String() != body {
+					t.Errorf("Want: %v, Got: %v", body, buf.String())
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("ETag", etag)
+				w.Header().Set("Last-Modified", lastModified)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"id":"1"}`))
+			})
+			res := httptest.NewRecorder()
+			middleware.Idempotency(next).ServeHTTP(res, tt.req)
+			if !reflect.DeepEqual(tt.wantStatus, res.Code) {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, res.Code)
+			}
+			if tt.wantCalls != calls {
+				t.Errorf("Want: %v, Got: %v", tt.wantCalls, calls)
+			}
+			if tt.wantBody != "" && !strings.Contains(res.Body.String(), tt.wantBody) {
+				t.Errorf("Want: %v, Got: %v", tt.wantBody, res.Body.String())
+			}
+			for k, v := range tt.wantHeaders {
+				if got := res.Header().Get(k); got != v {
+					t.Errorf("%s Want: %v, Got: %v", k, v, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestFingerprint(t *testing.T) {
+	body := []byte(`{"title":"title"}`)
+	first := requestFingerprint(httptest.NewRequest(http.MethodPost, "/articles?notify=true", nil), body)
+	if second := requestFingerprint(httptest.NewRequest(http.MethodPost, "/articles?notify=false", nil), body); first == second {
+		t.Errorf("Want: %v, Got: %v", "distinct fingerprints", second)
+	}
+	if again := requestFingerprint(httptest.NewRequest(http.MethodPost, "/articles?notify=true", nil), body); first != again {
+		t.Errorf("Want: %v, Got: %v", first, again)
+	}
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+)
+
+// statusWriter records the status code written by the handler
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(d []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(d)
+}
+
+// Invalidate returns a middleware dropping the cached responses of the paths returned for the request's {id} once
+// a request changing that resource succeeds, so readers do not keep seeing the old version until expiry
+func (t Middleware) Invalidate(paths func(id string) []string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sw := &statusWriter{ResponseWriter: w}
+			next.ServeHTTP(sw, r)
+			if sw.status < 200 || sw.status >= 300 {
+				return
+			}
+			if err := InvalidatePaths(t.cfg.Cacher, t.cacher, paths(mux.Vars(r)["id"])...); err != nil {
+				log.Print(err)
+			}
+		})
+	}
+}
+
+// ArticlePaths lists the cached paths showing the article with the given id: the article itself, the listings,
+// its related articles, its media, the tag counts, the popular articles and every category and author listing, since any of them
+// may include the article. The article is also cached under its slug, which is not known here, so every slug page goes.
+// Related lists of other articles showing this one are left to expire.
+func ArticlePaths(id string) []string {
+	paths := []string{"/articles", "/tags", "/articles/popular", "/categories/{id}/articles", "/authors/{id}/articles", "/articles/by-slug/{slug}"}
+	if id != "" {
+		paths = append(paths, "/articles/"+id, "/articles/"+id+"/related", "/articles/"+id+"/media")
+	}
+	return paths
+}
+
+// CategoryPaths lists the cached paths showing the category with the given id. Moving a category changes the
+// descendants of its old and new ancestors, so every category listing goes.
+func CategoryPaths(id string) []string {
+	paths := []string{"/categories", "/categories/{id}/articles"}
+	if id != "" {
+		paths = append(paths, "/categories/"+id)
+	}
+	return paths
+}
+
+// AuthorPaths lists the cached paths showing the author with the given id. Articles render their author's name,
+// so every article page goes along with the profile.
+func AuthorPaths(id string) []string {
+	paths := []string{"/authors", "/authors/{id}/articles", "/articles", "/articles/{id}", "/articles/by-slug/{slug}", "/categories/{id}/articles"}
+	if id != "" {
+		paths = append(paths, "/authors/"+id)
+	}
+	return paths
+}
+
+// CommentPaths lists the cached paths changed by moderating a comment of the article with the given id: its comment
+// list and, as they show the comment count, the article's own pages. Pending comments are shown nowhere, so posting
+// one invalidates nothing.
+func CommentPaths(id string) []string {
+	return append(ArticlePaths(id), "/articles/"+id+"/comments")
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware_Invalidate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name   string
+		target string
+		paths  func(string) []string
+		status int
+		setup  func(*mock.MockCacherI)
+	}{
+		{
+			name:   "Success:: invalidates article, listing, tags and category listings",
+			target: "/articles/1/publish",
+			paths:  ArticlePaths,
+			status: http.StatusOK,
+			setup: func(c *mock.MockCacherI) {
+				c.EXPECT().InvalidateTags("ams:v1:tag:/articles", "ams:v1:tag:/tags", "ams:v1:tag:/articles/popular", "ams:v1:tag:/categories/{id}/articles", "ams:v1:tag:/authors/{id}/articles", "ams:v1:tag:/articles/by-slug/{slug}", "ams:v1:tag:/articles/1", "ams:v1:tag:/articles/1/related", "ams:v1:tag:/articles/1/media").Return([]string{"k"}, nil)
+			},
+		},
+		{
+			name:   "Success:: invalidates category and category listings",
+			target: "/categories/c1",
+			paths:  CategoryPaths,
+			status: http.StatusOK,
+			setup: func(c *mock.MockCacherI) {
+				c.EXPECT().InvalidateTags("ams:v1:tag:/categories", "ams:v1:tag:/categories/{id}/articles", "ams:v1:tag:/categories/c1").Return([]string{"k"}, nil)
+			},
+		},
+		{
+			name:   "Success:: invalidates comment list and article pages",
+			target: "/articles/1/comments/c1/approve",
+			paths:  CommentPaths,
+			status: http.StatusOK,
+			setup: func(c *mock.MockCacherI) {
+				c.EXPECT().InvalidateTags("ams:v1:tag:/articles", "ams:v1:tag:/tags", "ams:v1:tag:/articles/popular", "ams:v1:tag:/categories/{id}/articles", "ams:v1:tag:/authors/{id}/articles", "ams:v1:tag:/articles/by-slug/{slug}", "ams:v1:tag:/articles/1", "ams:v1:tag:/articles/1/related", "ams:v1:tag:/articles/1/media", "ams:v1:tag:/articles/1/comments").Return([]string{"k"}, nil)
+			},
+		},
+		{
+			name:   "Success:: failed request keeps the cache",
+			target: "/articles/1/publish",
+			paths:  ArticlePaths,
+			status: http.StatusConflict,
+			setup:  func(c *mock.MockCacherI) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCacher := mock.NewMockCacherI(mockCtrl)
+			tt.setup(mockCacher)
+			mid := Middleware{cfg: &config.Config{Cacher: config.CacheConfig{KeyPrefix: "ams", KeyVersion: "v1"}}, cacher: mockCacher}
+			router := mux.NewRouter()
+			handler := mid.Invalidate(tt.paths)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			router.Handle("/articles/{id}/publish", handler)
+			router.Handle("/categories/{id}", handler)
+			router.Handle("/articles/{id}/comments/{comment}/{action}", handler)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.target, nil))
+			if rec.Code != tt.status {
+				t.Errorf("Want: %v, Got: %v", tt.status, rec.Code)
+			}
+		})
+	}
+}
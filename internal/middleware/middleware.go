@@ -1,42 +1,107 @@
 package middleware
 
 import (
+	"bytes"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/compress"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/httpcache"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/ratelimit"
 	redis "github.com/vatsal-chaturvedi/article-management-sys/internal/repo/cacher"
 
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
+var errBypass = errors.New("cache bypassed by request")
+
 type Middleware struct {
-	cfg    *config.Config
-	cacher redis.CacherI
+	cfg      *config.Config
+	cacher   redis.CacherI
+	verifier auth.TokenVerifierI
+	apiKeys  auth.ApiKeyVerifierI
+	limiter  ratelimit.LimiterI
 }
 
+// defaultMaxCacheableBytes bounds the buffered copy of a response when max_cacheable_bytes is not configured
+const defaultMaxCacheableBytes = 1 << 20
+
+// replayedHeaders are stored with a cached response and replayed on hits, besides Content-Type and the validators
+var replayedHeaders = []string{"Content-Language", "Content-Disposition", "Link", "Location"}
+
+// respWriterWithStatus passes the response through to the client while buffering a copy of it for the cache.
+// Once the body grows past maxSize the copy is dropped and the response is not cached.
+// Responses the handler marks private or no-store keep their Cache-Control and are not cached either.
+// The validators are kept as the handler set them, before an outer Compress adds its coding to the ETag.
 type respWriterWithStatus struct {
-	status   int
-	response string
+	status       int
+	etag         string
+	lastModified string
+	body         bytes.Buffer
+	maxSize      int64
+	overflow     bool
+	private      bool
+	cacheControl string
 	http.ResponseWriter
 }
 
 func (w *respWriterWithStatus) WriteHeader(code int) {
+	if w.status != -1 {
+		return
+	}
 	w.status = code
+	w.etag, w.lastModified = w.Header().Get("ETag"), w.Header().Get("Last-Modified")
+	directives := httpcache.CacheControl(w.Header().Get("Cache-Control"))
+	_, private := directives["private"]
+	_, noStore := directives["no-store"]
+	if private || noStore {
+		w.private = true
+	} else if (code >= 200 && code < 300) || code == http.StatusNotModified {
+		w.Header().Set("Cache-Control", w.cacheControl)
+	} else {
+		w.Header().Set("Cache-Control", "no-store")
+	}
 	w.ResponseWriter.WriteHeader(code)
 }
 
 func (w *respWriterWithStatus) Write(d []byte) (int, error) {
-	w.response = string(d)
+	if w.status == -1 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.overflow {
+		if int64(w.body.Len()+len(d)) > w.maxSize {
+			w.overflow = true
+			w.body = bytes.Buffer{}
+		} else {
+			w.body.Write(d)
+		}
+	}
 	return w.ResponseWriter.Write(d)
 }
 
-func NewMiddleware(cfg *config.SvcConfig, cacherI redis.CacherI) *Middleware {
+// Flush lets streaming handlers flush through the capture
+func (w *respWriterWithStatus) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func NewMiddleware(cfg *config.SvcConfig, cacherI redis.CacherI, verifier auth.TokenVerifierI, apiKeys auth.ApiKeyVerifierI, limiter ratelimit.LimiterI) *Middleware {
 	return &Middleware{
-		cfg:    cfg.Cfg,
-		cacher: cacherI,
+		cfg:      cfg.Cfg,
+		cacher:   cacherI,
+		verifier: verifier,
+		apiKeys:  apiKeys,
+		limiter:  limiter,
 	}
 }
 
@@ -44,12 +109,32 @@ func (t Middleware) Cacher(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var key string
 		var cacheResponse model.CacheResponse
-		key = fmt.Sprint(r.URL.String())
+		key = cacheKey(t.cfg.Cacher, r)
+		if len(t.cfg.Cacher.VaryHeaders) > 0 {
+			w.Header().Add("Vary", strings.Join(t.cfg.Cacher.VaryHeaders, ", "))
+		}
+		w.Header().Add("Vary", "Accept-Language")
+		if status := r.URL.Query().Get("status"); status != "" && status != model.StatusPublished {
+			// listings of unpublished articles depend on the caller, who is not part of the key
+			w.Header().Set("X-Cache", "BYPASS")
+			next.ServeHTTP(w, r)
+			return
+		}
+		expiry := t.expiryFor(r)
+		noCache, noStore := t.bypass(r)
 
 		Cacher := t.cacher
-		by, err := Cacher.Get(key)
+		var by []byte
+		err := errBypass
+		if !noCache && !noStore {
+			by, err = Cacher.Get(key)
+		}
 		if err == nil {
-			err = json.Unmarshal(by, &cacheResponse)
+			err = cacheResponse.UnmarshalBinary(by)
+			if err == nil && cacheResponse.Encoding != "" && !compress.Accepts(r.Header.Get("Accept-Encoding"), cacheResponse.Encoding) {
+				cacheResponse.Response, err = compress.Decode(cacheResponse.Encoding, cacheResponse.Response)
+				cacheResponse.Encoding = ""
+			}
 			if err != nil {
 				log.Print(err)
 				w.Header().Set("Content-Type", "application/json")
@@ -61,33 +146,151 @@ func (t Middleware) Cacher(next http.Handler) http.Handler {
 				})
 				return
 			}
-			w.Header().Set("Content-Type", cacheResponse.ContentType)
-			w.Write([]byte(cacheResponse.Response))
-			w.WriteHeader(cacheResponse.Status)
+			age := 0
+			if cacheResponse.StoredAt > 0 {
+				age = int(time.Now().Unix() - cacheResponse.StoredAt)
+			}
+			remaining := cacheResponse.MaxAge - age
+			if remaining < 0 {
+				remaining = 0
+			}
+			w.Header().Set("X-Cache", "HIT")
+			w.Header().Set("Age", strconv.Itoa(age))
+			w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", remaining))
+			if httpcache.NotModified(r, cacheResponse.ETag, cacheResponse.LastModified) {
+				httpcache.WriteNotModified(w, httpcache.EncodedETag(cacheResponse.ETag, cacheResponse.Encoding), cacheResponse.LastModified)
+				return
+			}
+			replay(w, cacheResponse)
 			return
 		}
 
-		hijackedWriter := &respWriterWithStatus{-1, "", w}
+		if noCache || noStore {
+			w.Header().Set("X-Cache", "BYPASS")
+		} else {
+			w.Header().Set("X-Cache", "MISS")
+		}
+		maxAge := int(expiry / time.Second)
+		hijackedWriter := &respWriterWithStatus{
+			status:         -1,
+			maxSize:        t.maxCacheableBytes(),
+			cacheControl:   fmt.Sprintf("public, max-age=%d", maxAge),
+			ResponseWriter: w,
+		}
 		next.ServeHTTP(hijackedWriter, r)
 
-		if hijackedWriter.status < 200 || hijackedWriter.status >= 300 {
+		if hijackedWriter.status < 200 || hijackedWriter.status >= 300 || noStore || hijackedWriter.private {
+			return
+		}
+		if hijackedWriter.overflow {
+			log.Print(fmt.Sprintf("response for %s exceeds %d bytes, not caching", key, hijackedWriter.maxSize))
 			return
 		}
 
 		cacheResponse = model.CacheResponse{
-			Status:      hijackedWriter.status,
-			Response:    hijackedWriter.response,
-			ContentType: w.Header().Get("Content-Type"),
+			Status:       hijackedWriter.status,
+			Response:     hijackedWriter.body.Bytes(),
+			ContentType:  w.Header().Get("Content-Type"),
+			ETag:         hijackedWriter.etag,
+			LastModified: hijackedWriter.lastModified,
+			StoredAt:     time.Now().Unix(),
+			MaxAge:       maxAge,
+		}
+		for _, name := range replayedHeaders {
+			if values := w.Header().Values(name); len(values) > 0 {
+				if cacheResponse.Headers == nil {
+					cacheResponse.Headers = map[string][]string{}
+				}
+				cacheResponse.Headers[name] = values
+			}
 		}
-		byt, err := json.Marshal(cacheResponse)
+		if len(cacheResponse.Response) >= t.cfg.Compression.MinSize {
+			compressed, err := compress.Encode(compress.Gzip, cacheResponse.Response)
+			if err != nil {
+				log.Print(err)
+				return
+			}
+			cacheResponse.Response = compressed
+			cacheResponse.Encoding = compress.Gzip
+		}
+		byt, err := cacheResponse.MarshalBinary()
 		if err != nil {
 			log.Print(err)
 			return
 		}
-		err = Cacher.Set(key, byt, t.cfg.Cacher.KeyExpiryDuration)
+		err = Cacher.Set(key, byt, expiry)
 		if err != nil {
 			log.Print(err)
 			return
 		}
+		err = Cacher.Tag(PathTag(t.cfg.Cacher, r.URL.EscapedPath()), key, expiry)
+		if err != nil {
+			log.Print(err)
+		}
+		// entries are also tagged with their route template so that every /categories/{id}/articles page can go at once
+		if tpl := routeTemplate(r); tpl != "*" && tpl != r.URL.EscapedPath() {
+			err = Cacher.Tag(PathTag(t.cfg.Cacher, tpl), key, expiry)
+			if err != nil {
+				log.Print(err)
+			}
+		}
 	})
 }
+
+// replay writes a cached response: headers first, then the stored status, then the body.
+// A compressed body is sent as is, the caller decodes it beforehand for clients not accepting its coding.
+func replay(w http.ResponseWriter, cacheResponse model.CacheResponse) {
+	for name, values := range cacheResponse.Headers {
+		w.Header()[http.CanonicalHeaderKey(name)] = values
+	}
+	if cacheResponse.Encoding != "" {
+		w.Header().Set("Content-Encoding", cacheResponse.Encoding)
+	}
+	if cacheResponse.ETag != "" {
+		w.Header().Set("ETag", httpcache.EncodedETag(cacheResponse.ETag, cacheResponse.Encoding))
+	}
+	if cacheResponse.LastModified != "" {
+		w.Header().Set("Last-Modified", cacheResponse.LastModified)
+	}
+	if cacheResponse.ContentType != "" {
+		w.Header().Set("Content-Type", cacheResponse.ContentType)
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(cacheResponse.Response)))
+	w.WriteHeader(cacheResponse.Status)
+	_, _ = w.Write(cacheResponse.Response)
+}
+
+func (t Middleware) maxCacheableBytes() int64 {
+	if t.cfg.Cacher.MaxCacheableBytes > 0 {
+		return t.cfg.Cacher.MaxCacheableBytes
+	}
+	return defaultMaxCacheableBytes
+}
+
+// expiryFor returns the cache lifetime configured for the matched route, falling back to key_expiry
+func (t Middleware) expiryFor(r *http.Request) time.Duration {
+	if expiry, ok := t.cfg.Cacher.RouteExpiryDuration[routeTemplate(r)]; ok {
+		return expiry
+	}
+	return t.cfg.Cacher.KeyExpiryDuration
+}
+
+// bypass reports the no-cache/no-store request directives, honoured only for callers presenting the bypass key
+func (t Middleware) bypass(r *http.Request) (noCache bool, noStore bool) {
+	directives := httpcache.CacheControl(r.Header.Get("Cache-Control"))
+	_, noCache = directives["no-cache"]
+	_, noStore = directives["no-store"]
+	if !noCache && !noStore {
+		return false, false
+	}
+	if !t.authorizedBypass(r) {
+		return false, false
+	}
+	return noCache, noStore
+}
+
+func (t Middleware) authorizedBypass(r *http.Request) bool {
+	key := t.cfg.Cacher.BypassKey
+	given := r.Header.Get("X-Cache-Bypass-Key")
+	return key != "" && subtle.ConstantTimeCompare([]byte(key), []byte(given)) == 1
+}
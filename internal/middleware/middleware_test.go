@@ -3,10 +3,17 @@ package middleware
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/compress"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/cacher"
 	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
 	"io/ioutil"
 	"net/http"
@@ -29,12 +36,52 @@ func test(hit *bool) http.HandlerFunc {
 	}
 }
 
+// cacheResponseMatcher matches an encoded model.CacheResponse ignoring the time it was stored at and its compression
+type cacheResponseMatcher struct {
+	want model.CacheResponse
+}
+
+func (m cacheResponseMatcher) Matches(x interface{}) bool {
+	b, ok := x.([]byte)
+	if !ok {
+		return false
+	}
+	var got model.CacheResponse
+	if err := got.UnmarshalBinary(b); err != nil || got.StoredAt == 0 {
+		return false
+	}
+	body, err := compress.Decode(got.Encoding, got.Response)
+	if err != nil {
+		return false
+	}
+	got.StoredAt, got.Response, got.Encoding = 0, body, ""
+	return reflect.DeepEqual(got, m.want)
+}
+
+func (m cacheResponseMatcher) String() string {
+	return fmt.Sprintf("is cache response %v", m.want)
+}
+
+func streamed(hit *bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*hit = true
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Language", "en")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("chunk1,"))
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte("chunk2"))
+	}
+}
+
 func TestMiddleware_Cacher(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	tests := []struct {
 		name      string
 		config    config.Config
+		route     string
+		handler   func(*bool) http.HandlerFunc
 		setupFunc func() (*http.Request, *mock.MockCacherI)
 		validator func(*httptest.ResponseRecorder, *bool)
 	}{
@@ -44,9 +91,9 @@ func TestMiddleware_Cacher(t *testing.T) {
 			setupFunc: func() (*http.Request, *mock.MockCacherI) {
 				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
 				mockCacher := mock.NewMockCacherI(mockCtrl)
-				cacheResponse := model.CacheResponse{Status: http.StatusOK, Response: "ok", ContentType: "application/json"}
-				b, _ := json.Marshal(cacheResponse)
-				mockCacher.EXPECT().Get("http://localhost:80").Return(b, nil)
+				cacheResponse := model.CacheResponse{Status: http.StatusOK, Response: []byte("ok"), ContentType: "application/json"}
+				b, _ := cacheResponse.MarshalBinary()
+				mockCacher.EXPECT().Get("/").Return(b, nil)
 				return req, mockCacher
 			},
 			validator: func(res *httptest.ResponseRecorder, hit *bool) {
@@ -66,6 +113,34 @@ func TestMiddleware_Cacher(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "SUCCESS::Cacher::Cached Response::not modified",
+			config: config.Config{},
+			setupFunc: func() (*http.Request, *mock.MockCacherI) {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
+				req.Header.Set("If-None-Match", `"abc"`)
+				mockCacher := mock.NewMockCacherI(mockCtrl)
+				cacheResponse := model.CacheResponse{Status: http.StatusOK, Response: []byte("ok"), ContentType: "application/json", ETag: `"abc"`}
+				b, _ := cacheResponse.MarshalBinary()
+				mockCacher.EXPECT().Get("/").Return(b, nil)
+				return req, mockCacher
+			},
+			validator: func(res *httptest.ResponseRecorder, hit *bool) {
+				if *hit != false {
+					t.Errorf("Want: %v, Got: %v", false, hit)
+					return
+				}
+				if !reflect.DeepEqual(http.StatusNotModified, res.Code) {
+					t.Errorf("Want: %v, Got: %v", http.StatusNotModified, res.Code)
+				}
+				if res.Body.Len() != 0 {
+					t.Errorf("Want: %v, Got: %v", "", res.Body.String())
+				}
+				if !reflect.DeepEqual(`"abc"`, res.Header().Get("ETag")) {
+					t.Errorf("Want: %v, Got: %v", `"abc"`, res.Header().Get("ETag"))
+				}
+			},
+		},
 		{
 			name:   "Failure::Cacher::Cached Response::unmarshal error",
 			config: config.Config{},
@@ -73,7 +148,7 @@ func TestMiddleware_Cacher(t *testing.T) {
 
 				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
 				mockCacher := mock.NewMockCacherI(mockCtrl)
-				mockCacher.EXPECT().Get("http://localhost:80").Return([]byte("123"), nil)
+				mockCacher.EXPECT().Get("/").Return([]byte("123"), nil)
 				return req, mockCacher
 			},
 			validator: func(res *httptest.ResponseRecorder, hit *bool) {
@@ -104,8 +179,9 @@ func TestMiddleware_Cacher(t *testing.T) {
 			setupFunc: func() (*http.Request, *mock.MockCacherI) {
 				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
 				mockCacher := mock.NewMockCacherI(mockCtrl)
-				mockCacher.EXPECT().Get("http://localhost:80").Return(nil, errors.New("error"))
-				mockCacher.EXPECT().Set("http://localhost:80", []byte("{\"Status\":200,\"Response\":\"{\\\"status\\\":200,\\\"message\\\":\\\"passed\\\",\\\"data\\\":null}\\n\",\"ContentType\":\"application/json\"}"), time.Minute)
+				mockCacher.EXPECT().Get("/").Return(nil, errors.New("error"))
+				mockCacher.EXPECT().Set("/", cacheResponseMatcher{model.CacheResponse{Status: 200, Response: []byte("{\"status\":200,\"message\":\"passed\",\"data\":null}\n"), ContentType: "application/json", MaxAge: 60}}, time.Minute)
+				mockCacher.EXPECT().Tag("tag:/", "/", time.Minute)
 				return req, mockCacher
 			},
 			validator: func(res *httptest.ResponseRecorder, hit *bool) {
@@ -132,8 +208,8 @@ func TestMiddleware_Cacher(t *testing.T) {
 
 				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
 				mockCacher := mock.NewMockCacherI(mockCtrl)
-				mockCacher.EXPECT().Get("http://localhost:80").Return(nil, errors.New("error"))
-				mockCacher.EXPECT().Set("http://localhost:80", []byte("{\"Status\":200,\"Response\":\"{\\\"status\\\":200,\\\"message\\\":\\\"passed\\\",\\\"data\\\":null}\\n\",\"ContentType\":\"application/json\"}"), time.Minute).Return(errors.New("error"))
+				mockCacher.EXPECT().Get("/").Return(nil, errors.New("error"))
+				mockCacher.EXPECT().Set("/", cacheResponseMatcher{model.CacheResponse{Status: 200, Response: []byte("{\"status\":200,\"message\":\"passed\",\"data\":null}\n"), ContentType: "application/json", MaxAge: 60}}, time.Minute).Return(errors.New("error"))
 				return req, mockCacher
 			},
 			validator: func(res *httptest.ResponseRecorder, hit *bool) {
@@ -150,6 +226,236 @@ func TestMiddleware_Cacher(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "SUCCESS::Cacher::Cached Response::age and freshness headers",
+			config: config.Config{},
+			setupFunc: func() (*http.Request, *mock.MockCacherI) {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
+				mockCacher := mock.NewMockCacherI(mockCtrl)
+				cacheResponse := model.CacheResponse{Status: http.StatusOK, Response: []byte("ok"), ContentType: "application/json", StoredAt: time.Now().Unix() - 4, MaxAge: 10}
+				b, _ := cacheResponse.MarshalBinary()
+				mockCacher.EXPECT().Get("/").Return(b, nil)
+				return req, mockCacher
+			},
+			validator: func(res *httptest.ResponseRecorder, hit *bool) {
+				if !reflect.DeepEqual("HIT", res.Header().Get("X-Cache")) {
+					t.Errorf("Want: %v, Got: %v", "HIT", res.Header().Get("X-Cache"))
+				}
+				if !reflect.DeepEqual("4", res.Header().Get("Age")) {
+					t.Errorf("Want: %v, Got: %v", "4", res.Header().Get("Age"))
+				}
+				if !reflect.DeepEqual("public, max-age=6", res.Header().Get("Cache-Control")) {
+					t.Errorf("Want: %v, Got: %v", "public, max-age=6", res.Header().Get("Cache-Control"))
+				}
+			},
+		},
+		{
+			name:   "SUCCESS::Cacher::no-cache from authorized caller refreshes the entry",
+			config: config.Config{Cacher: config.CacheConfig{KeyExpiryDuration: time.Minute, BypassKey: "secret"}},
+			setupFunc: func() (*http.Request, *mock.MockCacherI) {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
+				req.Header.Set("Cache-Control", "no-cache")
+				req.Header.Set("X-Cache-Bypass-Key", "secret")
+				mockCacher := mock.NewMockCacherI(mockCtrl)
+				mockCacher.EXPECT().Set("/", gomock.Any(), time.Minute)
+				mockCacher.EXPECT().Tag("tag:/", "/", time.Minute)
+				return req, mockCacher
+			},
+			validator: func(res *httptest.ResponseRecorder, hit *bool) {
+				if *hit != true {
+					t.Errorf("Want: %v, Got: %v", true, *hit)
+				}
+				if !reflect.DeepEqual("BYPASS", res.Header().Get("X-Cache")) {
+					t.Errorf("Want: %v, Got: %v", "BYPASS", res.Header().Get("X-Cache"))
+				}
+			},
+		},
+		{
+			name:   "SUCCESS::Cacher::no-store from authorized caller skips the cache",
+			config: config.Config{Cacher: config.CacheConfig{KeyExpiryDuration: time.Minute, BypassKey: "secret"}},
+			setupFunc: func() (*http.Request, *mock.MockCacherI) {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
+				req.Header.Set("Cache-Control", "no-store")
+				req.Header.Set("X-Cache-Bypass-Key", "secret")
+				return req, mock.NewMockCacherI(mockCtrl)
+			},
+			validator: func(res *httptest.ResponseRecorder, hit *bool) {
+				if *hit != true {
+					t.Errorf("Want: %v, Got: %v", true, *hit)
+				}
+			},
+		},
+		{
+			name:   "SUCCESS::Cacher::no-cache from unauthorized caller ignored",
+			config: config.Config{Cacher: config.CacheConfig{KeyExpiryDuration: time.Minute, BypassKey: "secret"}},
+			setupFunc: func() (*http.Request, *mock.MockCacherI) {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
+				req.Header.Set("Cache-Control", "no-cache")
+				req.Header.Set("X-Cache-Bypass-Key", "guess")
+				mockCacher := mock.NewMockCacherI(mockCtrl)
+				cacheResponse := model.CacheResponse{Status: http.StatusOK, Response: []byte("ok"), ContentType: "application/json"}
+				b, _ := cacheResponse.MarshalBinary()
+				mockCacher.EXPECT().Get("/").Return(b, nil)
+				return req, mockCacher
+			},
+			validator: func(res *httptest.ResponseRecorder, hit *bool) {
+				if *hit != false {
+					t.Errorf("Want: %v, Got: %v", false, *hit)
+				}
+			},
+		},
+		{
+			name:   "SUCCESS::Cacher::per route expiry",
+			config: config.Config{Cacher: config.CacheConfig{KeyExpiryDuration: time.Minute, RouteExpiryDuration: map[string]time.Duration{"/articles/{id}": time.Hour}}},
+			route:  "/articles/{id}",
+			setupFunc: func() (*http.Request, *mock.MockCacherI) {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost:80/articles/1", nil)
+				mockCacher := mock.NewMockCacherI(mockCtrl)
+				mockCacher.EXPECT().Get("/articles/1").Return(nil, errors.New("error"))
+				mockCacher.EXPECT().Set("/articles/1", gomock.Any(), time.Hour)
+				mockCacher.EXPECT().Tag("tag:/articles/1", "/articles/1", time.Hour)
+				mockCacher.EXPECT().Tag("tag:/articles/{id}", "/articles/1", time.Hour)
+				return req, mockCacher
+			},
+			validator: func(res *httptest.ResponseRecorder, hit *bool) {
+				if !reflect.DeepEqual("MISS", res.Header().Get("X-Cache")) {
+					t.Errorf("Want: %v, Got: %v", "MISS", res.Header().Get("X-Cache"))
+				}
+				if !reflect.DeepEqual("public, max-age=3600", res.Header().Get("Cache-Control")) {
+					t.Errorf("Want: %v, Got: %v", "public, max-age=3600", res.Header().Get("Cache-Control"))
+				}
+			},
+		},
+		{
+			name:   "SUCCESS::Cacher::Cached Response::status and headers replayed",
+			config: config.Config{},
+			setupFunc: func() (*http.Request, *mock.MockCacherI) {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
+				mockCacher := mock.NewMockCacherI(mockCtrl)
+				cacheResponse := model.CacheResponse{Status: http.StatusNonAuthoritativeInfo, Response: []byte("ok"), ContentType: "text/plain", Headers: map[string][]string{"Content-Language": {"en"}}}
+				b, _ := cacheResponse.MarshalBinary()
+				mockCacher.EXPECT().Get("/").Return(b, nil)
+				return req, mockCacher
+			},
+			validator: func(res *httptest.ResponseRecorder, hit *bool) {
+				if !reflect.DeepEqual(http.StatusNonAuthoritativeInfo, res.Code) {
+					t.Errorf("Want: %v, Got: %v", http.StatusNonAuthoritativeInfo, res.Code)
+				}
+				if !reflect.DeepEqual("en", res.Header().Get("Content-Language")) {
+					t.Errorf("Want: %v, Got: %v", "en", res.Header().Get("Content-Language"))
+				}
+				if !reflect.DeepEqual("ok", res.Body.String()) {
+					t.Errorf("Want: %v, Got: %v", "ok", res.Body.String())
+				}
+			},
+		},
+		{
+			name:    "SUCCESS::Cacher::Normal Response::multiple writes captured",
+			config:  config.Config{Cacher: config.CacheConfig{KeyExpiryDuration: time.Minute}},
+			handler: streamed,
+			setupFunc: func() (*http.Request, *mock.MockCacherI) {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
+				mockCacher := mock.NewMockCacherI(mockCtrl)
+				mockCacher.EXPECT().Get("/").Return(nil, errors.New("error"))
+				mockCacher.EXPECT().Set("/", cacheResponseMatcher{model.CacheResponse{Status: 200, Response: []byte("chunk1,chunk2"), ContentType: "text/plain", MaxAge: 60, Headers: map[string][]string{"Content-Language": {"en"}}}}, time.Minute)
+				mockCacher.EXPECT().Tag("tag:/", "/", time.Minute)
+				return req, mockCacher
+			},
+			validator: func(res *httptest.ResponseRecorder, hit *bool) {
+				if !reflect.DeepEqual("chunk1,chunk2", res.Body.String()) {
+					t.Errorf("Want: %v, Got: %v", "chunk1,chunk2", res.Body.String())
+				}
+			},
+		},
+		{
+			name:    "SUCCESS::Cacher::Normal Response::too large to cache",
+			config:  config.Config{Cacher: config.CacheConfig{KeyExpiryDuration: time.Minute, MaxCacheableBytes: 8}},
+			handler: streamed,
+			setupFunc: func() (*http.Request, *mock.MockCacherI) {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
+				mockCacher := mock.NewMockCacherI(mockCtrl)
+				mockCacher.EXPECT().Get("/").Return(nil, errors.New("error"))
+				return req, mockCacher
+			},
+			validator: func(res *httptest.ResponseRecorder, hit *bool) {
+				if !reflect.DeepEqual("chunk1,chunk2", res.Body.String()) {
+					t.Errorf("Want: %v, Got: %v", "chunk1,chunk2", res.Body.String())
+				}
+			},
+		},
+		{
+			name:   "SUCCESS::Cacher::Cached Response::compressed entry served as is",
+			config: config.Config{},
+			setupFunc: func() (*http.Request, *mock.MockCacherI) {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
+				req.Header.Set("Accept-Encoding", "gzip, br")
+				mockCacher := mock.NewMockCacherI(mockCtrl)
+				body, _ := compress.Encode(compress.Gzip, []byte("ok"))
+				cacheResponse := model.CacheResponse{Status: http.StatusOK, Response: body, ContentType: "application/json", Encoding: compress.Gzip, ETag: `"abc"`}
+				b, _ := cacheResponse.MarshalBinary()
+				mockCacher.EXPECT().Get("/").Return(b, nil)
+				return req, mockCacher
+			},
+			validator: func(res *httptest.ResponseRecorder, hit *bool) {
+				if !reflect.DeepEqual("gzip", res.Header().Get("Content-Encoding")) {
+					t.Errorf("Want: %v, Got: %v", "gzip", res.Header().Get("Content-Encoding"))
+				}
+				if !reflect.DeepEqual(`"abc-gzip"`, res.Header().Get("ETag")) {
+					t.Errorf("Want: %v, Got: %v", `"abc-gzip"`, res.Header().Get("ETag"))
+				}
+				body, err := compress.Decode(compress.Gzip, res.Body.Bytes())
+				if err != nil || !reflect.DeepEqual("ok", string(body)) {
+					t.Errorf("Want: %v, Got: %v", "ok", string(body))
+				}
+			},
+		},
+		{
+			name:   "SUCCESS::Cacher::Cached Response::compressed entry decoded for identity clients",
+			config: config.Config{},
+			setupFunc: func() (*http.Request, *mock.MockCacherI) {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
+				mockCacher := mock.NewMockCacherI(mockCtrl)
+				body, _ := compress.Encode(compress.Gzip, []byte("ok"))
+				cacheResponse := model.CacheResponse{Status: http.StatusOK, Response: body, ContentType: "application/json", Encoding: compress.Gzip}
+				b, _ := cacheResponse.MarshalBinary()
+				mockCacher.EXPECT().Get("/").Return(b, nil)
+				return req, mockCacher
+			},
+			validator: func(res *httptest.ResponseRecorder, hit *bool) {
+				if !reflect.DeepEqual("", res.Header().Get("Content-Encoding")) {
+					t.Errorf("Want: %v, Got: %v", "", res.Header().Get("Content-Encoding"))
+				}
+				if !reflect.DeepEqual("ok", res.Body.String()) {
+					t.Errorf("Want: %v, Got: %v", "ok", res.Body.String())
+				}
+			},
+		},
+		{
+			name:   "SUCCESS::Cacher::private response not cached",
+			config: config.Config{},
+			handler: func(hit *bool) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					*hit = true
+					w.Header().Set("Cache-Control", "private, no-cache")
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte("draft"))
+				}
+			},
+			setupFunc: func() (*http.Request, *mock.MockCacherI) {
+				req := httptest.NewRequest(http.MethodGet, "http://localhost:80", nil)
+				mockCacher := mock.NewMockCacherI(mockCtrl)
+				mockCacher.EXPECT().Get("/").Return(nil, errors.New("redis: nil"))
+				return req, mockCacher
+			},
+			validator: func(res *httptest.ResponseRecorder, hit *bool) {
+				if !*hit {
+					t.Errorf("Want: %v, Got: %v", true, *hit)
+				}
+				if !reflect.DeepEqual("private, no-cache", res.Header().Get("Cache-Control")) {
+					t.Errorf("Want: %v, Got: %v", "private, no-cache", res.Header().Get("Cache-Control"))
+				}
+			},
+		},
 	}
 
 	// to execute the tests in the table
@@ -164,11 +470,66 @@ func TestMiddleware_Cacher(t *testing.T) {
 				cfg:    cfg.Cfg}
 			var hit bool
 			testFunc := test(&hit)
-			x := middleware.Cacher(testFunc)
-			x.ServeHTTP(res, req)
+			if tt.handler != nil {
+				testFunc = tt.handler(&hit)
+			}
+			if tt.route != "" {
+				r := mux.NewRouter()
+				r.Handle(tt.route, testFunc)
+				r.Use(middleware.Cacher)
+				r.ServeHTTP(res, req)
+			} else {
+				x := middleware.Cacher(testFunc)
+				x.ServeHTTP(res, req)
+			}
 
 			tt.validator(res, &hit)
 
 		})
 	}
 }
+
+func TestMiddleware_Cacher_UnpublishedListing(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	middleware := Middleware{
+		cacher: cacher.NewCacher(config.CacheSvc{Rdb: redis.NewClient(&redis.Options{Addr: mr.Addr()})}),
+		cfg:    &config.Config{Cacher: config.CacheConfig{KeyExpiryDuration: time.Minute}},
+	}
+	// the drafts of the caller, the handler leaves an empty listing public like any other
+	drafts := map[string]string{"user-a": "[]", "user-b": `[{"id":"2"}]`}
+	handler := middleware.Cacher(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := auth.IdentityFrom(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(drafts[identity.Subject]))
+	}))
+	for _, tt := range []struct {
+		subject    string
+		wantStatus int
+		wantBody   string
+	}{
+		{subject: "user-a", wantStatus: http.StatusOK, wantBody: "[]"},
+		{subject: "user-b", wantStatus: http.StatusOK, wantBody: `[{"id":"2"}]`},
+		{wantStatus: http.StatusUnauthorized},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/articles?status=draft", nil)
+		if tt.subject != "" {
+			req = req.WithContext(auth.WithIdentity(req.Context(), &model.Identity{Subject: tt.subject}))
+		}
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+		if res.Code != tt.wantStatus || res.Body.String() != tt.wantBody {
+			t.Errorf("Want: %v %v, Got: %v %v", tt.wantStatus, tt.wantBody, res.Code, res.Body.String())
+		}
+		if got := res.Header().Get("X-Cache"); got != "BYPASS" {
+			t.Errorf("Want: %v, Got: %v", "BYPASS", got)
+		}
+	}
+}
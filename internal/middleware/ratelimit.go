@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/codes"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/ratelimit"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultRateLimitPrefix = "article-management-sys:ratelimit"
+
+// RateLimit counts every request against a token bucket of its client, answering 429 once the bucket is empty.
+// Clients are identified by their API key or user when authenticated and by their IP address otherwise.
+func (t Middleware) RateLimit(next http.Handler) http.Handler {
+	if !t.cfg.RateLimit.Enabled || t.limiter == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeTemplate(r)
+		class, limit := rateLimitFor(t.cfg.RateLimit, route, r.Method)
+		if t.throttle(w, limit, class, route, clientKey(r, t.cfg.RateLimit.TrustForwardedFor)) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ThrottleAuth counts the requests presenting credentials against a budget of their client address, so that
+// guessing API keys or tokens is throttled before Authenticate looks them up. It must run before Authenticate.
+func (t Middleware) ThrottleAuth(next http.Handler) http.Handler {
+	if !t.cfg.RateLimit.Enabled || t.limiter == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") == "" && r.Header.Get("Authorization") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if t.throttle(w, t.cfg.RateLimit.Auth, "auth", "*", addressKey(r, t.cfg.RateLimit.TrustForwardedFor)) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// throttle counts the request against the bucket of client for route and answers 429 once it is empty,
+// reporting whether it did. Limits without requests or period are not enforced.
+func (t Middleware) throttle(w http.ResponseWriter, limit config.RateLimit, class string, route string, client string) bool {
+	if limit.Requests <= 0 || limit.PeriodDuration <= 0 {
+		return false
+	}
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = limit.Requests
+	}
+	prefix := t.cfg.RateLimit.KeyPrefix
+	if prefix == "" {
+		prefix = defaultRateLimitPrefix
+	}
+	key := strings.Join([]string{prefix, class, route, client}, ":")
+	res, err := t.limiter.Allow(key, ratelimit.Limit{
+		Rate:  float64(limit.Requests) / limit.PeriodDuration.Seconds(),
+		Burst: burst,
+	})
+	if err != nil {
+		// fail open, an unavailable limiter must not take the API down
+		log.Print(err)
+		return false
+	}
+	h := w.Header()
+	h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d;burst=%d", limit.Requests, ceilSeconds(limit.PeriodDuration), burst))
+	if !res.Allowed {
+		h.Set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+		h.Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_ = json.NewEncoder(w).Encode(&model.Response{
+			Status:  http.StatusTooManyRequests,
+			Message: codes.GetErr(codes.ErrRateLimited),
+			Data:    nil,
+		})
+		return true
+	}
+	return false
+}
+
+// rateLimitFor returns the budget class of the request method and its limit on route
+func rateLimitFor(cfg config.RateLimitConfig, route string, method string) (string, config.RateLimit) {
+	override := cfg.Routes[route]
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		if override.Read != nil {
+			return "read", *override.Read
+		}
+		return "read", cfg.Read
+	default:
+		if override.Write != nil {
+			return "write", *override.Write
+		}
+		return "write", cfg.Write
+	}
+}
+
+// routeTemplate returns the path template of the matched route, requests matching no route share one bucket
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tmpl, err := route.GetPathTemplate(); err == nil {
+			return tmpl
+		}
+	}
+	return "*"
+}
+
+// clientKey identifies the caller of r
+func clientKey(r *http.Request, trustForwardedFor bool) string {
+	if identity, ok := auth.IdentityFrom(r.Context()); ok && identity.Subject != "" {
+		return "sub:" + identity.Subject
+	}
+	return addressKey(r, trustForwardedFor)
+}
+
+// addressKey identifies the client address of r
+func addressKey(r *http.Request, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			return "ip:" + strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
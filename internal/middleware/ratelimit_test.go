@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/ratelimit"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMiddleware_RateLimit(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		Read:    config.RateLimit{Requests: 60, PeriodDuration: time.Minute, Burst: 10},
+		Write:   config.RateLimit{Requests: 6, PeriodDuration: time.Minute},
+		Routes: map[string]config.RouteRateLimit{
+			"/articles/{id}": {Read: &config.RateLimit{Requests: 120, PeriodDuration: time.Minute}},
+		},
+	}
+	tests := []struct {
+		name       string
+		setupFunc  func(*mock.MockLimiterI) *http.Request
+		wantStatus int
+		wantHeader map[string]string
+	}{
+		{
+			name: "SUCCESS::RateLimit::anonymous read",
+			setupFunc: func(limiter *mock.MockLimiterI) *http.Request {
+				limiter.EXPECT().Allow(defaultRateLimitPrefix+":read:/articles:ip:10.0.0.1", ratelimit.Limit{Rate: 1, Burst: 10}).
+					Return(ratelimit.Result{Allowed: true, Limit: 10, Remaining: 9, Reset: time.Second}, nil)
+				req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+				req.RemoteAddr = "10.0.0.1:1234"
+				return req
+			},
+			wantStatus: http.StatusOK,
+			wantHeader: map[string]string{"RateLimit-Limit": "10", "RateLimit-Remaining": "9", "RateLimit-Reset": "1", "RateLimit-Policy": "60;w=60;burst=10"},
+		},
+		{
+			name: "SUCCESS::RateLimit::route override",
+			setupFunc: func(limiter *mock.MockLimiterI) *http.Request {
+				limiter.EXPECT().Allow(defaultRateLimitPrefix+":read:/articles/{id}:ip:10.0.0.1", ratelimit.Limit{Rate: 2, Burst: 120}).
+					Return(ratelimit.Result{Allowed: true, Limit: 120, Remaining: 119}, nil)
+				req := httptest.NewRequest(http.MethodGet, "/articles/1", nil)
+				req.RemoteAddr = "10.0.0.1:1234"
+				return req
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Failure::RateLimit::authenticated write exhausted",
+			setupFunc: func(limiter *mock.MockLimiterI) *http.Request {
+				limiter.EXPECT().Allow(defaultRateLimitPrefix+":write:/articles:sub:apikey:1", ratelimit.Limit{Rate: 0.1, Burst: 6}).
+					Return(ratelimit.Result{Limit: 6, RetryAfter: 9500 * time.Millisecond, Reset: time.Minute}, nil)
+				req := httptest.NewRequest(http.MethodPost, "/articles", nil)
+				return req.WithContext(auth.WithIdentity(req.Context(), &model.Identity{Subject: "apikey:1"}))
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantHeader: map[string]string{"Retry-After": "10", "RateLimit-Remaining": "0", "RateLimit-Reset": "60"},
+		},
+		{
+			name: "SUCCESS::RateLimit::limiter error fails open",
+			setupFunc: func(limiter *mock.MockLimiterI) *http.Request {
+				limiter.EXPECT().Allow(gomock.Any(), gomock.Any()).Return(ratelimit.Result{}, errors.New("error"))
+				return httptest.NewRequest(http.MethodGet, "/articles", nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := mock.NewMockLimiterI(mockCtrl)
+			req := tt.setupFunc(limiter)
+			middleware := Middleware{cfg: &config.Config{RateLimit: cfg}, limiter: limiter}
+			router := mux.NewRouter()
+			ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+			router.HandleFunc("/articles", ok)
+			router.HandleFunc("/articles/{id}", ok)
+			router.Use(middleware.RateLimit)
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			if !reflect.DeepEqual(tt.wantStatus, res.Code) {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, res.Code)
+			}
+			for k, v := range tt.wantHeader {
+				if got := res.Header().Get(k); got != v {
+					t.Errorf("%s Want: %v, Got: %v", k, v, got)
+				}
+			}
+		})
+	}
+}
+
+func TestMiddleware_ThrottleAuth(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		Auth:    config.RateLimit{Requests: 60, PeriodDuration: time.Minute, Burst: 5},
+	}
+	tests := []struct {
+		name       string
+		setupFunc  func(*mock.MockLimiterI, *mock.MockApiKeyVerifierI) *http.Request
+		wantStatus int
+	}{
+		{
+			name: "SUCCESS::ThrottleAuth::anonymous request not counted",
+			setupFunc: func(*mock.MockLimiterI, *mock.MockApiKeyVerifierI) *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/articles", nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Failure::ThrottleAuth::invalid key counted",
+			setupFunc: func(limiter *mock.MockLimiterI, apiKeys *mock.MockApiKeyVerifierI) *http.Request {
+				limiter.EXPECT().Allow(defaultRateLimitPrefix+":auth:*:ip:10.0.0.1", ratelimit.Limit{Rate: 1, Burst: 5}).
+					Return(ratelimit.Result{Allowed: true, Limit: 5, Remaining: 4}, nil)
+				apiKeys.EXPECT().Verify("guess").Return(nil, auth.ErrInvalidApiKey)
+				req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+				req.RemoteAddr = "10.0.0.1:1234"
+				req.Header.Set("X-API-Key", "guess")
+				return req
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "Failure::ThrottleAuth::exhausted before the key is looked up",
+			setupFunc: func(limiter *mock.MockLimiterI, apiKeys *mock.MockApiKeyVerifierI) *http.Request {
+				limiter.EXPECT().Allow(defaultRateLimitPrefix+":auth:*:ip:10.0.0.1", ratelimit.Limit{Rate: 1, Burst: 5}).
+					Return(ratelimit.Result{Limit: 5, RetryAfter: time.Second}, nil)
+				req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+				req.RemoteAddr = "10.0.0.1:1234"
+				req.Header.Set("X-API-Key", "guess")
+				return req
+			},
+			wantStatus: http.StatusTooManyRequests,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := mock.NewMockLimiterI(mockCtrl)
+			apiKeys := mock.NewMockApiKeyVerifierI(mockCtrl)
+			req := tt.setupFunc(limiter, apiKeys)
+			middleware := Middleware{cfg: &config.Config{RateLimit: cfg}, limiter: limiter, apiKeys: apiKeys}
+			ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+			res := httptest.NewRecorder()
+			middleware.ThrottleAuth(middleware.Authenticate(ok)).ServeHTTP(res, req)
+			if !reflect.DeepEqual(tt.wantStatus, res.Code) {
+				t.Errorf("Want: %v, Got: %v", tt.wantStatus, res.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/httpcache"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/views"
+	"log"
+	"net/http"
+	"time"
+)
+
+// viewedRoute is the route whose successful responses count as a view of the article {id}
+const viewedRoute = "/articles/{id}"
+
+// CountViews returns a middleware counting a view of the article for every successful GET of /articles/{id}.
+// It must run before Cacher so that responses served from the cache are counted too. Private responses, an author
+// looking at their own draft, are not views. Clients are identified as for rate limiting.
+func (t Middleware) CountViews(counter views.CounterI) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || routeTemplate(r) != viewedRoute {
+				next.ServeHTTP(w, r)
+				return
+			}
+			sw := &statusWriter{ResponseWriter: w}
+			next.ServeHTTP(sw, r)
+			if sw.status != http.StatusOK && sw.status != http.StatusNotModified {
+				return
+			}
+			if _, private := httpcache.CacheControl(w.Header().Get("Cache-Control"))["private"]; private {
+				return
+			}
+			if _, err := counter.Record(mux.Vars(r)["id"], clientKey(r, t.cfg.RateLimit.TrustForwardedFor), time.Now()); err != nil {
+				// a view that cannot be counted must not fail the read
+				log.Print(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware_CountViews(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name         string
+		target       string
+		status       int
+		cacheControl string
+		setup        func(*mock.MockCounterI)
+	}{
+		{
+			name:   "Success:: article read counts a view",
+			target: "/articles/1",
+			status: http.StatusOK,
+			setup: func(c *mock.MockCounterI) {
+				c.EXPECT().Record("1", "ip:192.0.2.1", gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name:   "Success:: revalidated read counts a view",
+			target: "/articles/1",
+			status: http.StatusNotModified,
+			setup: func(c *mock.MockCounterI) {
+				c.EXPECT().Record("1", "ip:192.0.2.1", gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name:   "Success:: counter failure does not fail the read",
+			target: "/articles/1",
+			status: http.StatusOK,
+			setup: func(c *mock.MockCounterI) {
+				c.EXPECT().Record("1", "ip:192.0.2.1", gomock.Any()).Return(false, errors.New("error"))
+			},
+		},
+		{
+			name:         "Success:: private draft is not a view",
+			target:       "/articles/1",
+			status:       http.StatusOK,
+			cacheControl: "private, no-cache",
+			setup:        func(c *mock.MockCounterI) {},
+		},
+		{
+			name:   "Success:: missing article is not a view",
+			target: "/articles/1",
+			status: http.StatusBadRequest,
+			setup:  func(c *mock.MockCounterI) {},
+		},
+		{
+			name:   "Success:: listing is not a view",
+			target: "/articles",
+			status: http.StatusOK,
+			setup:  func(c *mock.MockCounterI) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCounter := mock.NewMockCounterI(mockCtrl)
+			tt.setup(mockCounter)
+			mid := Middleware{cfg: &config.Config{}}
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.cacheControl != "" {
+					w.Header().Set("Cache-Control", tt.cacheControl)
+				}
+				w.WriteHeader(tt.status)
+			})
+			router := mux.NewRouter()
+			router.Handle("/articles", handler)
+			router.Handle("/articles/{id}", handler)
+			router.Use(mid.CountViews(mockCounter))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if rec.Code != tt.status {
+				t.Errorf("Want: %v, Got: %v", tt.status, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// ApiKeyDs is an API key as stored in the datasource, only the sha256 hash of the key is kept
+type ApiKeyDs struct {
+	Id         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+type ApiKeyRequest struct {
+	Name   string   `json:"name" validate:"required"`
+	Scopes []string `json:"scopes"`
+	// ExpiresIn is a duration such as 720h, keys never expire when empty
+	ExpiresIn string `json:"expires_in"`
+}
+
+type ApiKeyRotateRequest struct {
+	// ExpiresIn is the lifetime of the new key, which otherwise expires when the rotated key would have
+	ExpiresIn string `json:"expires_in"`
+}
+
+// ApiKeyCreated is returned once, when a key is created or rotated, and is the only time the key is visible
+type ApiKeyCreated struct {
+	ApiKeyDs
+	Key string `json:"key"`
+}
+
+const ApiKeySchema = `
+	(
+		id VARCHAR(255) NOT NULL PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		prefix VARCHAR(32) NOT NULL,
+		key_hash CHAR(64) NOT NULL UNIQUE,
+		scopes VARCHAR(1024) NOT NULL DEFAULT '',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		last_used_at TIMESTAMP NULL,
+		expires_at TIMESTAMP NULL,
+		revoked_at TIMESTAMP NULL
+	);
+`
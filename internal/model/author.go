@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// AuthorDs is an author profile. Authors writing through the API are keyed by the subject of their token,
+// the display name of their articles is always taken from here.
+type AuthorDs struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	Bio       string    `json:"bio"`
+	AvatarUrl string    `json:"avatar_url"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type AuthorRequest struct {
+	Name      string `json:"name" validate:"required,max=255"`
+	Bio       string `json:"bio" validate:"max=5000"`
+	AvatarUrl string `json:"avatar_url" validate:"omitempty,url,max=1024"`
+}
+
+const AuthorSchema = `
+	(
+		id VARCHAR(255) NOT NULL PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		bio TEXT NOT NULL,
+		avatar_url VARCHAR(1024) NOT NULL DEFAULT '',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		INDEX (name)
+	);
+`
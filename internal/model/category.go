@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// FilterCategories is the article filter key holding the ids of the categories to list the articles of
+const FilterCategories = "categories"
+
+// CategoryDs is a node of the category tree, root categories have no parent
+type CategoryDs struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	ParentId  *string   `json:"parent_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CategoryRequest struct {
+	Name     string  `json:"name" validate:"required,max=255"`
+	ParentId *string `json:"parent_id"`
+}
+
+// AssignCategoryRequest sets the primary category of an article, null removes it
+type AssignCategoryRequest struct {
+	CategoryId *string `json:"category_id"`
+}
+
+const CategorySchema = `
+	(
+		id VARCHAR(255) NOT NULL PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		parent_id VARCHAR(255) NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		INDEX (parent_id)
+	);
+`
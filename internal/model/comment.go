@@ -0,0 +1,66 @@
+package model
+
+import "time"
+
+// Comment statuses, new comments wait in the moderation queue and only approved ones are shown
+const (
+	CommentPending  = "pending"
+	CommentApproved = "approved"
+	CommentRejected = "rejected"
+)
+
+// ValidCommentStatus reports whether status is one of the comment statuses
+func ValidCommentStatus(status string) bool {
+	switch status {
+	case CommentPending, CommentApproved, CommentRejected:
+		return true
+	}
+	return false
+}
+
+// Comment filter keys handled by the comment datasource beside plain columns
+const (
+	// FilterTopLevel set to true restricts the comments to the ones that are not replies
+	FilterTopLevel = "top_level"
+	// FilterParents holds the ids of the comments to list the replies of
+	FilterParents = "parents"
+)
+
+// CommentDs is a comment on an article. Replies answer a top-level comment, replies to replies are not allowed.
+type CommentDs struct {
+	Id        string      `json:"id"`
+	ArticleId string      `json:"article_id"`
+	ParentId  *string     `json:"parent_id"`
+	AuthorId  string      `json:"author_id"`
+	Author    string      `json:"author"`
+	Body      string      `json:"body"`
+	Status    string      `json:"status"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	Replies   []CommentDs `json:"replies,omitempty"`
+}
+
+type CommentRequest struct {
+	Body     string  `json:"body" validate:"required,max=5000"`
+	ParentId *string `json:"parent_id"`
+	// Author and AuthorId are taken from the authenticated caller, never from the request body
+	Author   string `json:"-"`
+	AuthorId string `json:"-"`
+}
+
+const CommentSchema = `
+	(
+		id VARCHAR(255) NOT NULL PRIMARY KEY,
+		article_id VARCHAR(255) NOT NULL,
+		parent_id VARCHAR(255) NULL,
+		author_id VARCHAR(255) NOT NULL,
+		author VARCHAR(255) NOT NULL,
+		body TEXT NOT NULL,
+		status VARCHAR(32) NOT NULL DEFAULT 'pending',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		INDEX (article_id, status),
+		INDEX (parent_id),
+		INDEX (status)
+	);
+`
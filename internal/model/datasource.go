@@ -1,10 +1,71 @@
 package model
 
+import "time"
+
+// Article statuses, only published articles are visible to the public.
+// Approved articles passed review and wait for their publish_at.
+const (
+	StatusDraft     = "draft"
+	StatusInReview  = "in_review"
+	StatusApproved  = "approved"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+// ValidStatus reports whether status is one of the article statuses
+func ValidStatus(status string) bool {
+	switch status {
+	case StatusDraft, StatusInReview, StatusApproved, StatusPublished, StatusArchived:
+		return true
+	}
+	return false
+}
+
 type ArticleDs struct {
-	Id      string `json:"id"`
-	Title   string `json:"title"`
-	Author  string `json:"author"`
-	Content string `json:"content"`
+	Id       string `json:"id"`
+	Title    string `json:"title"`
+	Author   string `json:"author"`
+	AuthorId string `json:"author_id"`
+	Content  string `json:"content,omitempty"`
+	// ContentFormat is the format Content is written in: plain, markdown or html
+	ContentFormat string `json:"content_format"`
+	// ContentHtml is Content rendered as sanitised HTML, stored on every write
+	ContentHtml string `json:"content_html,omitempty"`
+	// Locale is the language of Title and Content, the article's own unless a translation was selected
+	Locale string `json:"locale"`
+	// Translations lists the locales the article can be read in, its own first
+	Translations []string `json:"translations"`
+	// Localized holds the translations loaded with the article by locale
+	Localized map[string]TranslationDs `json:"-"`
+	Status    string                   `json:"status"`
+	// Version is incremented on every write and sent as the article's ETag
+	Version int      `json:"version"`
+	Tags    []string `json:"tags"`
+	// CategoryId is the article's primary category
+	CategoryId *string `json:"category_id"`
+	// Slug addresses the article at /articles/by-slug/{slug}, it follows the title
+	Slug string `json:"slug"`
+	// CommentCount is the number of approved comments and replies
+	CommentCount int `json:"comment_count"`
+	// ViewCount is the number of views added to the database by the last flush of the view counters
+	ViewCount int64     `json:"view_count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// PublishAt and UnpublishAt are applied by the scheduler and cleared once applied
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+}
+
+// ArticleEdit is a change of an article's title and content, stored in one transaction with its revision
+type ArticleEdit struct {
+	Fields map[string]interface{}
+	// Version is the version the change was made against, the change applies to any version when 0
+	Version int
+	// FormerSlug is kept to redirect to the article when the change gives it a new slug
+	FormerSlug string
+	// Tags replace the tags of the article unless nil
+	Tags     []string
+	Revision RevisionDs
 }
 
 const Schema = `
@@ -12,6 +73,36 @@ const Schema = `
 		id VARCHAR(255) NOT NULL PRIMARY KEY,
 		title VARCHAR(255) NOT NULL,
 		author VARCHAR(255) NOT NULL,
-		content TEXT NOT NULL
+		author_id VARCHAR(255) NOT NULL DEFAULT '',
+		content TEXT NOT NULL,
+		content_format VARCHAR(16) NOT NULL DEFAULT 'plain',
+		content_html MEDIUMTEXT NULL,
+		locale VARCHAR(35) NOT NULL DEFAULT 'en',
+		status VARCHAR(32) NOT NULL DEFAULT 'draft',
+		version INT NOT NULL DEFAULT 1,
+		category_id VARCHAR(255) NULL,
+		slug VARCHAR(255) NULL UNIQUE,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		publish_at TIMESTAMP NULL,
+		unpublish_at TIMESTAMP NULL
+	);
+`
+
+const LeaseSchema = `
+	(
+		name VARCHAR(255) NOT NULL PRIMARY KEY,
+		holder VARCHAR(255) NOT NULL,
+		expires_at TIMESTAMP(3) NOT NULL
+	);
+`
+
+// SlugSchema keeps the former slugs of articles so that old links keep working
+const SlugSchema = `
+	(
+		slug VARCHAR(255) NOT NULL PRIMARY KEY,
+		article_id VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		INDEX (article_id)
 	);
 `
@@ -0,0 +1,152 @@
+package model
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// envelope layout, all integers are varints and strings/bytes are length prefixed:
+// magic(2) version(1) status storedAt maxAge contentType encoding etag lastModified
+// headerCount {name valueCount {value}} body
+var envelopeMagic = []byte{'A', 'C'}
+
+const envelopeVersion = 1
+
+var ErrEnvelope = errors.New("malformed cache envelope")
+
+// MarshalBinary encodes the cached response into the compact envelope stored in the cache
+func (c CacheResponse) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, len(c.Response)+128))
+	var scratch [binary.MaxVarintLen64]byte
+	putInt := func(v int64) {
+		buf.Write(scratch[:binary.PutVarint(scratch[:], v)])
+	}
+	putBytes := func(b []byte) {
+		putInt(int64(len(b)))
+		buf.Write(b)
+	}
+	buf.Write(envelopeMagic)
+	buf.WriteByte(envelopeVersion)
+	putInt(int64(c.Status))
+	putInt(c.StoredAt)
+	putInt(int64(c.MaxAge))
+	putBytes([]byte(c.ContentType))
+	putBytes([]byte(c.Encoding))
+	putBytes([]byte(c.ETag))
+	putBytes([]byte(c.LastModified))
+	names := make([]string, 0, len(c.Headers))
+	for name := range c.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	putInt(int64(len(names)))
+	for _, name := range names {
+		putBytes([]byte(name))
+		putInt(int64(len(c.Headers[name])))
+		for _, value := range c.Headers[name] {
+			putBytes([]byte(value))
+		}
+	}
+	putBytes(c.Response)
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes an envelope written by MarshalBinary.
+// Entries written by earlier releases as JSON are still understood.
+func (c *CacheResponse) UnmarshalBinary(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		return c.unmarshalLegacy(data)
+	}
+	if len(data) < 3 || !bytes.Equal(data[:2], envelopeMagic) || data[2] != envelopeVersion {
+		return ErrEnvelope
+	}
+	r := bytes.NewReader(data[3:])
+	var err error
+	getInt := func() int64 {
+		if err != nil {
+			return 0
+		}
+		var v int64
+		v, err = binary.ReadVarint(r)
+		return v
+	}
+	getBytes := func() []byte {
+		n := getInt()
+		if err != nil {
+			return nil
+		}
+		if n < 0 || n > int64(r.Len()) {
+			err = ErrEnvelope
+			return nil
+		}
+		if n == 0 {
+			return nil
+		}
+		b := make([]byte, n)
+		_, err = r.Read(b)
+		return b
+	}
+	c.Status = int(getInt())
+	c.StoredAt = getInt()
+	c.MaxAge = int(getInt())
+	c.ContentType = string(getBytes())
+	c.Encoding = string(getBytes())
+	c.ETag = string(getBytes())
+	c.LastModified = string(getBytes())
+	count := getInt()
+	if err == nil && (count < 0 || count > int64(r.Len())) {
+		err = ErrEnvelope
+	}
+	c.Headers = nil
+	for i := int64(0); i < count && err == nil; i++ {
+		name := string(getBytes())
+		values := make([]string, 0)
+		n := getInt()
+		if err == nil && (n < 0 || n > int64(r.Len())) {
+			err = ErrEnvelope
+		}
+		for j := int64(0); j < n && err == nil; j++ {
+			values = append(values, string(getBytes()))
+		}
+		if c.Headers == nil {
+			c.Headers = map[string][]string{}
+		}
+		c.Headers[name] = values
+	}
+	c.Response = getBytes()
+	if err != nil {
+		return ErrEnvelope
+	}
+	return nil
+}
+
+// unmarshalLegacy reads the JSON encoding used before the binary envelope was introduced
+func (c *CacheResponse) unmarshalLegacy(data []byte) error {
+	var legacy struct {
+		Status       int
+		Response     string
+		ContentType  string
+		ETag         string
+		LastModified string
+		StoredAt     int64
+		MaxAge       int
+		Headers      map[string][]string
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+	*c = CacheResponse{
+		Status:       legacy.Status,
+		Response:     []byte(legacy.Response),
+		ContentType:  legacy.ContentType,
+		ETag:         legacy.ETag,
+		LastModified: legacy.LastModified,
+		StoredAt:     legacy.StoredAt,
+		MaxAge:       legacy.MaxAge,
+		Headers:      legacy.Headers,
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCacheResponse_Binary(t *testing.T) {
+	tests := []struct {
+		name    string
+		give    []byte
+		want    CacheResponse
+		wantErr bool
+	}{
+		{
+			name: "Success::round trip",
+			give: func() []byte {
+				b, _ := CacheResponse{
+					Status:       200,
+					Response:     []byte{0x1f, 0x8b, 0x00},
+					ContentType:  "application/json",
+					Encoding:     "gzip",
+					ETag:         `"abc"`,
+					LastModified: "Mon, 02 Jan 2023 00:00:00 GMT",
+					StoredAt:     1672617600,
+					MaxAge:       10,
+					Headers:      map[string][]string{"Link": {"<a>", "<b>"}, "Content-Language": {"en"}},
+				}.MarshalBinary()
+				return b
+			}(),
+			want: CacheResponse{
+				Status:       200,
+				Response:     []byte{0x1f, 0x8b, 0x00},
+				ContentType:  "application/json",
+				Encoding:     "gzip",
+				ETag:         `"abc"`,
+				LastModified: "Mon, 02 Jan 2023 00:00:00 GMT",
+				StoredAt:     1672617600,
+				MaxAge:       10,
+				Headers:      map[string][]string{"Link": {"<a>", "<b>"}, "Content-Language": {"en"}},
+			},
+		},
+		{
+			name: "Success::empty body",
+			give: func() []byte {
+				b, _ := CacheResponse{Status: 204}.MarshalBinary()
+				return b
+			}(),
+			want: CacheResponse{Status: 204},
+		},
+		{
+			name: "Success::legacy json entry",
+			give: []byte(`{"Status":200,"Response":"ok","ContentType":"application/json"}`),
+			want: CacheResponse{Status: 200, Response: []byte("ok"), ContentType: "application/json"},
+		},
+		{
+			name:    "Failure::truncated envelope",
+			give:    []byte{'A', 'C', 1, 0x90},
+			wantErr: true,
+		},
+		{
+			name:    "Failure::unknown payload",
+			give:    []byte("123"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got CacheResponse
+			err := got.UnmarshalBinary(tt.give)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Want: %v, Got: %v", tt.wantErr, err)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
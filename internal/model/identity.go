@@ -0,0 +1,22 @@
+package model
+
+// Identity describes the authenticated caller of a request
+type Identity struct {
+	Subject string   `json:"sub"`
+	Name    string   `json:"name"`
+	Roles   []string `json:"roles"`
+	Scopes  []string `json:"scopes"`
+	Method  string   `json:"method"` // how the caller authenticated e.g. jwt
+}
+
+// HasRole reports whether the caller was granted any of the given roles
+func (i *Identity) HasRole(roles ...string) bool {
+	for _, have := range i.Roles {
+		for _, want := range roles {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
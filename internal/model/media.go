@@ -0,0 +1,71 @@
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// MediaDs is a file attached to an article. Key and ThumbnailKey locate the file and its thumbnail in the blob store,
+// ThumbnailKey is empty when the file has no thumbnail.
+type MediaDs struct {
+	Id           string    `json:"id"`
+	ArticleId    string    `json:"article_id"`
+	Filename     string    `json:"filename"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width,omitempty"`
+	Height       int       `json:"height,omitempty"`
+	Checksum     string    `json:"checksum"`
+	Key          string    `json:"-"`
+	ThumbnailKey string    `json:"-"`
+	Uploader     string    `json:"uploader"`
+	CreatedAt    time.Time `json:"created_at"`
+	Url          string    `json:"url"`
+	ThumbnailUrl string    `json:"thumbnail_url,omitempty"`
+	// Public is set when the article is published, its files may then be kept by shared caches
+	Public bool `json:"-"`
+}
+
+// MediaList is the files of an article, encoded as a plain list. Public is set when the article is published.
+type MediaList struct {
+	Media  []MediaDs
+	Public bool
+}
+
+func (l MediaList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.Media)
+}
+
+// MediaUpload is a file uploaded to be attached to an article
+type MediaUpload struct {
+	Filename string
+	Data     []byte
+	// Uploader is taken from the authenticated caller
+	Uploader string
+}
+
+// MediaFile is an opened stored file, or the thumbnail of one, the caller closes Body
+type MediaFile struct {
+	Media       MediaDs
+	ContentType string
+	Body        io.ReadCloser
+}
+
+const MediaSchema = `
+	(
+		id VARCHAR(255) NOT NULL PRIMARY KEY,
+		article_id VARCHAR(255) NOT NULL,
+		filename VARCHAR(255) NOT NULL,
+		content_type VARCHAR(127) NOT NULL,
+		size BIGINT NOT NULL,
+		width INT NOT NULL DEFAULT 0,
+		height INT NOT NULL DEFAULT 0,
+		checksum CHAR(64) NOT NULL,
+		storage_key VARCHAR(512) NOT NULL,
+		thumbnail_key VARCHAR(512) NOT NULL DEFAULT '',
+		uploader VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		INDEX (article_id, created_at)
+	);
+`
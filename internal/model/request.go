@@ -1,7 +1,28 @@
 package model
 
+import "time"
+
 type Article struct {
 	Title   string `json:"title" validate:"required"`
 	Content string `json:"content" validate:"required"`
-	Author  string `json:"author" validate:"required"`
+	// ContentFormat defaults to plain on create, on update a missing format leaves it unchanged
+	ContentFormat string `json:"content_format" validate:"omitempty,oneof=plain markdown html"`
+	// Locale is the language the article is written in, a BCP 47 tag. It defaults to en on create,
+	// on update a missing locale leaves it unchanged.
+	Locale string `json:"locale"`
+	// Tags are normalised before they are stored, on update a missing list leaves the tags unchanged
+	Tags []string `json:"tags"`
+	// Author and AuthorId are taken from the authenticated caller, never from the request body
+	Author   string `json:"-"`
+	AuthorId string `json:"-"`
+	// Editor is the subject of the caller making the change, recorded with the revision
+	Editor string `json:"-"`
+	// Version is the version the caller last read, taken from If-Match, zero skips the check
+	Version int `json:"-"`
+}
+
+// ScheduleRequest sets or, with null, clears when an article is published and withdrawn
+type ScheduleRequest struct {
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
 }
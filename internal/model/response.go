@@ -7,7 +7,13 @@ type Response struct {
 }
 
 type CacheResponse struct {
-	Status      int    // Status code of the cached response
-	Response    string // Response body of the cached response
-	ContentType string // Content type of the cached response
+	Status       int                 // Status code of the cached response
+	Response     []byte              // Response body of the cached response, compressed with Encoding
+	ContentType  string              // Content type of the cached response
+	Encoding     string              // Content-coding of Response, empty when it is stored uncompressed
+	ETag         string              // Strong entity tag of the uncompressed response body
+	LastModified string              // Last-Modified header of the cached response
+	StoredAt     int64               // Unix time at which the response was cached
+	MaxAge       int                 // Freshness lifetime of the cached response in seconds
+	Headers      map[string][]string // Additional headers replayed with the cached response
 }
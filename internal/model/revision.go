@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// RevisionDs is an immutable snapshot of an article taken after each change, Rev is the article version it was
+// taken at. Workflow actions bump the version without a snapshot, so revision numbers may skip.
+type RevisionDs struct {
+	ArticleId string `json:"article_id"`
+	Rev       int    `json:"rev"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	// ContentFormat is the format Content was written in, restored with it
+	ContentFormat string    `json:"content_format"`
+	Author        string    `json:"author"`
+	Editor        string    `json:"editor"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// RevisionDiff is a unified diff of the title and content of two revisions
+type RevisionDiff struct {
+	From int    `json:"from"`
+	To   int    `json:"to"`
+	Diff string `json:"diff"`
+}
+
+const RevisionSchema = `
+	(
+		article_id VARCHAR(255) NOT NULL,
+		rev INT NOT NULL,
+		title VARCHAR(255) NOT NULL,
+		content TEXT NOT NULL,
+		content_format VARCHAR(16) NOT NULL DEFAULT 'plain',
+		author VARCHAR(255) NOT NULL,
+		editor VARCHAR(255) NOT NULL DEFAULT '',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (article_id, rev)
+	);
+`
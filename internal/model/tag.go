@@ -0,0 +1,25 @@
+package model
+
+// FilterTags is the article filter key holding a TagFilter
+const FilterTags = "tags"
+
+// TagFilter selects the articles carrying any, or with All every, of Tags
+type TagFilter struct {
+	Tags []string
+	All  bool
+}
+
+// TagCount is a tag together with the number of published articles carrying it
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+const TagSchema = `
+	(
+		article_id VARCHAR(255) NOT NULL,
+		tag VARCHAR(64) NOT NULL,
+		PRIMARY KEY (article_id, tag),
+		INDEX (tag)
+	);
+`
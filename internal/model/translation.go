@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// TranslationDs is an article's title and content in another language than its own
+type TranslationDs struct {
+	ArticleId     string    `json:"article_id"`
+	Locale        string    `json:"locale"`
+	Title         string    `json:"title"`
+	Content       string    `json:"content,omitempty"`
+	ContentFormat string    `json:"content_format"`
+	ContentHtml   string    `json:"content_html,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type TranslationRequest struct {
+	Title   string `json:"title" validate:"required,max=255"`
+	Content string `json:"content" validate:"required"`
+	// ContentFormat defaults to the format of the article
+	ContentFormat string `json:"content_format" validate:"omitempty,oneof=plain markdown html"`
+}
+
+const TranslationSchema = `
+	(
+		article_id VARCHAR(255) NOT NULL,
+		locale VARCHAR(35) NOT NULL,
+		title VARCHAR(255) NOT NULL,
+		content TEXT NOT NULL,
+		content_format VARCHAR(16) NOT NULL DEFAULT 'plain',
+		content_html MEDIUMTEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (article_id, locale)
+	);
+`
@@ -0,0 +1,24 @@
+package model
+
+// FilterIds is the article filter key holding the ids of the articles to list
+const FilterIds = "ids"
+
+// PopularArticle is an article together with the number of views it got within the requested window
+type PopularArticle struct {
+	ArticleDs
+	WindowViews int64 `json:"window_views"`
+}
+
+// RelatedArticle is an article together with its similarity, between 0 and 1, to the article it was found for
+type RelatedArticle struct {
+	ArticleDs
+	Score float64 `json:"score"`
+}
+
+// ViewSchema keeps the total views of articles, added to by the view flusher
+const ViewSchema = `
+	(
+		article_id VARCHAR(255) NOT NULL PRIMARY KEY,
+		views BIGINT NOT NULL DEFAULT 0
+	);
+`
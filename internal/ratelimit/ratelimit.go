@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_ratelimit.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/ratelimit LimiterI
+
+// LimiterI takes one token from the bucket stored under key, creating it full when it does not exist
+type LimiterI interface {
+	Allow(key string, limit Limit) (Result, error)
+}
+
+// Limit describes a token bucket refilled at Rate tokens per second and holding at most Burst tokens
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Result is the state of a bucket after a request has been counted against it
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long until the next token is available, zero when the request was allowed
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+}
+
+// result builds the Result of a bucket left holding tokens
+func result(limit Limit, allowed bool, tokens float64) Result {
+	res := Result{
+		Allowed:   allowed,
+		Limit:     limit.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(limit.Burst) - tokens) / limit.Rate),
+	}
+	if !allowed {
+		res.RetryAfter = seconds((1 - tokens) / limit.Rate)
+	}
+	return res
+}
+
+func seconds(s float64) time.Duration {
+	if s <= 0 {
+		return 0
+	}
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"testing"
+	"time"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	tests := []struct {
+		name  string
+		setup func(now *time.Time) LimiterI
+	}{
+		{
+			name: "memory",
+			setup: func(now *time.Time) LimiterI {
+				return &memoryLimiter{buckets: map[string]*bucket{}, now: func() time.Time { return *now }}
+			},
+		},
+		{
+			name: "redis",
+			setup: func(now *time.Time) LimiterI {
+				rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+				return redisLimiter{rdb: rdb, now: func() time.Time { return *now }}
+			},
+		},
+		{
+			name: "redis unavailable falls back to memory",
+			setup: func(now *time.Time) LimiterI {
+				rdb := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+				fallback := &memoryLimiter{buckets: map[string]*bucket{}, now: func() time.Time { return *now }}
+				return redisLimiter{rdb: rdb, fallback: fallback, now: func() time.Time { return *now }}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+			limiter := tt.setup(&now)
+			limit := Limit{Rate: 1, Burst: 2}
+			for i, want := range []int{1, 0} {
+				res, err := limiter.Allow("key", limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !res.Allowed || res.Remaining != want || res.Limit != 2 {
+					t.Errorf("request %d: Want: %v, Got: %+v", i, want, res)
+				}
+			}
+			res, _ := limiter.Allow("key", limit)
+			if res.Allowed || res.RetryAfter != time.Second {
+				t.Errorf("Want: %v, Got: %+v", "denied for 1s", res)
+			}
+			if res, _ := limiter.Allow("other", limit); !res.Allowed {
+				t.Errorf("Want: %v, Got: %+v", "independent bucket", res)
+			}
+			now = now.Add(1500 * time.Millisecond)
+			res, _ = limiter.Allow("key", limit)
+			if !res.Allowed || res.Remaining != 0 || res.Reset != 1500*time.Millisecond {
+				t.Errorf("Want: %v, Got: %+v", "refilled", res)
+			}
+		})
+	}
+}
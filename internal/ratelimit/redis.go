@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"log"
+	"math"
+	"strconv"
+	"time"
+)
+
+// tokenBucket refills and takes a token from the bucket at KEYS[1] atomically.
+// ARGV: refill rate in tokens per millisecond, burst, current time in milliseconds.
+// It returns whether the token was taken and the tokens left, as a string to keep the fraction.
+var tokenBucket = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+if now > ts then
+	tokens = math.min(burst, tokens + (now - ts) * rate)
+	ts = now
+end
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', ts)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+var errUnexpectedReply = errors.New("ratelimit: unexpected reply from token bucket script")
+
+// redisLimiter shares token buckets between instances through redis, falling back to another limiter
+// while redis is unavailable
+type redisLimiter struct {
+	rdb      *redis.Client
+	fallback LimiterI
+	now      func() time.Time
+}
+
+func NewRedisLimiter(rdb *redis.Client, fallback LimiterI) LimiterI {
+	return &redisLimiter{
+		rdb:      rdb,
+		fallback: fallback,
+		now:      time.Now,
+	}
+}
+
+func (l redisLimiter) Allow(key string, limit Limit) (Result, error) {
+	now := l.now().UnixNano() / int64(time.Millisecond)
+	rate := strconv.FormatFloat(limit.Rate/1000, 'g', -1, 64)
+	values, err := tokenBucket.Run(context.Background(), l.rdb, []string{key}, rate, limit.Burst, now).Slice()
+	var tokens float64
+	if err == nil {
+		err = errUnexpectedReply
+		if len(values) == 2 {
+			if left, ok := values[1].(string); ok {
+				tokens, err = strconv.ParseFloat(left, 64)
+			}
+		}
+	}
+	if err != nil {
+		if l.fallback == nil {
+			return Result{}, err
+		}
+		log.Print(err)
+		return l.fallback.Allow(key, limit)
+	}
+	allowed, _ := values[0].(int64)
+	return result(limit, allowed == 1, math.Max(tokens, 0)), nil
+}
@@ -0,0 +1,65 @@
+package related
+
+import (
+	"context"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"log"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_related_refresher.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/related RefresherI
+
+const defaultRefreshInterval = 5 * time.Minute
+
+// RefresherI rebuilds the index from the published articles in the background
+type RefresherI interface {
+	// Run refreshes the index at once and then every interval until ctx is done
+	Run(ctx context.Context)
+	// Refresh rebuilds the index from the published articles, keeping the current one on failure
+	Refresh() error
+}
+
+type refresher struct {
+	index    IndexI
+	ds       datasource.DataSourceI
+	interval time.Duration
+}
+
+func NewRefresherI(index IndexI, ds datasource.DataSourceI, interval time.Duration) RefresherI {
+	if interval <= 0 {
+		interval = defaultRefreshInterval
+	}
+	return &refresher{
+		index:    index,
+		ds:       ds,
+		interval: interval,
+	}
+}
+
+func (r *refresher) Run(ctx context.Context) {
+	if err := r.Refresh(); err != nil {
+		log.Print(err)
+	}
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(); err != nil {
+				log.Print(err)
+			}
+		}
+	}
+}
+
+func (r *refresher) Refresh() error {
+	articles, err := r.ds.Get(map[string]interface{}{"status": model.StatusPublished}, 0, 0)
+	if err != nil {
+		return err
+	}
+	r.index.Build(articles)
+	return nil
+}
@@ -0,0 +1,47 @@
+package related_test
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/related"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"testing"
+)
+
+func TestRefresher_Refresh(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	published := []model.ArticleDs{{Id: "1"}, {Id: "2"}}
+	tests := []struct {
+		name    string
+		setup   func(*mock.MockIndexI, *mock.MockDataSourceI)
+		wantErr bool
+	}{
+		{
+			name: "Success:: index rebuilt from the published articles",
+			setup: func(x *mock.MockIndexI, ds *mock.MockDataSourceI) {
+				ds.EXPECT().Get(map[string]interface{}{"status": model.StatusPublished}, 0, 0).Return(published, nil)
+				x.EXPECT().Build(published)
+			},
+		},
+		{
+			name: "Failure:: current index kept",
+			setup: func(x *mock.MockIndexI, ds *mock.MockDataSourceI) {
+				ds.EXPECT().Get(map[string]interface{}{"status": model.StatusPublished}, 0, 0).Return(nil, errors.New("error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockIndex := mock.NewMockIndexI(mockCtrl)
+			mockDs := mock.NewMockDataSourceI(mockCtrl)
+			tt.setup(mockIndex, mockDs)
+			err := related.NewRefresherI(mockIndex, mockDs, 0).Refresh()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Want: %v, Got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package related
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_related.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/related IndexI
+
+// Weights of the signals making up the similarity of two articles, they add up to 1
+const (
+	TextWeight   = 0.6
+	TagWeight    = 0.3
+	AuthorWeight = 0.1
+)
+
+// titleBoost counts every word of the title that many times, titles say more about an article than its body
+const titleBoost = 2
+
+// stopWords are left out of the text vectors, they appear everywhere and relate nothing
+var stopWords = map[string]struct{}{}
+
+func init() {
+	for _, w := range strings.Fields(`a an and are as at be but by for from has have how i if in into is it its of on or
+		our so that the their then there these this to was we were what when which who why will with you your`) {
+		stopWords[w] = struct{}{}
+	}
+}
+
+// IndexI ranks the indexed articles by their similarity to an article
+type IndexI interface {
+	// Related returns up to limit indexed articles most similar to article, most similar first. The article
+	// itself and articles sharing nothing with it are left out.
+	Related(article model.ArticleDs, limit int) []Match
+	// Build replaces the indexed articles
+	Build(articles []model.ArticleDs)
+}
+
+// Match is an indexed article and its similarity to the article Related was asked about, between 0 and 1
+type Match struct {
+	Id    string
+	Score float64
+}
+
+// document is an indexed article reduced to what its similarity is computed from
+type document struct {
+	id     string
+	author string
+	tags   map[string]struct{}
+	vector map[string]float64
+}
+
+// index holds TF-IDF vectors of the title and content of articles. Vectors are computed once per Build, so
+// ranking an article costs one pass over the indexed articles.
+type index struct {
+	mu   sync.RWMutex
+	idf  map[string]float64
+	docs []document
+	// unseen is the idf of terms not found in any indexed article
+	unseen float64
+}
+
+func NewIndexI() IndexI {
+	return &index{idf: map[string]float64{}, unseen: 1}
+}
+
+func (x *index) Build(articles []model.ArticleDs) {
+	terms := make([]map[string]int, len(articles))
+	df := map[string]int{}
+	for i, article := range articles {
+		terms[i] = termCounts(article)
+		for term := range terms[i] {
+			df[term]++
+		}
+	}
+	idf := make(map[string]float64, len(df))
+	for term, n := range df {
+		idf[term] = smoothIdf(len(articles), n)
+	}
+	unseen := smoothIdf(len(articles), 0)
+	docs := make([]document, 0, len(articles))
+	for i, article := range articles {
+		docs = append(docs, newDocument(article, terms[i], idf, unseen))
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.idf = idf
+	x.docs = docs
+	x.unseen = unseen
+}
+
+func (x *index) Related(article model.ArticleDs, limit int) []Match {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	// the article is vectorised with the current idf, so edits since the last Build are taken into account
+	source := newDocument(article, termCounts(article), x.idf, x.unseen)
+	matches := make([]Match, 0, len(x.docs))
+	for _, doc := range x.docs {
+		if doc.id == source.id {
+			continue
+		}
+		score := TextWeight*cosine(source.vector, doc.vector) + TagWeight*jaccard(source.tags, doc.tags)
+		if source.author != "" && source.author == doc.author {
+			score += AuthorWeight
+		}
+		if score > 0 {
+			matches = append(matches, Match{Id: doc.id, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Id < matches[j].Id
+	})
+	if limit >= 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// smoothIdf is the inverse document frequency of a term found in n of total articles, smoothed so that a term
+// found in every article still weighs a little
+func smoothIdf(total int, n int) float64 {
+	return math.Log(float64(1+total)/float64(1+n)) + 1
+}
+
+// newDocument weighs the article's terms by tf-idf and normalises the vector to unit length. Terms missing
+// from idf, only written since the last Build, weigh unseen.
+func newDocument(article model.ArticleDs, counts map[string]int, idf map[string]float64, unseen float64) document {
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	vector := make(map[string]float64, len(counts))
+	var norm float64
+	for term, n := range counts {
+		weight, ok := idf[term]
+		if !ok {
+			weight = unseen
+		}
+		weight *= float64(n) / float64(total)
+		vector[term] = weight
+		norm += weight * weight
+	}
+	norm = math.Sqrt(norm)
+	for term := range vector {
+		vector[term] /= norm
+	}
+	tags := make(map[string]struct{}, len(article.Tags))
+	for _, tag := range article.Tags {
+		tags[tag] = struct{}{}
+	}
+	author := article.AuthorId
+	if author == "" {
+		author = strings.ToLower(strings.TrimSpace(article.Author))
+	}
+	return document{id: article.Id, author: author, tags: tags, vector: vector}
+}
+
+// termCounts counts the words of the article's title, boosted, and content
+func termCounts(article model.ArticleDs) map[string]int {
+	counts := map[string]int{}
+	for _, term := range tokenize(article.Title) {
+		counts[term] += titleBoost
+	}
+	for _, term := range tokenize(article.Content) {
+		counts[term]++
+	}
+	return counts
+}
+
+// tokenize splits text into lower-cased words of letters and digits, leaving out stop words and single characters
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := words[:0]
+	for _, word := range words {
+		if _, stop := stopWords[word]; stop || len([]rune(word)) < 2 {
+			continue
+		}
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+func cosine(a map[string]float64, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var dot float64
+	for term, weight := range a {
+		dot += weight * b[term]
+	}
+	return dot
+}
+
+func jaccard(a map[string]struct{}, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for tag := range a {
+		if _, ok := b[tag]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
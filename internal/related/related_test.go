@@ -0,0 +1,56 @@
+package related
+
+import (
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	got := tokenize("The Go scheduler, and how it works: M:N threads in Go 1.21!")
+	want := []string{"go", "scheduler", "works", "threads", "go", "21"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+}
+
+func TestIndex_Related(t *testing.T) {
+	articles := []model.ArticleDs{
+		{Id: "1", Title: "Goroutines and channels", Content: "Channels let goroutines communicate without locks.", AuthorId: "a", Tags: []string{"go", "concurrency"}},
+		{Id: "2", Title: "Channels in depth", Content: "Buffered channels and unbuffered channels block differently.", AuthorId: "b", Tags: []string{"go"}},
+		{Id: "3", Title: "Sourdough bread", Content: "Flour, water and patience make a good loaf.", AuthorId: "c", Tags: []string{"baking"}},
+		{Id: "4", Title: "Mutexes", Content: "Locks protect shared memory between threads.", AuthorId: "a", Tags: []string{"concurrency"}},
+		{Id: "5", Title: "Rye loaves", Content: "Rye flour needs more water.", AuthorId: "d"},
+	}
+	x := NewIndexI()
+	x.Build(articles)
+
+	got := x.Related(articles[0], 10)
+	ids := make([]string, 0, len(got))
+	for _, m := range got {
+		ids = append(ids, m.Id)
+		if m.Score <= 0 || m.Score > 1 {
+			t.Errorf("Want: %v, Got: %v", "score in (0, 1]", m)
+		}
+	}
+	if want := []string{"2", "4"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Want: %v, Got: %v", want, ids)
+	}
+	if got = x.Related(articles[0], 1); len(got) != 1 || got[0].Id != "2" {
+		t.Errorf("Want: %v, Got: %v", "only article 2", got)
+	}
+	if got = x.Related(articles[2], 10); len(got) != 1 || got[0].Id != "5" {
+		t.Errorf("Want: %v, Got: %v", "only article 5", got)
+	}
+
+	// an article written since the last build is ranked against the index all the same
+	fresh := model.ArticleDs{Id: "6", Title: "Sourdough starters", Content: "Feeding a sourdough starter with flour."}
+	if got = x.Related(fresh, 10); len(got) == 0 || got[0].Id != "3" {
+		t.Errorf("Want: %v, Got: %v", "article 3 first", got)
+	}
+
+	// the same author alone relates articles
+	if got = x.Related(model.ArticleDs{Id: "7", Title: "zzz", AuthorId: "d"}, 10); !reflect.DeepEqual(got, []Match{{Id: "5", Score: AuthorWeight}}) {
+		t.Errorf("Want: %v, Got: %v", "article 5 by the same author", got)
+	}
+}
@@ -0,0 +1,81 @@
+// Package render turns article content into HTML that is safe to embed in a page
+package render
+
+import (
+	"bytes"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+	"html"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// Content formats articles are written in
+const (
+	FormatPlain    = "plain"
+	FormatMarkdown = "markdown"
+	FormatHtml     = "html"
+)
+
+// markdown renders GitHub flavoured Markdown. Raw HTML is passed through, Html sanitises it with the rest.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+)
+
+// policy is the allowlist every rendered article goes through: the formatting elements of user generated content,
+// links and images over http(s) or relative URLs only, no scripts, styles, event handlers or iframes.
+// Links get rel="nofollow noopener" and open in a new tab.
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	// language hints of fenced code blocks, for client side highlighting
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+-]+$`)).OnElements("code")
+	return p
+}
+
+var blankLines = regexp.MustCompile(`\n(?:[ \t]*\n)+`)
+
+// Html renders content written in format as sanitised HTML. Plain text is escaped, its blank lines separate
+// paragraphs and other line breaks are kept.
+func Html(format string, content string) string {
+	switch format {
+	case FormatMarkdown:
+		var buf bytes.Buffer
+		if err := markdown.Convert([]byte(content), &buf); err != nil {
+			log.Print(err)
+			return plain(content)
+		}
+		return Sanitize(buf.String())
+	case FormatHtml:
+		return Sanitize(content)
+	default:
+		return plain(content)
+	}
+}
+
+// Sanitize strips everything outside the allowlist from s
+func Sanitize(s string) string {
+	return policy.Sanitize(s)
+}
+
+func plain(content string) string {
+	content = strings.ReplaceAll(strings.TrimSpace(content), "\r\n", "\n")
+	if content == "" {
+		return ""
+	}
+	var b strings.Builder
+	for _, paragraph := range blankLines.Split(content, -1) {
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>\n"))
+		b.WriteString("</p>\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package render
+
+import (
+	"testing"
+)
+
+func TestHtml(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		content string
+		want    string
+	}{
+		{
+			name:    "plain text is escaped into paragraphs",
+			format:  FormatPlain,
+			content: "Fish & chips <b>\nline two\n\n  \nnext paragraph",
+			want:    "<p>Fish &amp; chips &lt;b&gt;<br>\nline two</p>\n<p>next paragraph</p>\n",
+		},
+		{
+			name:    "unknown format is rendered as plain text",
+			format:  "",
+			content: "<i>hi</i>",
+			want:    "<p>&lt;i&gt;hi&lt;/i&gt;</p>\n",
+		},
+		{
+			name:    "markdown",
+			format:  FormatMarkdown,
+			content: "# Title\n\nSome **bold** and ~~gone~~ text.\n\n```go\nfmt.Println(1)\n```\n",
+			want:    "<h1>Title</h1>\n<p>Some <strong>bold</strong> and <del>gone</del> text.</p>\n<pre><code class=\"language-go\">fmt.Println(1)\n</code></pre>\n",
+		},
+		{
+			name:    "markdown links are nofollow",
+			format:  FormatMarkdown,
+			content: "[site](https://example.com) [local](/articles/1)",
+			want:    "<p><a href=\"https://example.com\" rel=\"nofollow noopener\" target=\"_blank\">site</a> <a href=\"/articles/1\" rel=\"nofollow\">local</a></p>\n",
+		},
+		{
+			name:    "script in markdown is dropped",
+			format:  FormatMarkdown,
+			content: "hello <script>alert(1)</script>",
+			want:    "<p>hello </p>\n",
+		},
+		{
+			name:    "javascript urls are dropped",
+			format:  FormatMarkdown,
+			content: "[click](javascript:alert(1))",
+			want:    "<p>click</p>\n",
+		},
+		{
+			name:    "event handlers and styles are dropped from html",
+			format:  FormatHtml,
+			content: `<p onclick="steal()" style="color:red">text</p><img src="x.png" onerror="steal()"><iframe src="https://evil.example"></iframe>`,
+			want:    `<p>text</p><img src="x.png">`,
+		},
+		{
+			name:    "unknown classes are dropped",
+			format:  FormatHtml,
+			content: `<code class="language-go">x</code><code class="evil">y</code>`,
+			want:    `<code class="language-go">x</code><code>y</code>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Html(tt.format, tt.content); got != tt.want {
+				t.Errorf("Want: %q, Got: %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package cacher
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"log"
+	"sync"
+	"time"
+)
+
+const defaultInvalidationChannel = "article-management-sys:cache-invalidation"
+
+// lruEntry is a single value held by the in-process tier
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// invalidation is the message published to other instances whenever a key is written or removed
+type invalidation struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+// lruCache is a bounded in-process cache tier layered in front of another CacherI (usually redis).
+// Writes and deletes are announced over redis pub/sub so other instances drop their local copies.
+type lruCache struct {
+	mu         sync.Mutex
+	next       CacherI
+	ll         *list.List
+	items      map[string]*list.Element
+	maxEntries int
+	maxBytes   int64
+	curBytes   int64
+	ttl        time.Duration
+	rdb        *redis.Client
+	channel    string
+	id         string
+	// generation counts evictions, a value fetched from the next tier is only kept when none happened meanwhile
+	generation uint64
+}
+
+// ttlGetter is implemented by tiers that report how long a value has left, so local copies never outlive it
+type ttlGetter interface {
+	GetWithTTL(key string) ([]byte, time.Duration, error)
+}
+
+// NewLocalCacher wraps next with an LRU tier limited by entry count and total bytes.
+// If cacheSvc carries a redis client the tier subscribes to the invalidation channel.
+func NewLocalCacher(cfg config.LocalCacheConfig, cacheSvc config.CacheSvc, next CacherI) CacherI {
+	l := &lruCache{
+		next:       next,
+		ll:         list.New(),
+		items:      map[string]*list.Element{},
+		maxEntries: cfg.MaxEntries,
+		maxBytes:   cfg.MaxBytes,
+		ttl:        cfg.TTLDuration,
+		rdb:        cacheSvc.Rdb,
+		channel:    cfg.InvalidationChannel,
+		id:         uuid.NewString(),
+	}
+	if l.channel == "" {
+		l.channel = defaultInvalidationChannel
+	}
+	if l.rdb != nil {
+		ps := l.rdb.Subscribe(context.Background(), l.channel)
+		// wait for the subscription to be confirmed so no invalidation is missed after start-up
+		if _, err := ps.Receive(context.Background()); err != nil {
+			log.Print(err)
+		}
+		go l.listen(ps.Channel())
+	}
+	return l
+}
+
+// listen evicts keys announced by other instances
+func (l *lruCache) listen(messages <-chan *redis.Message) {
+	for msg := range messages {
+		var inv invalidation
+		if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil {
+			log.Print(err)
+			continue
+		}
+		if inv.Origin == l.id {
+			continue
+		}
+		l.evict(inv.Keys...)
+	}
+}
+
+func (l *lruCache) Get(key string) ([]byte, error) {
+	l.mu.Lock()
+	if el, ok := l.items[key]; ok {
+		entry := el.Value.(*lruEntry)
+		if time.Now().Before(entry.expiresAt) {
+			l.ll.MoveToFront(el)
+			l.mu.Unlock()
+			return entry.value, nil
+		}
+		l.removeElement(el)
+	}
+	generation := l.generation
+	l.mu.Unlock()
+
+	data, ttl, err := l.fetch(key)
+	if err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	if l.generation == generation {
+		l.insert(key, data, ttl)
+	}
+	l.mu.Unlock()
+	return data, nil
+}
+
+// fetch reads key from the next tier and returns how long it may be kept locally, at most until it expires there
+func (l *lruCache) fetch(key string) ([]byte, time.Duration, error) {
+	g, ok := l.next.(ttlGetter)
+	if !ok {
+		data, err := l.next.Get(key)
+		return data, l.ttl, err
+	}
+	data, remaining, err := g.GetWithTTL(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	ttl := l.ttl
+	switch {
+	case remaining > 0 && remaining < ttl:
+		ttl = remaining
+	case remaining <= 0 && remaining != -1:
+		// the key expired between the two commands
+		ttl = 0
+	}
+	return data, ttl, nil
+}
+
+func (l *lruCache) Set(key string, value interface{}, expiry time.Duration) error {
+	err := l.next.Set(key, value, expiry)
+	if err != nil {
+		l.evict(key)
+		return err
+	}
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	}
+	ttl := l.ttl
+	if expiry > 0 && (ttl <= 0 || expiry < ttl) {
+		ttl = expiry
+	}
+	if data != nil {
+		l.add(key, data, ttl)
+	} else {
+		l.evict(key)
+	}
+	l.publish(key)
+	return nil
+}
+
+func (l *lruCache) Delete(keys ...string) error {
+	l.evict(keys...)
+	err := l.next.Delete(keys...)
+	l.publish(keys...)
+	return err
+}
+
+// SetNX is decided by the next tier, a successful write drops every local copy of key
+func (l *lruCache) SetNX(key string, value interface{}, expiry time.Duration) (bool, error) {
+	ok, err := l.next.SetNX(key, value, expiry)
+	if err != nil || !ok {
+		return ok, err
+	}
+	l.evict(key)
+	l.publish(key)
+	return true, nil
+}
+
+func (l *lruCache) Tag(tag string, key string, expiry time.Duration) error {
+	return l.next.Tag(tag, key, expiry)
+}
+
+// InvalidateTags removes the tagged keys from the next tier, then drops them here and on the other instances
+func (l *lruCache) InvalidateTags(tags ...string) ([]string, error) {
+	keys, err := l.next.InvalidateTags(tags...)
+	l.evict(keys...)
+	l.publish(keys...)
+	return keys, err
+}
+
+// add stores a value locally, evicting the least recently used entries until both limits hold
+func (l *lruCache) add(key string, value []byte, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.insert(key, value, ttl)
+}
+
+// insert must be called with the lock held
+func (l *lruCache) insert(key string, value []byte, ttl time.Duration) {
+	if el, ok := l.items[key]; ok {
+		l.removeElement(el)
+	}
+	size := int64(len(key) + len(value))
+	if ttl <= 0 || (l.maxBytes > 0 && size > l.maxBytes) {
+		return
+	}
+	l.items[key] = l.ll.PushFront(&lruEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)})
+	l.curBytes += size
+	for (l.maxEntries > 0 && l.ll.Len() > l.maxEntries) || (l.maxBytes > 0 && l.curBytes > l.maxBytes) {
+		l.removeElement(l.ll.Back())
+	}
+}
+
+func (l *lruCache) evict(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.generation++
+	for _, key := range keys {
+		if el, ok := l.items[key]; ok {
+			l.removeElement(el)
+		}
+	}
+}
+
+// removeElement must be called with the lock held
+func (l *lruCache) removeElement(el *list.Element) {
+	entry := l.ll.Remove(el).(*lruEntry)
+	delete(l.items, entry.key)
+	l.curBytes -= int64(len(entry.key) + len(entry.value))
+}
+
+func (l *lruCache) publish(keys ...string) {
+	if l.rdb == nil || len(keys) == 0 {
+		return
+	}
+	msg, err := json.Marshal(invalidation{Origin: l.id, Keys: keys})
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	if err := l.rdb.Publish(context.Background(), l.channel, msg).Err(); err != nil {
+		log.Print(err)
+	}
+}
@@ -0,0 +1,205 @@
+package cacher
+
+import (
+	"context"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLocalCacher_Get(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tests := []struct {
+		name         string
+		cfg          config.LocalCacheConfig
+		setupFunc    func(*mock.MockCacherI) CacherI
+		validateFunc func(CacherI)
+	}{
+		{
+			name: "Success:: Get:: served locally after first hit",
+			cfg:  config.LocalCacheConfig{MaxEntries: 2, TTLDuration: time.Minute},
+			setupFunc: func(next *mock.MockCacherI) CacherI {
+				next.EXPECT().Get("1").Return([]byte("ABC"), nil).Times(1)
+				return nil
+			},
+			validateFunc: func(c CacherI) {
+				for i := 0; i < 2; i++ {
+					data, err := c.Get("1")
+					if err != nil {
+						t.Errorf("want %v got %v", nil, err.Error())
+					}
+					if !reflect.DeepEqual(data, []byte("ABC")) {
+						t.Errorf("want %v got %v", "ABC", string(data))
+					}
+				}
+			},
+		},
+		{
+			name: "Success:: Get:: least recently used entry evicted",
+			cfg:  config.LocalCacheConfig{MaxEntries: 2, TTLDuration: time.Minute},
+			setupFunc: func(next *mock.MockCacherI) CacherI {
+				next.EXPECT().Set(gomock.Any(), gomock.Any(), time.Minute).Return(nil).Times(3)
+				next.EXPECT().Get("1").Return([]byte("1"), nil).Times(1)
+				return nil
+			},
+			validateFunc: func(c CacherI) {
+				_ = c.Set("1", []byte("1"), time.Minute)
+				_ = c.Set("2", []byte("2"), time.Minute)
+				_ = c.Set("3", []byte("3"), time.Minute)
+				// "1" must come from the next tier, "3" must still be local
+				_, _ = c.Get("1")
+				_, _ = c.Get("3")
+			},
+		},
+		{
+			name: "Success:: Get:: byte limit respected",
+			cfg:  config.LocalCacheConfig{MaxBytes: 4, TTLDuration: time.Minute},
+			setupFunc: func(next *mock.MockCacherI) CacherI {
+				next.EXPECT().Set("1", []byte("ABCDEF"), time.Minute).Return(nil)
+				next.EXPECT().Get("1").Return([]byte("ABCDEF"), nil).Times(2)
+				return nil
+			},
+			validateFunc: func(c CacherI) {
+				_ = c.Set("1", []byte("ABCDEF"), time.Minute)
+				_, _ = c.Get("1")
+				_, _ = c.Get("1")
+			},
+		},
+		{
+			name: "Success:: Get:: expired entry refreshed",
+			cfg:  config.LocalCacheConfig{TTLDuration: time.Minute},
+			setupFunc: func(next *mock.MockCacherI) CacherI {
+				next.EXPECT().Set("1", []byte("ABC"), time.Millisecond).Return(nil)
+				next.EXPECT().Get("1").Return(nil, errors.New("redis: nil"))
+				return nil
+			},
+			validateFunc: func(c CacherI) {
+				_ = c.Set("1", []byte("ABC"), time.Millisecond)
+				time.Sleep(5 * time.Millisecond)
+				_, err := c.Get("1")
+				if err == nil {
+					t.Errorf("want %v got %v", "error", nil)
+				}
+			},
+		},
+		{
+			name: "Failure:: Get",
+			cfg:  config.LocalCacheConfig{TTLDuration: time.Minute},
+			setupFunc: func(next *mock.MockCacherI) CacherI {
+				next.EXPECT().Get("1").Return(nil, errors.New("error"))
+				return nil
+			},
+			validateFunc: func(c CacherI) {
+				_, err := c.Get("1")
+				if err == nil {
+					t.Errorf("want %v got %v", "error", nil)
+				}
+			},
+		},
+		{
+			name: "Success:: Delete",
+			cfg:  config.LocalCacheConfig{TTLDuration: time.Minute},
+			setupFunc: func(next *mock.MockCacherI) CacherI {
+				next.EXPECT().Set("1", []byte("ABC"), time.Minute).Return(nil)
+				next.EXPECT().Delete("1").Return(nil)
+				next.EXPECT().Get("1").Return(nil, errors.New("redis: nil"))
+				return nil
+			},
+			validateFunc: func(c CacherI) {
+				_ = c.Set("1", []byte("ABC"), time.Minute)
+				if err := c.Delete("1"); err != nil {
+					t.Errorf("want %v got %v", nil, err.Error())
+				}
+				if _, err := c.Get("1"); err == nil {
+					t.Errorf("want %v got %v", "error", nil)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := mock.NewMockCacherI(mockCtrl)
+			tt.setupFunc(next)
+			c := NewLocalCacher(tt.cfg, config.CacheSvc{}, next)
+			tt.validateFunc(c)
+		})
+	}
+}
+
+func TestLocalCacher_Invalidation(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	cfg := config.LocalCacheConfig{MaxEntries: 10, TTLDuration: time.Minute}
+	rdb1 := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	rdb2 := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	instance1 := NewLocalCacher(cfg, config.CacheSvc{Rdb: rdb1}, NewCacher(config.CacheSvc{Rdb: rdb1}))
+	instance2 := NewLocalCacher(cfg, config.CacheSvc{Rdb: rdb2}, NewCacher(config.CacheSvc{Rdb: rdb2}))
+
+	if err := instance1.Set("1", []byte("old"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := instance2.Get("1"); string(data) != "old" {
+		t.Fatalf("want %v got %v", "old", string(data))
+	}
+	if err := instance1.Set("1", []byte("new"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		data, _ := instance2.Get("1")
+		if string(data) == "new" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("want %v got %v", "new", string(data))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestLocalCacher_GetKeepsRedisExpiry(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	if err := rdb.Set(context.Background(), "1", "ABC", time.Second).Err(); err != nil {
+		t.Fatal(err)
+	}
+	c := NewLocalCacher(config.LocalCacheConfig{TTLDuration: time.Hour}, config.CacheSvc{}, NewCacher(config.CacheSvc{Rdb: rdb})).(*lruCache)
+	if data, err := c.Get("1"); err != nil || string(data) != "ABC" {
+		t.Fatalf("want %v got %v %v", "ABC", string(data), err)
+	}
+	entry := c.items["1"].Value.(*lruEntry)
+	if time.Until(entry.expiresAt) > time.Second {
+		t.Errorf("want %v got %v", "expiry within the redis ttl", time.Until(entry.expiresAt))
+	}
+}
+
+func TestLocalCacher_GetRacingInvalidation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	next := mock.NewMockCacherI(mockCtrl)
+	c := NewLocalCacher(config.LocalCacheConfig{TTLDuration: time.Minute}, config.CacheSvc{}, next).(*lruCache)
+	// an invalidation arriving while the value is read must not be undone by caching the stale value
+	next.EXPECT().Get("1").DoAndReturn(func(string) ([]byte, error) {
+		c.evict("1")
+		return []byte("old"), nil
+	})
+	next.EXPECT().Get("1").Return([]byte("new"), nil)
+	_, _ = c.Get("1")
+	if data, _ := c.Get("1"); string(data) != "new" {
+		t.Errorf("want %v got %v", "new", string(data))
+	}
+}
@@ -11,6 +11,13 @@ import (
 type CacherI interface {
 	Get(string) ([]byte, error)
 	Set(string, interface{}, time.Duration) error
+	Delete(...string) error
+	// SetNX sets key only when it does not exist yet and reports whether it did
+	SetNX(string, interface{}, time.Duration) (bool, error)
+	// Tag records key under tag so it can be removed together with the other keys of the tag
+	Tag(tag string, key string, expiry time.Duration) error
+	// InvalidateTags deletes every key recorded under the tags, and the tags, returning the deleted keys
+	InvalidateTags(tags ...string) ([]string, error)
 }
 type cache struct {
 	rdb *redis.Client
@@ -29,6 +36,27 @@ func (c cache) Get(key string) ([]byte, error) {
 	}
 	return data, err
 }
+
+// GetWithTTL returns the value of key with the time it has left, -1 when it does not expire
+func (c cache) GetWithTTL(key string) ([]byte, time.Duration, error) {
+	ctx := context.Background()
+	var get *redis.StringCmd
+	var pttl *redis.DurationCmd
+	_, err := c.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		pttl = pipe.PTTL(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err := get.Bytes()
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, pttl.Val(), nil
+}
+
 func (c cache) Set(key string, value interface{}, expiry time.Duration) error {
 	err := c.rdb.Set(context.Background(), key, value, expiry).Err()
 	if err != nil {
@@ -36,3 +64,41 @@ func (c cache) Set(key string, value interface{}, expiry time.Duration) error {
 	}
 	return nil
 }
+func (c cache) Delete(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.rdb.Del(context.Background(), keys...).Err()
+}
+
+func (c cache) SetNX(key string, value interface{}, expiry time.Duration) (bool, error) {
+	return c.rdb.SetNX(context.Background(), key, value, expiry).Result()
+}
+
+func (c cache) Tag(tag string, key string, expiry time.Duration) error {
+	ctx := context.Background()
+	_, err := c.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, tag, key)
+		if expiry > 0 {
+			pipe.Expire(ctx, tag, expiry)
+		}
+		return nil
+	})
+	return err
+}
+
+func (c cache) InvalidateTags(tags ...string) ([]string, error) {
+	ctx := context.Background()
+	var keys []string
+	for _, tag := range tags {
+		members, err := c.rdb.SMembers(ctx, tag).Result()
+		if err != nil {
+			return keys, err
+		}
+		keys = append(keys, members...)
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return keys, c.rdb.Del(ctx, append(append([]string(nil), keys...), tags...)...).Err()
+}
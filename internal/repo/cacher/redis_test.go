@@ -2,6 +2,7 @@ package cacher
 
 import (
 	"errors"
+	"github.com/alicebob/miniredis/v2"
 	"github.com/go-redis/redis/v8"
 	"github.com/go-redis/redismock/v8"
 	"github.com/golang/mock/gomock"
@@ -118,3 +119,103 @@ func TestGet(t *testing.T) {
 	}
 
 }
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupFunc    func() (*redis.Client, redismock.ClientMock)
+		validateFunc func(error)
+	}{
+		{
+			name: "Success:: Delete",
+			setupFunc: func() (*redis.Client, redismock.ClientMock) {
+				db, mock := redismock.NewClientMock()
+				mock.ExpectDel("1", "2").SetVal(2)
+				return db, mock
+			},
+			validateFunc: func(err error) {
+				if err != nil {
+					t.Errorf("want %v got %v", nil, err.Error())
+				}
+			},
+		},
+		{
+			name: "Failure:: Delete",
+			setupFunc: func() (*redis.Client, redismock.ClientMock) {
+				db, mock := redismock.NewClientMock()
+				mock.ExpectDel("1", "2").SetErr(errors.New("error"))
+				return db, mock
+			},
+			validateFunc: func(err error) {
+				if err == nil {
+					t.Errorf("want %v got %v", "error", nil)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockCache := tt.setupFunc()
+			mockCacher := NewCacher(config.CacheSvc{Rdb: mockDB})
+			err := mockCacher.Delete("1", "2")
+			if mockCache.ExpectationsWereMet() != nil {
+				t.Log(mockCache.ExpectationsWereMet())
+				t.Fail()
+			}
+			tt.validateFunc(err)
+		})
+	}
+}
+
+func TestSetNX(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	mock.ExpectSetNX("1", "ABC", time.Minute).SetVal(true)
+	mock.ExpectSetNX("1", "ABC", time.Minute).SetVal(false)
+	c := NewCacher(config.CacheSvc{Rdb: db})
+	for _, want := range []bool{true, false} {
+		ok, err := c.SetNX("1", "ABC", time.Minute)
+		if err != nil {
+			t.Errorf("want %v got %v", nil, err.Error())
+		}
+		if ok != want {
+			t.Errorf("want %v got %v", want, ok)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("want %v got %v", nil, err.Error())
+	}
+}
+
+func TestInvalidateTags(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	c := NewCacher(config.CacheSvc{Rdb: redis.NewClient(&redis.Options{Addr: mr.Addr()})})
+	for _, key := range []string{"/articles/1?page=1", "/articles/1?page=2"} {
+		if err := c.Set(key, "ABC", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Tag("tag:/articles/1", key, time.Minute); err != nil {
+			t.Errorf("want %v got %v", nil, err)
+		}
+	}
+	if err := c.Set("/articles/2", "ABC", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := c.InvalidateTags("tag:/articles/1")
+	if err != nil {
+		t.Errorf("want %v got %v", nil, err)
+	}
+	if len(keys) != 2 {
+		t.Errorf("want %v got %v", 2, keys)
+	}
+	for _, key := range append(keys, "tag:/articles/1") {
+		if mr.Exists(key) {
+			t.Errorf("want %v got %v", "deleted", key)
+		}
+	}
+	if !mr.Exists("/articles/2") {
+		t.Errorf("want %v got %v", "untagged key kept", "deleted")
+	}
+}
@@ -0,0 +1,124 @@
+package datasource
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"strings"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../../pkg/mock/mock_apikey_datasource.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource ApiKeyDataSourceI
+
+type ApiKeyDataSourceI interface {
+	GetApiKeys(filter map[string]interface{}) ([]model.ApiKeyDs, error)
+	InsertApiKey(key model.ApiKeyDs) error
+	UpdateApiKey(id string, fields map[string]interface{}) error
+	// ReplaceApiKey inserts key and revokes the live key with the given id in one transaction
+	ReplaceApiKey(id string, key model.ApiKeyDs) error
+}
+
+// ErrApiKeyInactive is returned by ReplaceApiKey when the key to replace was revoked or has expired
+var ErrApiKeyInactive = errors.New("api key is revoked or expired")
+
+type apiKeySqlDs struct {
+	sqlSvc *sql.DB
+	table  string
+}
+
+// NewApiKeySql creates a new instance of apiKeySqlDs with a given database service and table name.
+func NewApiKeySql(dbSvc config.DbSvc, tableName string) ApiKeyDataSourceI {
+	return &apiKeySqlDs{
+		sqlSvc: dbSvc.Db,
+		table:  tableName,
+	}
+}
+
+// GetApiKeys retrieves the api keys matching the given filters, newest first.
+func (d apiKeySqlDs) GetApiKeys(filter map[string]interface{}) ([]model.ApiKeyDs, error) {
+	var keys []model.ApiKeyDs
+	q := fmt.Sprintf("SELECT id, name, prefix, key_hash, scopes, created_at, last_used_at, expires_at, revoked_at FROM %s", d.table)
+	where, args := assignmentsFromMap(filter)
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	q += " ORDER BY created_at DESC;"
+	rows, err := d.sqlSvc.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			key                            model.ApiKeyDs
+			scopes                         string
+			lastUsedAt, expiresAt, revoked sql.NullTime
+		)
+		err = rows.Scan(&key.Id, &key.Name, &key.Prefix, &key.KeyHash, &scopes, &key.CreatedAt, &lastUsedAt, &expiresAt, &revoked)
+		if err != nil {
+			return nil, err
+		}
+		if scopes != "" {
+			key.Scopes = strings.Split(scopes, ",")
+		}
+		key.LastUsedAt = nullTime(lastUsedAt)
+		key.ExpiresAt = nullTime(expiresAt)
+		key.RevokedAt = nullTime(revoked)
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// InsertApiKey adds a new api key to the database service.
+func (d apiKeySqlDs) InsertApiKey(key model.ApiKeyDs) error {
+	return d.insert(d.sqlSvc, key)
+}
+
+// ReplaceApiKey issues key before revoking the key with the given id, so the caller is never left without a key.
+// Nothing is changed when that key was revoked or expired in the meantime.
+func (d apiKeySqlDs) ReplaceApiKey(id string, key model.ApiKeyDs) error {
+	tx, err := d.sqlSvc.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err = d.insert(tx, key); err != nil {
+		return err
+	}
+	res, err := tx.Exec(fmt.Sprintf("UPDATE %s SET revoked_at = CURRENT_TIMESTAMP WHERE id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)", d.table), id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrApiKeyInactive
+	}
+	return tx.Commit()
+}
+
+func (d apiKeySqlDs) insert(db execer, key model.ApiKeyDs) error {
+	queryString := fmt.Sprintf("INSERT INTO %s", d.table)
+	_, err := db.Exec(queryString+"(id, name, prefix, key_hash, scopes, expires_at) VALUES(?,?,?,?,?,?)",
+		key.Id, key.Name, key.Prefix, key.KeyHash, strings.Join(key.Scopes, ","), key.ExpiresAt)
+	return err
+}
+
+// UpdateApiKey sets the given columns of the api key with the given id.
+func (d apiKeySqlDs) UpdateApiKey(id string, fields map[string]interface{}) error {
+	set, args := assignmentsFromMap(fields)
+	args = append(args, id)
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("UPDATE %s SET %s WHERE id = ?", d.table, strings.Join(set, ", ")), args...)
+	return err
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
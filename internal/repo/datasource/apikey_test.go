@@ -0,0 +1,143 @@
+package datasource
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestApiKeySqlDs_GetApiKeys(t *testing.T) {
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		filter    map[string]interface{}
+		setupFunc func(sqlmock.Sqlmock)
+		want      []model.ApiKeyDs
+		wantErr   bool
+	}{
+		{
+			name:   "SUCCESS::GetApiKeys",
+			filter: map[string]interface{}{"key_hash": "hash"},
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, prefix, key_hash, scopes, created_at, last_used_at, expires_at, revoked_at FROM api_keys WHERE key_hash = ? ORDER BY created_at DESC;")).
+					WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "prefix", "key_hash", "scopes", "created_at", "last_used_at", "expires_at", "revoked_at"}).
+						AddRow("1", "ingest", "ams_abc", "hash", "articles:write,role:author", created, nil, nil, created))
+			},
+			want: []model.ApiKeyDs{{
+				Id:        "1",
+				Name:      "ingest",
+				Prefix:    "ams_abc",
+				KeyHash:   "hash",
+				Scopes:    []string{"articles:write", "role:author"},
+				CreatedAt: created,
+				RevokedAt: &created,
+			}},
+		},
+		{
+			name: "FAILURE::GetApiKeys::query error",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, prefix, key_hash, scopes, created_at, last_used_at, expires_at, revoked_at FROM api_keys ORDER BY created_at DESC;")).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.setupFunc(mock)
+			got, err := apiKeySqlDs{sqlSvc: db, table: "api_keys"}.GetApiKeys(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Want: %v, Got: %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Want: %v, Got: %v", nil, err)
+			}
+		})
+	}
+}
+
+func TestApiKeySqlDs_InsertAndUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := apiKeySqlDs{sqlSvc: db, table: "api_keys"}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO api_keys(id, name, prefix, key_hash, scopes, expires_at) VALUES(?,?,?,?,?,?)")).
+		WithArgs("1", "ingest", "ams_abc", "hash", "a,b", nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE api_keys SET last_used_at = ?, revoked_at = ? WHERE id = ?")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = ds.InsertApiKey(model.ApiKeyDs{Id: "1", Name: "ingest", Prefix: "ams_abc", KeyHash: "hash", Scopes: []string{"a", "b"}})
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	err = ds.UpdateApiKey("1", map[string]interface{}{"revoked_at": time.Now(), "last_used_at": time.Now()})
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
+
+func TestApiKeySqlDs_ReplaceApiKey(t *testing.T) {
+	revoke := regexp.QuoteMeta("UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)")
+	tests := []struct {
+		name      string
+		setupFunc func(sqlmock.Sqlmock)
+		wantErr   error
+	}{
+		{
+			name: "SUCCESS::ReplaceApiKey",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO api_keys(id, name, prefix, key_hash, scopes, expires_at) VALUES(?,?,?,?,?,?)")).
+					WithArgs("2", "ingest", "ams_def", "hash", "a", nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(revoke).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "FAILURE::ReplaceApiKey::old key inactive",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO api_keys(id, name, prefix, key_hash, scopes, expires_at) VALUES(?,?,?,?,?,?)")).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(revoke).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrApiKeyInactive,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.setupFunc(mock)
+			err = apiKeySqlDs{sqlSvc: db, table: "api_keys"}.ReplaceApiKey("1", model.ApiKeyDs{Id: "2", Name: "ingest", Prefix: "ams_def", KeyHash: "hash", Scopes: []string{"a"}})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Want: %v, Got: %v", tt.wantErr, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Want: %v, Got: %v", nil, err)
+			}
+		})
+	}
+}
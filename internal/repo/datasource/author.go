@@ -0,0 +1,133 @@
+package datasource
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"strings"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../../pkg/mock/mock_author_datasource.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource AuthorDataSourceI
+
+type AuthorDataSourceI interface {
+	GetAuthors(filter map[string]interface{}) ([]model.AuthorDs, error)
+	InsertAuthor(author model.AuthorDs) error
+	UpdateAuthor(id string, fields map[string]interface{}) error
+	// MigrateAuthors creates the profiles of the authors only known by name on articles and links those articles
+	// to them, returning the number of articles linked
+	MigrateAuthors() (int64, error)
+}
+
+// ErrAmbiguousAuthor is returned by MigrateAuthors when an author name matches several profiles, its articles
+// could be attributed to any of them
+var ErrAmbiguousAuthor = errors.New("author name matches several profiles")
+
+type authorSqlDs struct {
+	sqlSvc       *sql.DB
+	table        string
+	articleTable string
+}
+
+// NewAuthorSql creates a new instance of authorSqlDs with a given database service, author and article table name.
+func NewAuthorSql(dbSvc config.DbSvc, tableName string, articleTableName string) AuthorDataSourceI {
+	return &authorSqlDs{
+		sqlSvc:       dbSvc.Db,
+		table:        tableName,
+		articleTable: articleTableName,
+	}
+}
+
+// GetAuthors retrieves the authors matching the given filters, sorted by name.
+func (d authorSqlDs) GetAuthors(filter map[string]interface{}) ([]model.AuthorDs, error) {
+	var authors []model.AuthorDs
+	q := fmt.Sprintf("SELECT id, name, bio, avatar_url, created_at, updated_at FROM %s", d.table)
+	where, args := assignmentsFromMap(filter)
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	q += " ORDER BY name;"
+	rows, err := d.sqlSvc.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var author model.AuthorDs
+		err = rows.Scan(&author.Id, &author.Name, &author.Bio, &author.AvatarUrl, &author.CreatedAt, &author.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		authors = append(authors, author)
+	}
+	return authors, rows.Err()
+}
+
+// InsertAuthor adds a new author to the database service.
+func (d authorSqlDs) InsertAuthor(author model.AuthorDs) error {
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("INSERT INTO %s(id, name, bio, avatar_url) VALUES(?,?,?,?)", d.table), author.Id, author.Name, author.Bio, author.AvatarUrl)
+	return err
+}
+
+// UpdateAuthor sets the given columns of the author with the given id.
+func (d authorSqlDs) UpdateAuthor(id string, fields map[string]interface{}) error {
+	set, args := assignmentsFromMap(fields)
+	args = append(args, id)
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("UPDATE %s SET %s WHERE id = ?", d.table, strings.Join(set, ", ")), args...)
+	return err
+}
+
+// MigrateAuthors runs in one transaction: articles written through the API get a profile keyed by their author_id,
+// the remaining author strings get one profile per name ignoring case and surrounding spaces, reusing a profile
+// of the same name when there is one, and those articles are pointed at it. Nothing is changed when a name
+// matches several profiles.
+func (d authorSqlDs) MigrateAuthors() (int64, error) {
+	tx, err := d.sqlSvc.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(fmt.Sprintf("INSERT IGNORE INTO %s(id, name, bio) SELECT author_id, MAX(TRIM(author)), '' FROM %s WHERE author_id <> '' GROUP BY author_id", d.table, d.articleTable))
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.Exec(fmt.Sprintf("INSERT INTO %[1]s(id, name, bio) SELECT UUID(), MIN(TRIM(a.author)), '' FROM %[2]s a WHERE a.author_id = '' AND NOT EXISTS (SELECT 1 FROM %[1]s au WHERE LOWER(au.name) = LOWER(TRIM(a.author))) GROUP BY LOWER(TRIM(a.author))", d.table, d.articleTable))
+	if err != nil {
+		return 0, err
+	}
+	ambiguous, err := d.ambiguousAuthors(tx)
+	if err != nil {
+		return 0, err
+	}
+	if len(ambiguous) > 0 {
+		return 0, fmt.Errorf("%w: %s", ErrAmbiguousAuthor, strings.Join(ambiguous, ", "))
+	}
+	res, err := tx.Exec(fmt.Sprintf("UPDATE %s a JOIN %s au ON LOWER(au.name) = LOWER(TRIM(a.author)) SET a.author_id = au.id WHERE a.author_id = ''", d.articleTable, d.table))
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return n, tx.Commit()
+}
+
+// ambiguousAuthors lists the author names of unlinked articles that match more than one profile
+func (d authorSqlDs) ambiguousAuthors(tx *sql.Tx) ([]string, error) {
+	rows, err := tx.Query(fmt.Sprintf("SELECT LOWER(TRIM(a.author)) FROM %s a JOIN %s au ON LOWER(au.name) = LOWER(TRIM(a.author)) WHERE a.author_id = '' GROUP BY LOWER(TRIM(a.author)) HAVING COUNT(DISTINCT au.id) > 1 ORDER BY 1", d.articleTable, d.table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
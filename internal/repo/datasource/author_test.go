@@ -0,0 +1,101 @@
+package datasource
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestAuthorSqlDs_GetAuthors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, bio, avatar_url, created_at, updated_at FROM authors WHERE id = ? ORDER BY name;")).
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "bio", "avatar_url", "created_at", "updated_at"}).
+			AddRow("user-1", "Jane Doe", "Writes about Go", "", created, created))
+
+	got, err := authorSqlDs{sqlSvc: db, table: "authors"}.GetAuthors(map[string]interface{}{"id": "user-1"})
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	want := []model.AuthorDs{{Id: "user-1", Name: "Jane Doe", Bio: "Writes about Go", CreatedAt: created, UpdatedAt: created}}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
+
+func TestAuthorSqlDs_MigrateAuthors(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupFunc func(sqlmock.Sqlmock)
+		want      int64
+		wantErr   bool
+	}{
+		{
+			name: "SUCCESS::MigrateAuthors",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO authors(id, name, bio) SELECT author_id, MAX(TRIM(author)), '' FROM articles WHERE author_id <> '' GROUP BY author_id")).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO authors(id, name, bio) SELECT UUID(), MIN(TRIM(a.author)), '' FROM articles a WHERE a.author_id = '' AND NOT EXISTS (SELECT 1 FROM authors au WHERE LOWER(au.name) = LOWER(TRIM(a.author))) GROUP BY LOWER(TRIM(a.author))")).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT LOWER(TRIM(a.author)) FROM articles a JOIN authors au ON LOWER(au.name) = LOWER(TRIM(a.author)) WHERE a.author_id = '' GROUP BY LOWER(TRIM(a.author)) HAVING COUNT(DISTINCT au.id) > 1 ORDER BY 1")).
+					WillReturnRows(sqlmock.NewRows([]string{"author"}))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE articles a JOIN authors au ON LOWER(au.name) = LOWER(TRIM(a.author)) SET a.author_id = au.id WHERE a.author_id = ''")).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+			want: 3,
+		},
+		{
+			name: "FAILURE::MigrateAuthors::name of several profiles",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO authors")).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO authors")).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT LOWER(TRIM(a.author))")).
+					WillReturnRows(sqlmock.NewRows([]string{"author"}).AddRow("jane doe"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "FAILURE::MigrateAuthors::rolled back",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO authors")).WillReturnError(errors.New("error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.setupFunc(mock)
+			got, err := authorSqlDs{sqlSvc: db, table: "authors", articleTable: "articles"}.MigrateAuthors()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Want: %v, Got: %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Want: %v, Got: %v", nil, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package datasource
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"strings"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../../pkg/mock/mock_category_datasource.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource CategoryDataSourceI
+
+type CategoryDataSourceI interface {
+	GetCategories(filter map[string]interface{}) ([]model.CategoryDs, error)
+	InsertCategory(category model.CategoryDs) error
+	UpdateCategory(id string, fields map[string]interface{}) error
+	DeleteCategory(id string) error
+}
+
+type categorySqlDs struct {
+	sqlSvc *sql.DB
+	table  string
+}
+
+// NewCategorySql creates a new instance of categorySqlDs with a given database service and table name.
+func NewCategorySql(dbSvc config.DbSvc, tableName string) CategoryDataSourceI {
+	return &categorySqlDs{
+		sqlSvc: dbSvc.Db,
+		table:  tableName,
+	}
+}
+
+// GetCategories retrieves the categories matching the given filters, sorted by name.
+func (d categorySqlDs) GetCategories(filter map[string]interface{}) ([]model.CategoryDs, error) {
+	var categories []model.CategoryDs
+	q := fmt.Sprintf("SELECT id, name, parent_id, created_at, updated_at FROM %s", d.table)
+	where, args := assignmentsFromMap(filter)
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	q += " ORDER BY name;"
+	rows, err := d.sqlSvc.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			category model.CategoryDs
+			parentId sql.NullString
+		)
+		err = rows.Scan(&category.Id, &category.Name, &parentId, &category.CreatedAt, &category.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if parentId.Valid {
+			category.ParentId = &parentId.String
+		}
+		categories = append(categories, category)
+	}
+	return categories, rows.Err()
+}
+
+// InsertCategory adds a new category to the database service.
+func (d categorySqlDs) InsertCategory(category model.CategoryDs) error {
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("INSERT INTO %s(id, name, parent_id) VALUES(?,?,?)", d.table), category.Id, category.Name, category.ParentId)
+	return err
+}
+
+// UpdateCategory sets the given columns of the category with the given id.
+func (d categorySqlDs) UpdateCategory(id string, fields map[string]interface{}) error {
+	set, args := assignmentsFromMap(fields)
+	args = append(args, id)
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("UPDATE %s SET %s WHERE id = ?", d.table, strings.Join(set, ", ")), args...)
+	return err
+}
+
+// DeleteCategory removes the category with the given id.
+func (d categorySqlDs) DeleteCategory(id string) error {
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = ?", d.table), id)
+	return err
+}
@@ -0,0 +1,68 @@
+package datasource
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestCategorySqlDs_GetCategories(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, parent_id, created_at, updated_at FROM categories WHERE parent_id = ? ORDER BY name;")).
+		WithArgs("root").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "parent_id", "created_at", "updated_at"}).
+			AddRow("1", "Go", "root", created, created))
+
+	got, err := categorySqlDs{sqlSvc: db, table: "categories"}.GetCategories(map[string]interface{}{"parent_id": "root"})
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	parent := "root"
+	want := []model.CategoryDs{{Id: "1", Name: "Go", ParentId: &parent, CreatedAt: created, UpdatedAt: created}}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
+
+func TestCategorySqlDs_InsertUpdateDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := categorySqlDs{sqlSvc: db, table: "categories"}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO categories(id, name, parent_id) VALUES(?,?,?)")).
+		WithArgs("1", "Go", nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE categories SET name = ?, parent_id = ? WHERE id = ?")).
+		WithArgs("Golang", "root", "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM categories WHERE id = ?")).
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = ds.InsertCategory(model.CategoryDs{Id: "1", Name: "Go"})
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	err = ds.UpdateCategory("1", map[string]interface{}{"name": "Golang", "parent_id": "root"})
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	err = ds.DeleteCategory("1")
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
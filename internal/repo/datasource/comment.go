@@ -0,0 +1,101 @@
+package datasource
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"strings"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../../pkg/mock/mock_comment_datasource.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource CommentDataSourceI
+
+type CommentDataSourceI interface {
+	// GetComments lists the comments matching filter, oldest first, limit 0 returning all of them
+	GetComments(filter map[string]interface{}, limit int, offset int) ([]model.CommentDs, error)
+	InsertComment(comment model.CommentDs) error
+	UpdateComment(id string, fields map[string]interface{}) error
+}
+
+type commentSqlDs struct {
+	sqlSvc *sql.DB
+	table  string
+}
+
+// NewCommentSql creates a new instance of commentSqlDs with a given database service and table name.
+func NewCommentSql(dbSvc config.DbSvc, tableName string) CommentDataSourceI {
+	return &commentSqlDs{
+		sqlSvc: dbSvc.Db,
+		table:  tableName,
+	}
+}
+
+// GetComments retrieves the comments matching the given filters. model.FilterTopLevel leaves the replies out and
+// model.FilterParents restricts the comments to the replies of the given comments.
+func (d commentSqlDs) GetComments(filter map[string]interface{}, limit int, offset int) ([]model.CommentDs, error) {
+	q := fmt.Sprintf("SELECT id, article_id, parent_id, author_id, author, body, status, created_at, updated_at FROM %s", d.table)
+	topLevel, _ := filter[model.FilterTopLevel].(bool)
+	parents, hasParents := filter[model.FilterParents].([]string)
+	columns := make(map[string]interface{}, len(filter))
+	for k, v := range filter {
+		if k != model.FilterTopLevel && k != model.FilterParents {
+			columns[k] = v
+		}
+	}
+	where, args := assignmentsFromMap(columns)
+	if topLevel {
+		where = append(where, "parent_id IS NULL")
+	}
+	if hasParents {
+		if len(parents) == 0 {
+			return nil, nil
+		}
+		where = append(where, fmt.Sprintf("parent_id IN (%s)", placeholders(len(parents))))
+		for _, id := range parents {
+			args = append(args, id)
+		}
+	}
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	q += " ORDER BY created_at, id"
+	if limit > 0 {
+		q += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+	}
+	rows, err := d.sqlSvc.Query(q+";", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var comments []model.CommentDs
+	for rows.Next() {
+		var (
+			comment  model.CommentDs
+			parentId sql.NullString
+		)
+		err = rows.Scan(&comment.Id, &comment.ArticleId, &parentId, &comment.AuthorId, &comment.Author, &comment.Body, &comment.Status, &comment.CreatedAt, &comment.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if parentId.Valid {
+			comment.ParentId = &parentId.String
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+// InsertComment adds a new comment to the database service.
+func (d commentSqlDs) InsertComment(comment model.CommentDs) error {
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("INSERT INTO %s(id, article_id, parent_id, author_id, author, body, status) VALUES(?,?,?,?,?,?,?)", d.table),
+		comment.Id, comment.ArticleId, comment.ParentId, comment.AuthorId, comment.Author, comment.Body, comment.Status)
+	return err
+}
+
+// UpdateComment sets the given columns of the comment with the given id.
+func (d commentSqlDs) UpdateComment(id string, fields map[string]interface{}) error {
+	set, args := assignmentsFromMap(fields)
+	args = append(args, id)
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("UPDATE %s SET %s WHERE id = ?", d.table, strings.Join(set, ", ")), args...)
+	return err
+}
@@ -0,0 +1,90 @@
+package datasource
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestCommentSqlDs_GetComments(t *testing.T) {
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	parent := "c1"
+	columns := []string{"id", "article_id", "parent_id", "author_id", "author", "body", "status", "created_at", "updated_at"}
+	tests := []struct {
+		name      string
+		filter    map[string]interface{}
+		limit     int
+		setupFunc func(sqlmock.Sqlmock)
+		want      []model.CommentDs
+	}{
+		{
+			name:   "SUCCESS::GetComments::top level page",
+			filter: map[string]interface{}{"article_id": "1", "status": model.CommentApproved, model.FilterTopLevel: true},
+			limit:  10,
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, article_id, parent_id, author_id, author, body, status, created_at, updated_at FROM comments WHERE article_id = ? AND status = ? AND parent_id IS NULL ORDER BY created_at, id LIMIT 10 OFFSET 0;")).
+					WithArgs("1", model.CommentApproved).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("c1", "1", nil, "user-1", "Jane", "First", model.CommentApproved, created, created))
+			},
+			want: []model.CommentDs{{Id: "c1", ArticleId: "1", AuthorId: "user-1", Author: "Jane", Body: "First", Status: model.CommentApproved, CreatedAt: created, UpdatedAt: created}},
+		},
+		{
+			name:   "SUCCESS::GetComments::replies",
+			filter: map[string]interface{}{"status": model.CommentApproved, model.FilterParents: []string{"c1", "c2"}},
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, article_id, parent_id, author_id, author, body, status, created_at, updated_at FROM comments WHERE status = ? AND parent_id IN (?,?) ORDER BY created_at, id;")).
+					WithArgs(model.CommentApproved, "c1", "c2").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("r1", "1", "c1", "user-2", "Joe", "Reply", model.CommentApproved, created, created))
+			},
+			want: []model.CommentDs{{Id: "r1", ArticleId: "1", ParentId: &parent, AuthorId: "user-2", Author: "Joe", Body: "Reply", Status: model.CommentApproved, CreatedAt: created, UpdatedAt: created}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.setupFunc(mock)
+			got, err := commentSqlDs{sqlSvc: db, table: "comments"}.GetComments(tt.filter, tt.limit, 0)
+			if err != nil {
+				t.Errorf("Want: %v, Got: %v", nil, err)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Want: %v, Got: %v", nil, err)
+			}
+		})
+	}
+}
+
+func TestCommentSqlDs_InsertAndUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := commentSqlDs{sqlSvc: db, table: "comments"}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO comments(id, article_id, parent_id, author_id, author, body, status) VALUES(?,?,?,?,?,?,?)")).
+		WithArgs("c1", "1", nil, "user-1", "Jane", "First", model.CommentPending).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE comments SET status = ? WHERE id = ?")).
+		WithArgs(model.CommentApproved, "c1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = ds.InsertComment(model.CommentDs{Id: "c1", ArticleId: "1", AuthorId: "user-1", Author: "Jane", Body: "First", Status: model.CommentPending})
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	err = ds.UpdateComment("c1", map[string]interface{}{"status": model.CommentApproved})
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
@@ -1,10 +1,32 @@
 package datasource
 
-import "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+import (
+	"errors"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"time"
+)
 
 //go:generate mockgen --build_flags=--mod=mod --destination=./../../../pkg/mock/mock_datasource.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource DataSourceI
 
 type DataSourceI interface {
 	Get(filter map[string]interface{}, limit int, offset int) ([]model.ArticleDs, error)
-	Insert(user model.ArticleDs) error
+	// Insert stores a new article with its tags and first revision, ErrSlugTaken when another article has its slug
+	Insert(article model.ArticleDs, revision model.RevisionDs) error
+	Update(id string, fields map[string]interface{}) error
+	// Edit stores a change of an article's title and content with its revision, reporting whether the article
+	// was still at the version the change was made against, ErrSlugTaken when another article has its new slug
+	Edit(id string, edit model.ArticleEdit) (bool, error)
+	// UpdateIfStatus updates the article only if it is in status, reporting whether it was
+	UpdateIfStatus(id string, status string, fields map[string]interface{}) (bool, error)
+	// GetTagCounts returns every tag of a published article with the number of published articles carrying it
+	GetTagCounts() ([]model.TagCount, error)
+	// GetSlugOwners returns the current and former slugs equal to base or to base with a suffix, with their article id
+	GetSlugOwners(base string) (map[string]string, error)
+	// GetSlugRedirect returns the id of the article that formerly had slug, or "" when none had
+	GetSlugRedirect(slug string) (string, error)
+	// GetDue returns the articles whose scheduled publication or withdrawal is due at now
+	GetDue(now time.Time) ([]model.ArticleDs, error)
 }
+
+// ErrSlugTaken is returned by Insert and Edit when a concurrent write gave another article the slug first
+var ErrSlugTaken = errors.New("slug is taken")
@@ -0,0 +1,57 @@
+package datasource
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../../pkg/mock/mock_lease_datasource.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource LeaseDataSourceI
+
+// LeaseDataSourceI hands a named lease to one holder at a time so only one instance runs a singleton job
+type LeaseDataSourceI interface {
+	// Acquire takes or renews the lease for ttl and reports whether holder now holds it
+	Acquire(name string, holder string, ttl time.Duration) (bool, error)
+	Release(name string, holder string) error
+}
+
+type leaseSqlDs struct {
+	sqlSvc *sql.DB
+	table  string
+}
+
+// NewLeaseSql creates a new instance of leaseSqlDs with a given database service and table name.
+func NewLeaseSql(dbSvc config.DbSvc, tableName string) LeaseDataSourceI {
+	return &leaseSqlDs{
+		sqlSvc: dbSvc.Db,
+		table:  tableName,
+	}
+}
+
+// Acquire renews the lease when holder has it or it has expired, creates it when it does not exist yet,
+// then reads back who holds it. Expiry is measured by the database clock, which every instance shares,
+// so instances with drifting clocks never hold the lease together.
+func (d leaseSqlDs) Acquire(name string, holder string, ttl time.Duration) (bool, error) {
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("UPDATE %s SET holder = ?, expires_at = NOW(3) + INTERVAL ? MICROSECOND WHERE name = ? AND (holder = ? OR expires_at < NOW(3))", d.table),
+		holder, ttl.Microseconds(), name, holder)
+	if err != nil {
+		return false, err
+	}
+	_, err = d.sqlSvc.Exec(fmt.Sprintf("INSERT IGNORE INTO %s(name, holder, expires_at) VALUES(?,?,NOW(3) + INTERVAL ? MICROSECOND)", d.table), name, holder, ttl.Microseconds())
+	if err != nil {
+		return false, err
+	}
+	var current string
+	err = d.sqlSvc.QueryRow(fmt.Sprintf("SELECT holder FROM %s WHERE name = ?", d.table), name).Scan(&current)
+	if err != nil {
+		return false, err
+	}
+	return current == holder, nil
+}
+
+// Release gives the lease up early if holder still has it.
+func (d leaseSqlDs) Release(name string, holder string) error {
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("DELETE FROM %s WHERE name = ? AND holder = ?", d.table), name, holder)
+	return err
+}
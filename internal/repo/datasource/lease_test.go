@@ -0,0 +1,83 @@
+package datasource
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestLeaseSqlDs_Acquire(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupFunc func(mock sqlmock.Sqlmock)
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name: "SUCCESS::Acquire:: lease held",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE leases SET holder = ?, expires_at = NOW(3) + INTERVAL ? MICROSECOND WHERE name = ? AND (holder = ? OR expires_at < NOW(3))")).
+					WithArgs("me", time.Minute.Microseconds(), "scheduler", "me").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO leases(name, holder, expires_at) VALUES(?,?,NOW(3) + INTERVAL ? MICROSECOND)")).
+					WithArgs("scheduler", "me", time.Minute.Microseconds()).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT holder FROM leases WHERE name = ?")).
+					WithArgs("scheduler").WillReturnRows(sqlmock.NewRows([]string{"holder"}).AddRow("me"))
+			},
+			want: true,
+		},
+		{
+			name: "SUCCESS::Acquire:: lease held by another instance",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE leases").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT IGNORE INTO leases").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT holder FROM leases").WillReturnRows(sqlmock.NewRows([]string{"holder"}).AddRow("other"))
+			},
+			want: false,
+		},
+		{
+			name: "FAILURE::Acquire:: update error",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE leases").WillReturnError(errors.New("connection refused"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.setupFunc(mock)
+			ds := leaseSqlDs{sqlSvc: db, table: "leases"}
+			got, err := ds.Acquire("scheduler", "me", time.Minute)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Want: %v, Got: %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Want: %v, Got: %v", nil, err)
+			}
+		})
+	}
+}
+
+func TestLeaseSqlDs_Release(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM leases WHERE name = ? AND holder = ?")).
+		WithArgs("scheduler", "me").WillReturnResult(sqlmock.NewResult(0, 1))
+	err = leaseSqlDs{sqlSvc: db, table: "leases"}.Release("scheduler", "me")
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
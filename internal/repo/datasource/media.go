@@ -0,0 +1,68 @@
+package datasource
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"strings"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../../pkg/mock/mock_media_datasource.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource MediaDataSourceI
+
+type MediaDataSourceI interface {
+	// GetMedia lists the files matching filter, oldest first
+	GetMedia(filter map[string]interface{}) ([]model.MediaDs, error)
+	InsertMedia(media model.MediaDs) error
+	DeleteMedia(id string) error
+}
+
+type mediaSqlDs struct {
+	sqlSvc *sql.DB
+	table  string
+}
+
+// NewMediaSql creates a new instance of mediaSqlDs with a given database service and table name.
+func NewMediaSql(dbSvc config.DbSvc, tableName string) MediaDataSourceI {
+	return &mediaSqlDs{
+		sqlSvc: dbSvc.Db,
+		table:  tableName,
+	}
+}
+
+// GetMedia retrieves the files matching the given filters.
+func (d mediaSqlDs) GetMedia(filter map[string]interface{}) ([]model.MediaDs, error) {
+	q := fmt.Sprintf("SELECT id, article_id, filename, content_type, size, width, height, checksum, storage_key, thumbnail_key, uploader, created_at FROM %s", d.table)
+	where, args := assignmentsFromMap(filter)
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	rows, err := d.sqlSvc.Query(q+" ORDER BY created_at, id;", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var media []model.MediaDs
+	for rows.Next() {
+		var m model.MediaDs
+		err = rows.Scan(&m.Id, &m.ArticleId, &m.Filename, &m.ContentType, &m.Size, &m.Width, &m.Height, &m.Checksum, &m.Key, &m.ThumbnailKey, &m.Uploader, &m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		media = append(media, m)
+	}
+	return media, rows.Err()
+}
+
+// InsertMedia records a file stored for an article.
+func (d mediaSqlDs) InsertMedia(m model.MediaDs) error {
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("INSERT INTO %s(id, article_id, filename, content_type, size, width, height, checksum, storage_key, thumbnail_key, uploader) VALUES(?,?,?,?,?,?,?,?,?,?,?)", d.table),
+		m.Id, m.ArticleId, m.Filename, m.ContentType, m.Size, m.Width, m.Height, m.Checksum, m.Key, m.ThumbnailKey, m.Uploader)
+	return err
+}
+
+// DeleteMedia removes the record of the file with the given id.
+func (d mediaSqlDs) DeleteMedia(id string) error {
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = ?", d.table), id)
+	return err
+}
@@ -0,0 +1,60 @@
+package datasource
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestMediaSqlDs_GetMedia(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, article_id, filename, content_type, size, width, height, checksum, storage_key, thumbnail_key, uploader, created_at FROM media WHERE article_id = ? ORDER BY created_at, id;")).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "article_id", "filename", "content_type", "size", "width", "height", "checksum", "storage_key", "thumbnail_key", "uploader", "created_at"}).
+			AddRow("m1", "1", "photo.png", "image/png", 2048, 640, 480, "abc", "articles/1/m1.png", "articles/1/m1-thumbnail.png", "user-1", created))
+
+	got, err := mediaSqlDs{sqlSvc: db, table: "media"}.GetMedia(map[string]interface{}{"article_id": "1"})
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	want := []model.MediaDs{{Id: "m1", ArticleId: "1", Filename: "photo.png", ContentType: "image/png", Size: 2048, Width: 640, Height: 480,
+		Checksum: "abc", Key: "articles/1/m1.png", ThumbnailKey: "articles/1/m1-thumbnail.png", Uploader: "user-1", CreatedAt: created}}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
+
+func TestMediaSqlDs_InsertDeleteMedia(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO media(id, article_id, filename, content_type, size, width, height, checksum, storage_key, thumbnail_key, uploader) VALUES(?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs("m1", "1", "doc.pdf", "application/pdf", int64(10), 0, 0, "abc", "articles/1/m1.pdf", "", "user-1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM media WHERE id = ?")).
+		WithArgs("m1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ds := mediaSqlDs{sqlSvc: db, table: "media"}
+	err = ds.InsertMedia(model.MediaDs{Id: "m1", ArticleId: "1", Filename: "doc.pdf", ContentType: "application/pdf", Size: 10, Checksum: "abc", Key: "articles/1/m1.pdf", Uploader: "user-1"})
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	if err = ds.DeleteMedia("m1"); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
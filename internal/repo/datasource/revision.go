@@ -0,0 +1,55 @@
+package datasource
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"strings"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../../pkg/mock/mock_revision_datasource.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource RevisionDataSourceI
+
+// RevisionDataSourceI reads the revisions of articles, they are stored by DataSourceI with every change of an
+// article's title and content and never modified
+type RevisionDataSourceI interface {
+	GetRevisions(filter map[string]interface{}) ([]model.RevisionDs, error)
+}
+
+type revisionSqlDs struct {
+	sqlSvc *sql.DB
+	table  string
+}
+
+// NewRevisionSql creates a new instance of revisionSqlDs with a given database service and table name.
+func NewRevisionSql(dbSvc config.DbSvc, tableName string) RevisionDataSourceI {
+	return &revisionSqlDs{
+		sqlSvc: dbSvc.Db,
+		table:  tableName,
+	}
+}
+
+// GetRevisions retrieves the revisions matching the given filters, newest first.
+func (d revisionSqlDs) GetRevisions(filter map[string]interface{}) ([]model.RevisionDs, error) {
+	var revisions []model.RevisionDs
+	q := fmt.Sprintf("SELECT article_id, rev, title, content, content_format, author, editor, created_at FROM %s", d.table)
+	where, args := assignmentsFromMap(filter)
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	q += " ORDER BY rev DESC;"
+	rows, err := d.sqlSvc.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var revision model.RevisionDs
+		err = rows.Scan(&revision.ArticleId, &revision.Rev, &revision.Title, &revision.Content, &revision.ContentFormat, &revision.Author, &revision.Editor, &revision.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, rows.Err()
+}
@@ -0,0 +1,33 @@
+package datasource
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestRevisionSqlDs_GetRevisions(t *testing.T) {
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT article_id, rev, title, content, content_format, author, editor, created_at FROM revisions WHERE article_id = ? AND rev = ? ORDER BY rev DESC;")).
+		WithArgs("1", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "rev", "title", "content", "content_format", "author", "editor", "created_at"}).
+			AddRow("1", 2, "TITLE", "CONTENT", "markdown", "AUTHOR", "user-1", created))
+	got, err := revisionSqlDs{sqlSvc: db, table: "revisions"}.GetRevisions(map[string]interface{}{"article_id": "1", "rev": 2})
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	want := []model.RevisionDs{{ArticleId: "1", Rev: 2, Title: "TITLE", Content: "CONTENT", ContentFormat: "markdown", Author: "AUTHOR", Editor: "user-1", CreatedAt: created}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
@@ -0,0 +1,148 @@
+package datasource
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../../pkg/mock/mock_schema_datasource.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource SchemaDataSourceI
+
+type SchemaDataSourceI interface {
+	// Migrate brings a database created by an older release up to the current schema and returns the changes
+	// it made, it changes nothing on a current database
+	Migrate() ([]string, error)
+}
+
+// column is a column added to a table after its first release
+type column struct {
+	name string
+	// definition is used to add the column to existing rows
+	definition string
+}
+
+type schemaSqlDs struct {
+	sqlSvc *sql.DB
+	db     config.DbCfg
+}
+
+// NewSchemaSql creates a new instance of schemaSqlDs with a given database service, taking the table names from
+// the database configuration.
+func NewSchemaSql(dbSvc config.DbSvc, db config.DbCfg) SchemaDataSourceI {
+	return &schemaSqlDs{
+		sqlSvc: dbSvc.Db,
+		db:     db,
+	}
+}
+
+// tables returns the schema of every table by name, the article table first
+func (d schemaSqlDs) tables() [][2]string {
+	return [][2]string{
+		{d.db.TableName, model.Schema},
+		{d.db.ApiKeyTable(), model.ApiKeySchema},
+		{d.db.LeaseTable(), model.LeaseSchema},
+		{d.db.RevisionTable(), model.RevisionSchema},
+		{d.db.TagTable(), model.TagSchema},
+		{d.db.CategoryTable(), model.CategorySchema},
+		{d.db.AuthorTable(), model.AuthorSchema},
+		{d.db.SlugTable(), model.SlugSchema},
+		{d.db.CommentTable(), model.CommentSchema},
+		{d.db.ViewTable(), model.ViewSchema},
+		{d.db.MediaTable(), model.MediaSchema},
+		{d.db.TranslationTable(), model.TranslationSchema},
+	}
+}
+
+// articleColumnsAdded are the columns of the article table missing from the first release, in schema order.
+// Articles written back then were public, so they are added as published; new articles still start as drafts.
+var articleColumnsAdded = []column{
+	{name: "author_id", definition: "VARCHAR(255) NOT NULL DEFAULT '' AFTER author"},
+	{name: "content_format", definition: "VARCHAR(16) NOT NULL DEFAULT 'plain' AFTER content"},
+	{name: "content_html", definition: "MEDIUMTEXT NULL AFTER content_format"},
+	{name: "locale", definition: "VARCHAR(35) NOT NULL DEFAULT 'en' AFTER content_html"},
+	{name: "status", definition: "VARCHAR(32) NOT NULL DEFAULT 'published' AFTER locale"},
+	{name: "version", definition: "INT NOT NULL DEFAULT 1 AFTER status"},
+	{name: "category_id", definition: "VARCHAR(255) NULL AFTER version"},
+	{name: "slug", definition: "VARCHAR(255) NULL UNIQUE AFTER category_id"},
+	{name: "created_at", definition: "TIMESTAMP DEFAULT CURRENT_TIMESTAMP AFTER slug"},
+	{name: "updated_at", definition: "TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP AFTER created_at"},
+	{name: "publish_at", definition: "TIMESTAMP NULL AFTER updated_at"},
+	{name: "unpublish_at", definition: "TIMESTAMP NULL AFTER publish_at"},
+}
+
+// revisionColumnsAdded are the columns of the revision table missing from its first release
+var revisionColumnsAdded = []column{
+	{name: "content_format", definition: "VARCHAR(16) NOT NULL DEFAULT 'plain' AFTER content"},
+}
+
+// Migrate creates the missing tables and adds the missing columns of the article and revision tables. Every step checks the
+// current schema first, so an interrupted run is completed by running it again.
+func (d schemaSqlDs) Migrate() ([]string, error) {
+	present, err := d.names("SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE()")
+	if err != nil {
+		return nil, err
+	}
+	var applied []string
+	for _, table := range d.tables() {
+		if present[table[0]] {
+			continue
+		}
+		_, err = d.sqlSvc.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s %s", table[0], table[1]))
+		if err != nil {
+			return applied, err
+		}
+		applied = append(applied, "created table "+table[0])
+	}
+	added, err := d.addColumns(d.db.TableName, articleColumnsAdded)
+	applied = append(applied, added...)
+	if err != nil {
+		return applied, err
+	}
+	added, err = d.addColumns(d.db.RevisionTable(), revisionColumnsAdded)
+	applied = append(applied, added...)
+	if err != nil {
+		return applied, err
+	}
+	// status was added with existing articles published, articles created from now on are drafts
+	_, err = d.sqlSvc.Exec(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN status SET DEFAULT '%s'", d.db.TableName, model.StatusDraft))
+	return applied, err
+}
+
+// addColumns adds the given columns missing from table
+func (d schemaSqlDs) addColumns(table string, columns []column) ([]string, error) {
+	existing, err := d.names("SELECT COLUMN_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?", table)
+	if err != nil {
+		return nil, err
+	}
+	var applied []string
+	for _, c := range columns {
+		if existing[c.name] {
+			continue
+		}
+		_, err = d.sqlSvc.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, c.name, c.definition))
+		if err != nil {
+			return applied, err
+		}
+		applied = append(applied, fmt.Sprintf("added column %s.%s", table, c.name))
+	}
+	return applied, nil
+}
+
+// names returns the names listed by an information_schema query
+func (d schemaSqlDs) names(query string, args ...interface{}) (map[string]bool, error) {
+	rows, err := d.sqlSvc.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	names := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names[name] = true
+	}
+	return names, rows.Err()
+}
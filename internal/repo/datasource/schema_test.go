@@ -0,0 +1,92 @@
+package datasource
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestSchemaSqlDs_Migrate(t *testing.T) {
+	cfg := config.DbCfg{TableName: "newTemp"}
+	ds := schemaSqlDs{db: cfg}
+	tables := sqlmock.NewRows([]string{"TABLE_NAME"})
+	for _, table := range ds.tables() {
+		tables.AddRow(table[0])
+	}
+	columns := sqlmock.NewRows([]string{"COLUMN_NAME"}).AddRow("id").AddRow("title").AddRow("author").AddRow("content")
+	for _, c := range articleColumnsAdded {
+		columns.AddRow(c.name)
+	}
+	tests := []struct {
+		name      string
+		setupFunc func(sqlmock.Sqlmock)
+		want      []string
+	}{
+		{
+			name: "SUCCESS::Migrate::first release",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE()")).
+					WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME"}).AddRow("newTemp").AddRow("other"))
+				for _, table := range ds.tables()[1:] {
+					mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS " + table[0] + " ")).WillReturnResult(sqlmock.NewResult(0, 0))
+				}
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COLUMN_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?")).
+					WithArgs("newTemp").
+					WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME"}).AddRow("id").AddRow("title").AddRow("author").AddRow("content"))
+				for _, c := range articleColumnsAdded {
+					mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE newTemp ADD COLUMN " + c.name + " " + c.definition)).WillReturnResult(sqlmock.NewResult(0, 0))
+				}
+				// created above with every column
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COLUMN_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?")).
+					WithArgs("article_revisions").
+					WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME"}).AddRow("article_id").AddRow("content_format"))
+				mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE newTemp ALTER COLUMN status SET DEFAULT 'draft'")).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			want: func() []string {
+				var want []string
+				for _, table := range ds.tables()[1:] {
+					want = append(want, "created table "+table[0])
+				}
+				for _, c := range articleColumnsAdded {
+					want = append(want, "added column newTemp."+c.name)
+				}
+				return want
+			}(),
+		},
+		{
+			name: "SUCCESS::Migrate::current schema",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE()")).
+					WillReturnRows(tables)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COLUMN_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?")).
+					WithArgs("newTemp").
+					WillReturnRows(columns)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COLUMN_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?")).
+					WithArgs("article_revisions").
+					WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME"}).AddRow("article_id").AddRow("content_format"))
+				mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE newTemp ALTER COLUMN status SET DEFAULT 'draft'")).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.setupFunc(mock)
+			got, err := schemaSqlDs{sqlSvc: db, db: cfg}.Migrate()
+			if err != nil {
+				t.Errorf("Want: %v, Got: %v", nil, err)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Want: %v, Got: %v", nil, err)
+			}
+		})
+	}
+}
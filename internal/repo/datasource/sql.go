@@ -2,54 +2,116 @@ package datasource
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/go-sql-driver/mysql"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/render"
+	"sort"
 	"strings"
+	"time"
 )
 
 type sqlDs struct {
-	sqlSvc *sql.DB
-	table  string
+	sqlSvc       *sql.DB
+	table        string
+	tagTable     string
+	authorTable  string
+	slugTable    string
+	commentTable string
+	// revisionTable receives a revision with every change of an article's title and content
+	revisionTable string
+	viewTable     string
+	// translationTable holds the translations loaded with every article
+	translationTable string
 }
 
-// NewSql creates a new instance of sqlDs with a given database service and table name.
-func NewSql(dbSvc config.DbSvc, tableName string) DataSourceI {
+// NewSql creates a new instance of sqlDs with a given database service, taking the article table and the tables
+// joined to it from the database configuration.
+func NewSql(dbSvc config.DbSvc, db config.DbCfg) DataSourceI {
 	return &sqlDs{
-		sqlSvc: dbSvc.Db,
-		table:  tableName,
+		sqlSvc:           dbSvc.Db,
+		table:            db.TableName,
+		tagTable:         db.TagTable(),
+		authorTable:      db.AuthorTable(),
+		slugTable:        db.SlugTable(),
+		revisionTable:    db.RevisionTable(),
+		commentTable:     db.CommentTable(),
+		viewTable:        db.ViewTable(),
+		translationTable: db.TranslationTable(),
 	}
 }
 
-// queryFromMap returns a string containing SQL queries generated from a given map of filters and a join separator.
-func queryFromMap(d map[string]interface{}, join string) string {
+// assignmentsFromMap returns "column = ?" placeholders for the given values, sorted by column, and their arguments.
+func assignmentsFromMap(d map[string]interface{}) ([]string, []interface{}) {
+	columns := make([]string, 0, len(d))
+	for k := range d {
+		columns = append(columns, k)
+	}
+	sort.Strings(columns)
 	var (
-		q string
-		f []string
+		f    []string
+		args []interface{}
 	)
-	for k, v := range d {
-		switch v.(type) {
-		case string:
-			f = append(f, fmt.Sprintf(`%s = '%s'`, k, v))
-		default:
-			f = append(f, fmt.Sprintf(`%s = %v`, k, v))
-		}
+	for _, k := range columns {
+		f = append(f, fmt.Sprintf("%s = ?", k))
+		args = append(args, d[k])
 	}
-	if len(f) > 0 {
-		q = fmt.Sprintf(`%s`, strings.Join(f, ` `+join+` `))
+	return f, args
+}
+
+// mysqlDuplicateKey is the number of MySQL's error for a write violating a unique key
+const mysqlDuplicateKey = 1062
+
+// slugTaken returns ErrSlugTaken when err is a violation of the unique slug of the article table, err otherwise
+func slugTaken(err error) error {
+	var mysqlErr *mysql.MySQLError
+	// the key is named slug, or <table>.slug since MySQL 8
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateKey && strings.HasSuffix(mysqlErr.Message, "slug'") {
+		return ErrSlugTaken
 	}
-	return q
+	return err
 }
 
+// articleColumns are the columns scanned by scanArticles, in order
+const articleColumns = "id, title, author, author_id, content, content_format, content_html, locale, status, version, category_id, slug, created_at, updated_at, publish_at, unpublish_at"
+
 // Get retrieves transactions from the database service based on a given set of filters, limit, and offset.
+// A model.TagFilter under model.FilterTags restricts the articles to the tagged ones and
+// category ids under model.FilterCategories to the articles of those categories, article ids under model.FilterIds
+// to those articles.
 func (d sqlDs) Get(filter map[string]interface{}, limit int, offset int) ([]model.ArticleDs, error) {
-	var article model.ArticleDs
-	var articles []model.ArticleDs
-	q := fmt.Sprintf("SELECT id, title, author, content FROM %s", d.table)
-	whereQuery := queryFromMap(filter, " AND ")
-	if whereQuery != "" {
-		whereQuery = " WHERE " + whereQuery
-		q += whereQuery
+	q := fmt.Sprintf("SELECT %s FROM %s", articleColumns, d.table)
+	tags, _ := filter[model.FilterTags].(model.TagFilter)
+	categories, hasCategories := filter[model.FilterCategories].([]string)
+	ids, hasIds := filter[model.FilterIds].([]string)
+	columns := make(map[string]interface{}, len(filter))
+	for k, v := range filter {
+		if k != model.FilterTags && k != model.FilterCategories && k != model.FilterIds {
+			columns[k] = v
+		}
+	}
+	where, args := assignmentsFromMap(columns)
+	if hasCategories {
+		where = append(where, fmt.Sprintf("category_id IN (%s)", placeholders(len(categories))))
+		for _, id := range categories {
+			args = append(args, id)
+		}
+	}
+	if hasIds {
+		where = append(where, fmt.Sprintf("id IN (%s)", placeholders(len(ids))))
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+	if len(tags.Tags) > 0 {
+		clause, tagArgs := d.tagClause(tags)
+		where = append(where, clause)
+		args = append(args, tagArgs...)
+	}
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
 	}
 	//sort based on created at
 	r := " ORDER BY created_at DESC;"
@@ -57,27 +119,406 @@ func (d sqlDs) Get(filter map[string]interface{}, limit int, offset int) ([]mode
 		r = fmt.Sprintf(" ORDER BY title LIMIT %d OFFSET %d ;", limit, offset)
 	}
 	q += r
-	rows, err := d.sqlSvc.Query(q)
+	rows, err := d.sqlSvc.Query(q, args...)
 	if err != nil {
 		return nil, err
 	}
+	articles, err := scanArticles(rows)
+	if err != nil || len(articles) == 0 {
+		return articles, err
+	}
+	if err = d.loadTags(articles); err != nil {
+		return nil, err
+	}
+	if err = d.loadAuthors(articles); err != nil {
+		return nil, err
+	}
+	if err = d.loadCommentCounts(articles); err != nil {
+		return nil, err
+	}
+	if err = d.loadViewCounts(articles); err != nil {
+		return nil, err
+	}
+	return articles, d.loadTranslations(articles)
+}
+
+// tagClause matches the articles carrying any of the filter's tags, or all of them
+func (d sqlDs) tagClause(f model.TagFilter) (string, []interface{}) {
+	args := make([]interface{}, 0, len(f.Tags)+1)
+	for _, tag := range f.Tags {
+		args = append(args, tag)
+	}
+	clause := fmt.Sprintf("id IN (SELECT article_id FROM %s WHERE tag IN (%s)", d.tagTable, placeholders(len(f.Tags)))
+	if f.All {
+		clause += " GROUP BY article_id HAVING COUNT(*) = ?"
+		args = append(args, len(f.Tags))
+	}
+	return clause + ")", args
+}
+
+// loadTags fills in the tags of the given articles with a single query
+func (d sqlDs) loadTags(articles []model.ArticleDs) error {
+	index := make(map[string]int, len(articles))
+	args := make([]interface{}, 0, len(articles))
+	for i, article := range articles {
+		index[article.Id] = i
+		args = append(args, article.Id)
+	}
+	rows, err := d.sqlSvc.Query(fmt.Sprintf("SELECT article_id, tag FROM %s WHERE article_id IN (%s) ORDER BY tag", d.tagTable, placeholders(len(args))), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 	for rows.Next() {
-		err = rows.Scan(&article.Id, &article.Title, &article.Author, &article.Content)
+		var id, tag string
+		if err = rows.Scan(&id, &tag); err != nil {
+			return err
+		}
+		if i, ok := index[id]; ok {
+			articles[i].Tags = append(articles[i].Tags, tag)
+		}
+	}
+	return rows.Err()
+}
+
+// loadAuthors replaces the author name stored on the given articles with the name of their author profile
+func (d sqlDs) loadAuthors(articles []model.ArticleDs) error {
+	var args []interface{}
+	seen := map[string]bool{}
+	for _, article := range articles {
+		if article.AuthorId != "" && !seen[article.AuthorId] {
+			seen[article.AuthorId] = true
+			args = append(args, article.AuthorId)
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	rows, err := d.sqlSvc.Query(fmt.Sprintf("SELECT id, name FROM %s WHERE id IN (%s)", d.authorTable, placeholders(len(args))), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	names := make(map[string]string, len(args))
+	for rows.Next() {
+		var id, name string
+		if err = rows.Scan(&id, &name); err != nil {
+			return err
+		}
+		names[id] = name
+	}
+	for i := range articles {
+		if name, ok := names[articles[i].AuthorId]; ok {
+			articles[i].Author = name
+		}
+	}
+	return rows.Err()
+}
+
+// loadCommentCounts fills in the number of approved comments of the given articles with a single query
+func (d sqlDs) loadCommentCounts(articles []model.ArticleDs) error {
+	index := make(map[string]int, len(articles))
+	args := make([]interface{}, 0, len(articles)+1)
+	args = append(args, model.CommentApproved)
+	for i, article := range articles {
+		index[article.Id] = i
+		args = append(args, article.Id)
+	}
+	rows, err := d.sqlSvc.Query(fmt.Sprintf("SELECT article_id, COUNT(*) FROM %s WHERE status = ? AND article_id IN (%s) GROUP BY article_id", d.commentTable, placeholders(len(articles))), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id    string
+			count int
+		)
+		if err = rows.Scan(&id, &count); err != nil {
+			return err
+		}
+		if i, ok := index[id]; ok {
+			articles[i].CommentCount = count
+		}
+	}
+	return rows.Err()
+}
+
+// loadViewCounts fills in the total views of the given articles with a single query
+func (d sqlDs) loadViewCounts(articles []model.ArticleDs) error {
+	index := make(map[string]int, len(articles))
+	args := make([]interface{}, 0, len(articles))
+	for i, article := range articles {
+		index[article.Id] = i
+		args = append(args, article.Id)
+	}
+	rows, err := d.sqlSvc.Query(fmt.Sprintf("SELECT article_id, views FROM %s WHERE article_id IN (%s)", d.viewTable, placeholders(len(articles))), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id    string
+			views int64
+		)
+		if err = rows.Scan(&id, &views); err != nil {
+			return err
+		}
+		if i, ok := index[id]; ok {
+			articles[i].ViewCount = views
+		}
+	}
+	return rows.Err()
+}
+
+// loadTranslations lists the locales of every article, its own first, and keeps its translations by locale
+func (d sqlDs) loadTranslations(articles []model.ArticleDs) error {
+	index := make(map[string]int, len(articles))
+	args := make([]interface{}, 0, len(articles))
+	for i, article := range articles {
+		index[article.Id] = i
+		args = append(args, article.Id)
+		articles[i].Translations = []string{article.Locale}
+	}
+	rows, err := d.sqlSvc.Query(fmt.Sprintf("SELECT article_id, locale, title, content, content_format, content_html, created_at, updated_at FROM %s WHERE article_id IN (%s) ORDER BY locale", d.translationTable, placeholders(len(articles))), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t model.TranslationDs
+		if err = rows.Scan(&t.ArticleId, &t.Locale, &t.Title, &t.Content, &t.ContentFormat, &t.ContentHtml, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return err
+		}
+		i, ok := index[t.ArticleId]
+		if !ok || t.Locale == articles[i].Locale {
+			continue
+		}
+		if articles[i].Localized == nil {
+			articles[i].Localized = map[string]model.TranslationDs{}
+		}
+		articles[i].Localized[t.Locale] = t
+		articles[i].Translations = append(articles[i].Translations, t.Locale)
+	}
+	return rows.Err()
+}
+
+// setTags replaces the tags of an article
+func (d sqlDs) setTags(tx execer, id string, tags []string) error {
+	_, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE article_id = ?", d.tagTable), id)
+	if err != nil || len(tags) == 0 {
+		return err
+	}
+	values := make([]string, 0, len(tags))
+	args := make([]interface{}, 0, 2*len(tags))
+	for _, tag := range tags {
+		values = append(values, "(?,?)")
+		args = append(args, id, tag)
+	}
+	_, err = tx.Exec(fmt.Sprintf("INSERT INTO %s(article_id, tag) VALUES%s", d.tagTable, strings.Join(values, ",")), args...)
+	return err
+}
+
+// GetTagCounts counts the published articles of every tag, most used first.
+func (d sqlDs) GetTagCounts() ([]model.TagCount, error) {
+	q := fmt.Sprintf("SELECT t.tag, COUNT(*) FROM %s t JOIN %s a ON a.id = t.article_id WHERE a.status = ? GROUP BY t.tag ORDER BY COUNT(*) DESC, t.tag;", d.tagTable, d.table)
+	rows, err := d.sqlSvc.Query(q, model.StatusPublished)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var counts []model.TagCount
+	for rows.Next() {
+		var count model.TagCount
+		if err = rows.Scan(&count.Tag, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
+
+// GetSlugOwners returns base and the slugs of the form base-<suffix>, current or former, with the id of their article.
+func (d sqlDs) GetSlugOwners(base string) (map[string]string, error) {
+	// slugs are made of [a-z0-9-] only, so base holds no LIKE wildcard
+	q := fmt.Sprintf("SELECT slug, id FROM %s WHERE slug = ? OR slug LIKE ? UNION ALL SELECT slug, article_id FROM %s WHERE slug = ? OR slug LIKE ?", d.table, d.slugTable)
+	rows, err := d.sqlSvc.Query(q, base, base+"-%", base, base+"-%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	owners := map[string]string{}
+	for rows.Next() {
+		var slug, id string
+		if err = rows.Scan(&slug, &id); err != nil {
+			return nil, err
+		}
+		owners[slug] = id
+	}
+	return owners, rows.Err()
+}
+
+// GetSlugRedirect returns the id of the article a former slug belongs to, or "" for an unknown slug.
+func (d sqlDs) GetSlugRedirect(slug string) (string, error) {
+	var id string
+	err := d.sqlSvc.QueryRow(fmt.Sprintf("SELECT article_id FROM %s WHERE slug = ?", d.slugTable), slug).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return id, err
+}
+
+// recordSlug keeps a former slug of an article, a slug an article gets back is simply kept again.
+func (d sqlDs) recordSlug(tx execer, id string, slug string) error {
+	_, err := tx.Exec(fmt.Sprintf("INSERT INTO %s(slug, article_id) VALUES(?,?) ON DUPLICATE KEY UPDATE article_id = VALUES(article_id)", d.slugTable), slug, id)
+	return err
+}
+
+// execer runs statements on either the database or a transaction
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// placeholders returns n comma separated "?"
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+// GetDue retrieves the approved articles whose publish_at and the published articles whose unpublish_at has passed.
+func (d sqlDs) GetDue(now time.Time) ([]model.ArticleDs, error) {
+	q := fmt.Sprintf("SELECT %s FROM %s WHERE (publish_at <= ? AND status = ?) OR (unpublish_at <= ? AND status = ?);", articleColumns, d.table)
+	rows, err := d.sqlSvc.Query(q, now, model.StatusApproved, now, model.StatusPublished)
+	if err != nil {
+		return nil, err
+	}
+	return scanArticles(rows)
+}
+
+func scanArticles(rows *sql.Rows) ([]model.ArticleDs, error) {
+	defer rows.Close()
+	var articles []model.ArticleDs
+	for rows.Next() {
+		var (
+			article                model.ArticleDs
+			categoryId, slug       sql.NullString
+			contentHtml            sql.NullString
+			publishAt, unpublishAt sql.NullTime
+		)
+		err := rows.Scan(&article.Id, &article.Title, &article.Author, &article.AuthorId, &article.Content, &article.ContentFormat, &contentHtml, &article.Locale, &article.Status, &article.Version, &categoryId, &slug, &article.CreatedAt, &article.UpdatedAt, &publishAt, &unpublishAt)
 		if err != nil {
 			return nil, err
 		}
+		article.Slug = slug.String
+		article.ContentHtml = contentHtml.String
+		if !contentHtml.Valid {
+			// written before content was rendered on write
+			article.ContentHtml = render.Html(article.ContentFormat, article.Content)
+		}
+		if categoryId.Valid {
+			article.CategoryId = &categoryId.String
+		}
+		article.PublishAt = nullTime(publishAt)
+		article.UnpublishAt = nullTime(unpublishAt)
 		articles = append(articles, article)
 	}
-	rows.Close()
-	return articles, nil
+	return articles, rows.Err()
 }
 
-// Insert adds a new transaction to the database service.
-func (d sqlDs) Insert(article model.ArticleDs) error {
+// Insert adds a new article with its tags and first revision in one transaction, creating the profile of its author
+// on their first article.
+func (d sqlDs) Insert(article model.ArticleDs, revision model.RevisionDs) error {
+	tx, err := d.sqlSvc.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if article.AuthorId != "" {
+		_, err = tx.Exec(fmt.Sprintf("INSERT IGNORE INTO %s(id, name, bio) VALUES(?,?,'')", d.authorTable), article.AuthorId, article.Author)
+		if err != nil {
+			return err
+		}
+	}
 	queryString := fmt.Sprintf("INSERT INTO %s", d.table)
-	_, err := d.sqlSvc.Exec(queryString+"(id, title, author, content) VALUES(?,?,?,?)", article.Id, article.Title, article.Author, article.Content)
+	_, err = tx.Exec(queryString+"(id, title, slug, author, author_id, content, content_format, content_html, locale, status) VALUES(?,?,?,?,?,?,?,?,?,?)", article.Id, article.Title, article.Slug, article.Author, article.AuthorId, article.Content, article.ContentFormat, article.ContentHtml, article.Locale, article.Status)
 	if err != nil {
+		return slugTaken(err)
+	}
+	if err = d.insertRevision(tx, revision); err != nil {
 		return err
 	}
+	if len(article.Tags) > 0 {
+		if err = d.setTags(tx, article.Id, article.Tags); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Update sets the given columns of the article with the given id and bumps its version.
+func (d sqlDs) Update(id string, fields map[string]interface{}) error {
+	set, args := assignmentsFromMap(fields)
+	args = append(args, id)
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("UPDATE %s SET %s, version = version + 1 WHERE id = ?", d.table, strings.Join(set, ", ")), args...)
 	return err
 }
+
+// Edit updates the article, keeps its former slug, stores the revision and replaces the tags in one transaction,
+// so a failed change leaves nothing behind. With a version the update applies only while the article is still at
+// that version, the check and the write happen in one statement so two concurrent writers cannot both succeed.
+func (d sqlDs) Edit(id string, edit model.ArticleEdit) (bool, error) {
+	tx, err := d.sqlSvc.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	set, args := assignmentsFromMap(edit.Fields)
+	q := fmt.Sprintf("UPDATE %s SET %s, version = version + 1 WHERE id = ?", d.table, strings.Join(set, ", "))
+	args = append(args, id)
+	if edit.Version > 0 {
+		q += " AND version = ?"
+		args = append(args, edit.Version)
+	}
+	res, err := tx.Exec(q, args...)
+	if err != nil {
+		return false, slugTaken(err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	if edit.FormerSlug != "" {
+		if err = d.recordSlug(tx, id, edit.FormerSlug); err != nil {
+			return false, err
+		}
+	}
+	if err = d.insertRevision(tx, edit.Revision); err != nil {
+		return false, err
+	}
+	if edit.Tags != nil {
+		if err = d.setTags(tx, id, edit.Tags); err != nil {
+			return false, err
+		}
+	}
+	return true, tx.Commit()
+}
+
+// insertRevision stores revision numbered with the article's version, its Rev is ignored. It runs in the
+// transaction that wrote the article, whose row stays locked until commit, so no other change can take the number.
+func (d sqlDs) insertRevision(tx execer, revision model.RevisionDs) error {
+	q := fmt.Sprintf("INSERT INTO %s(article_id, rev, title, content, content_format, author, editor) SELECT id, version, ?, ?, ?, ?, ? FROM %s WHERE id = ?", d.revisionTable, d.table)
+	_, err := tx.Exec(q, revision.Title, revision.Content, revision.ContentFormat, revision.Author, revision.Editor, revision.ArticleId)
+	return err
+}
+
+// UpdateIfStatus is Update applied only while the article is still in the given status, so a workflow action
+// cannot overwrite one taken concurrently.
+func (d sqlDs) UpdateIfStatus(id string, status string, fields map[string]interface{}) (bool, error) {
+	set, args := assignmentsFromMap(fields)
+	args = append(args, id, status)
+	res, err := d.sqlSvc.Exec(fmt.Sprintf("UPDATE %s SET %s, version = version + 1 WHERE id = ? AND status = ?", d.table, strings.Join(set, ", ")), args...)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -3,15 +3,18 @@ package datasource
 import (
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
 	"reflect"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestSqlDs_Get(t *testing.T) {
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
 	tests := []struct {
 		name      string
 		setupFunc func() (sqlDs, sqlmock.Sqlmock)
@@ -29,18 +32,46 @@ func TestSqlDs_Get(t *testing.T) {
 					t.Fail()
 				}
 				dB := sqlDs{
-					sqlSvc: db,
-					table:  "newTemp",
+					sqlSvc:           db,
+					table:            "newTemp",
+					tagTable:         "newTags",
+					authorTable:      "newAuthors",
+					commentTable:     "newComments",
+					viewTable:        "newViews",
+					translationTable: "newTranslations",
 				}
-				mock.ExpectQuery("SELECT id, title, author, content FROM newTemp WHERE id = '1234' ORDER BY title LIMIT 1 OFFSET 2 ").WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "content"}).AddRow("1", "TITLE", "AUTHOR", "CONTENT"))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, author, author_id, content, content_format, content_html, locale, status, version, category_id, slug, created_at, updated_at, publish_at, unpublish_at FROM newTemp WHERE id = ? ORDER BY title LIMIT 1 OFFSET 2 ")).WithArgs("1234").WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "author_id", "content", "content_format", "content_html", "locale", "status", "version", "category_id", "slug", "created_at", "updated_at", "publish_at", "unpublish_at"}).AddRow("1", "TITLE", "AUTHOR", "user-1", "CONTENT", "markdown", "<p>CONTENT</p>\n", "en", "published", 3, nil, "title", created, updated, created, nil))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT article_id, tag FROM newTags WHERE article_id IN (?) ORDER BY tag")).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag"}).AddRow("1", "db").AddRow("1", "go"))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name FROM newAuthors WHERE id IN (?)")).WithArgs("user-1").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("user-1", "Jane Doe"))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT article_id, COUNT(*) FROM newComments WHERE status = ? AND article_id IN (?) GROUP BY article_id")).WithArgs(model.CommentApproved, "1").WillReturnRows(sqlmock.NewRows([]string{"article_id", "count"}).AddRow("1", 4))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT article_id, views FROM newViews WHERE article_id IN (?)")).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"article_id", "views"}).AddRow("1", 42))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT article_id, locale, title, content, content_format, content_html, created_at, updated_at FROM newTranslations WHERE article_id IN (?) ORDER BY locale")).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"article_id", "locale", "title", "content", "content_format", "content_html", "created_at", "updated_at"}).AddRow("1", "en", "STALE", "STALE", "plain", "", created, updated).AddRow("1", "fr", "TITRE", "CONTENU", "markdown", "<p>CONTENU</p>\n", created, updated))
 				return dB, mock
 			},
 			validator: func(rows []model.ArticleDs, err error, mock sqlmock.Sqlmock) {
 				temp := []model.ArticleDs{{
-					Id:      "1",
-					Title:   "TITLE",
-					Author:  "AUTHOR",
-					Content: "CONTENT",
+					Id:            "1",
+					Title:         "TITLE",
+					Author:        "Jane Doe",
+					AuthorId:      "user-1",
+					Content:       "CONTENT",
+					ContentFormat: "markdown",
+					ContentHtml:   "<p>CONTENT</p>\n",
+					Locale:        "en",
+					Translations:  []string{"en", "fr"},
+					Localized: map[string]model.TranslationDs{"fr": {
+						ArticleId: "1", Locale: "fr", Title: "TITRE", Content: "CONTENU", ContentFormat: "markdown",
+						ContentHtml: "<p>CONTENU</p>\n", CreatedAt: created, UpdatedAt: updated,
+					}},
+					Status:       "published",
+					Version:      3,
+					Tags:         []string{"db", "go"},
+					Slug:         "title",
+					CommentCount: 4,
+					ViewCount:    42,
+					CreatedAt:    created,
+					UpdatedAt:    updated,
+					PublishAt:    &created,
 				}}
 				if mock.ExpectationsWereMet() != nil {
 					t.Errorf("Want: %v, Got: %v", nil, mock.ExpectationsWereMet())
@@ -56,6 +87,33 @@ func TestSqlDs_Get(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "SUCCESS::Get:: all tags",
+			filter: map[string]interface{}{"status": "published", model.FilterTags: model.TagFilter{Tags: []string{"db", "go"}, All: true}},
+			setupFunc: func() (sqlDs, sqlmock.Sqlmock) {
+				db, mock, err := sqlmock.New()
+				if err != nil {
+					t.Fail()
+				}
+				dB := sqlDs{
+					sqlSvc:   db,
+					table:    "newTemp",
+					tagTable: "newTags",
+				}
+				mock.ExpectQuery(regexp.QuoteMeta("FROM newTemp WHERE status = ? AND id IN (SELECT article_id FROM newTags WHERE tag IN (?,?) GROUP BY article_id HAVING COUNT(*) = ?) ORDER BY title LIMIT 1 OFFSET 2 ;")).
+					WithArgs("published", "db", "go", 2).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				return dB, mock
+			},
+			validator: func(rows []model.ArticleDs, err error, mock sqlmock.Sqlmock) {
+				if mock.ExpectationsWereMet() != nil {
+					t.Errorf("Want: %v, Got: %v", nil, mock.ExpectationsWereMet())
+					return
+				}
+				if err != nil || len(rows) != 0 {
+					t.Errorf("Want: %v, Got: %v %v", nil, rows, err)
+				}
+			},
+		},
 		{
 			name:   "FAILURE::Get:: get rows query error",
 			filter: map[string]interface{}{"userid": "1234"},
@@ -68,7 +126,7 @@ func TestSqlDs_Get(t *testing.T) {
 					sqlSvc: db,
 					table:  "newTemp",
 				}
-				mock.ExpectQuery("SELECT id, title, author, content FROM newTemp WHERE userid = '1234' ORDER BY title LIMIT 1 OFFSET 2 ;").WillReturnError(errors.New("Unknown column"))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, author, author_id, content, content_format, content_html, locale, status, version, category_id, slug, created_at, updated_at, publish_at, unpublish_at FROM newTemp WHERE userid = ? ORDER BY title LIMIT 1 OFFSET 2 ;")).WithArgs("1234").WillReturnError(errors.New("Unknown column"))
 				return dB, mock
 			},
 			validator: func(rows []model.ArticleDs, err error, mock sqlmock.Sqlmock) {
@@ -99,7 +157,6 @@ func TestSqlDs_Get(t *testing.T) {
 	}
 }
 
-//
 func TestSqlDs_Insert(t *testing.T) {
 	// table driven tests
 	tests := []struct {
@@ -111,9 +168,11 @@ func TestSqlDs_Insert(t *testing.T) {
 		{
 			name: "SUCCESS:: Insert Transaction",
 			data: model.ArticleDs{
+				Id:      "1",
 				Title:   "TITLE",
 				Author:  "AUTHOR",
 				Content: "CONTENT",
+				Tags:    []string{"go", "db"},
 			},
 			setupFunc: func() (sqlDs, sqlmock.Sqlmock) {
 				db, mock, err := sqlmock.New()
@@ -121,12 +180,19 @@ func TestSqlDs_Insert(t *testing.T) {
 					t.Fail()
 				}
 				dB := sqlDs{
-					sqlSvc: db,
-					table:  "newTemp",
+					sqlSvc:        db,
+					table:         "newTemp",
+					tagTable:      "newTags",
+					revisionTable: "newRevisions",
 				}
-				m := mock.ExpectExec(regexp.QuoteMeta("INSERT INTO newTemp(id, title, author, content) VALUES(?,?,?,?)")).WithArgs(sqlmock.AnyArg(), "TITLE", "AUTHOR", "CONTENT")
+				mock.ExpectBegin()
+				m := mock.ExpectExec(regexp.QuoteMeta("INSERT INTO newTemp(id, title, slug, author, author_id, content, content_format, content_html, locale, status) VALUES(?,?,?,?,?,?,?,?,?,?)")).WithArgs("1", "TITLE", "", "AUTHOR", "", "CONTENT", "", "", "", "")
 				m.WillReturnError(nil)
 				m.WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO newRevisions(article_id, rev, title, content, content_format, author, editor) SELECT id, version, ?, ?, ?, ?, ? FROM newTemp WHERE id = ?")).WithArgs("TITLE", "CONTENT", "markdown", "AUTHOR", "user-1", "1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM newTags WHERE article_id = ?")).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO newTags(article_id, tag) VALUES(?,?),(?,?)")).WithArgs("1", "go", "1", "db").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
 				return dB, mock
 			},
 			validator: func(mock sqlmock.Sqlmock, err error) {
@@ -141,6 +207,43 @@ func TestSqlDs_Insert(t *testing.T) {
 
 			},
 		},
+		{
+			name: "SUCCESS:: Insert:: creates the author profile",
+			data: model.ArticleDs{
+				Id:       "1",
+				Title:    "TITLE",
+				Author:   "Jane Doe",
+				AuthorId: "user-1",
+				Content:  "CONTENT",
+			},
+			setupFunc: func() (sqlDs, sqlmock.Sqlmock) {
+				db, mock, err := sqlmock.New()
+				if err != nil {
+					t.Fail()
+				}
+				dB := sqlDs{
+					sqlSvc:        db,
+					table:         "newTemp",
+					authorTable:   "newAuthors",
+					revisionTable: "newRevisions",
+				}
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO newAuthors(id, name, bio) VALUES(?,?,'')")).WithArgs("user-1", "Jane Doe").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO newTemp(id, title, slug, author, author_id, content, content_format, content_html, locale, status) VALUES(?,?,?,?,?,?,?,?,?,?)")).WithArgs("1", "TITLE", "", "Jane Doe", "user-1", "CONTENT", "", "", "", "").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO newRevisions(article_id, rev, title, content, content_format, author, editor) SELECT id, version, ?, ?, ?, ?, ? FROM newTemp WHERE id = ?")).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return dB, mock
+			},
+			validator: func(mock sqlmock.Sqlmock, err error) {
+				if err != nil {
+					t.Errorf("Want: %v, Got: %v", nil, err.Error())
+					return
+				}
+				if mock.ExpectationsWereMet() != nil {
+					t.Errorf("Want: %v, Got: %v", nil, mock.ExpectationsWereMet())
+				}
+			},
+		},
 		{
 			name: "FAILURE:: insert :: sql error",
 			data: model.ArticleDs{
@@ -157,9 +260,12 @@ func TestSqlDs_Insert(t *testing.T) {
 					sqlSvc: db,
 					table:  "newTemp",
 				}
-				m := mock.ExpectExec(regexp.QuoteMeta("INSERT INTO newTemp(id, title, author, content) VALUES(?,?,?,?)")).WithArgs(sqlmock.AnyArg(), "TITLE", "AUTHOR", "CONTENT")
+				mock.ExpectBegin()
+				m := mock.ExpectExec(regexp.QuoteMeta("INSERT INTO newTemp(id, title, slug, author, author_id, content, content_format, content_html, locale, status) VALUES(?,?,?,?,?,?,?,?,?,?)")).WithArgs(sqlmock.AnyArg(), "TITLE", "", "AUTHOR", "", "CONTENT", "", "", "", "")
 				m.WillReturnError(errors.New("sql error"))
 				m.WillReturnResult(sqlmock.NewResult(1, 1))
+				// nothing is kept of a failed insert
+				mock.ExpectRollback()
 				return dB, mock
 			},
 			validator: func(mock sqlmock.Sqlmock, err error) {
@@ -179,7 +285,7 @@ func TestSqlDs_Insert(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			db, mock := tt.setupFunc()
 			// STEP 2: call the test function
-			err := db.Insert(tt.data)
+			err := db.Insert(tt.data, model.RevisionDs{ArticleId: "1", Title: "TITLE", Content: "CONTENT", ContentFormat: "markdown", Author: "AUTHOR", Editor: "user-1"})
 			// STEP 3: validation of output
 			if tt.validator != nil {
 				tt.validator(mock, err)
@@ -187,3 +293,238 @@ func TestSqlDs_Insert(t *testing.T) {
 		})
 	}
 }
+
+func TestSqlDs_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE newTemp SET content = ?, title = ?, version = version + 1 WHERE id = ?")).
+		WithArgs("CONTENT", "TITLE", "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err = sqlDs{sqlSvc: db, table: "newTemp"}.Update("1", map[string]interface{}{"title": "TITLE", "content": "CONTENT"})
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
+
+func TestSqlDs_GetDue(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, author, author_id, content, content_format, content_html, locale, status, version, category_id, slug, created_at, updated_at, publish_at, unpublish_at FROM newTemp WHERE (publish_at <= ? AND status = ?) OR (unpublish_at <= ? AND status = ?);")).
+		WithArgs(now, model.StatusApproved, now, model.StatusPublished).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "author_id", "content", "content_format", "content_html", "locale", "status", "version", "category_id", "slug", "created_at", "updated_at", "publish_at", "unpublish_at"}).
+			AddRow("1", "TITLE", "AUTHOR", "user-1", "CONTENT", "plain", nil, "en", model.StatusApproved, 1, nil, nil, now, now, now, nil))
+	rows, err := sqlDs{sqlSvc: db, table: "newTemp"}.GetDue(now)
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	if len(rows) != 1 || rows[0].PublishAt == nil || !rows[0].PublishAt.Equal(now) || rows[0].UnpublishAt != nil {
+		t.Errorf("Want: %v, Got: %v", "one due approved article", rows)
+	}
+	// articles written before content was rendered on write are rendered on read
+	if want := "<p>CONTENT</p>\n"; len(rows) == 1 && rows[0].ContentHtml != want {
+		t.Errorf("Want: %v, Got: %v", want, rows[0].ContentHtml)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
+
+func TestSqlDs_Edit(t *testing.T) {
+	update := regexp.QuoteMeta("UPDATE newTemp SET slug = ?, title = ?, version = version + 1 WHERE id = ? AND version = ?")
+	edit := model.ArticleEdit{
+		Fields:     map[string]interface{}{"title": "TITLE", "slug": "title"},
+		Version:    3,
+		FormerSlug: "old",
+		Tags:       []string{"go", "db"},
+		Revision:   model.RevisionDs{ArticleId: "1", Title: "TITLE", Content: "CONTENT", ContentFormat: "markdown", Author: "AUTHOR", Editor: "user-1"},
+	}
+	tests := []struct {
+		name        string
+		setupFunc   func(sqlmock.Sqlmock)
+		wantUpdated bool
+		wantErr     bool
+	}{
+		{
+			name: "SUCCESS::Edit",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(update).WithArgs("title", "TITLE", "1", 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO newSlugs(slug, article_id) VALUES(?,?) ON DUPLICATE KEY UPDATE article_id = VALUES(article_id)")).
+					WithArgs("old", "1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO newRevisions(article_id, rev, title, content, content_format, author, editor) SELECT id, version, ?, ?, ?, ?, ? FROM newTemp WHERE id = ?")).
+					WithArgs("TITLE", "CONTENT", "markdown", "AUTHOR", "user-1", "1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM newTags WHERE article_id = ?")).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO newTags(article_id, tag) VALUES(?,?),(?,?)")).WithArgs("1", "go", "1", "db").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			wantUpdated: true,
+		},
+		{
+			name: "SUCCESS::Edit::version changed",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(update).WithArgs("title", "TITLE", "1", 3).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name: "FAILURE::Edit::revision not stored",
+			setupFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(update).WithArgs("title", "TITLE", "1", 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO newSlugs(slug, article_id)")).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO newRevisions")).WillReturnError(errors.New("error"))
+				// the article update is rolled back with it
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.setupFunc(mock)
+			ds := sqlDs{sqlSvc: db, table: "newTemp", tagTable: "newTags", slugTable: "newSlugs", revisionTable: "newRevisions"}
+			updated, err := ds.Edit("1", edit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Want: %v, Got: %v", tt.wantErr, err)
+			}
+			if updated != tt.wantUpdated {
+				t.Errorf("Want: %v, Got: %v", tt.wantUpdated, updated)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Want: %v, Got: %v", nil, err)
+			}
+		})
+	}
+}
+
+func TestSqlDs_GetTagCounts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT t.tag, COUNT(*) FROM newTags t JOIN newTemp a ON a.id = t.article_id WHERE a.status = ? GROUP BY t.tag ORDER BY COUNT(*) DESC, t.tag;")).
+		WithArgs(model.StatusPublished).
+		WillReturnRows(sqlmock.NewRows([]string{"tag", "count"}).AddRow("go", 3).AddRow("db", 1))
+	got, err := sqlDs{sqlSvc: db, table: "newTemp", tagTable: "newTags"}.GetTagCounts()
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	want := []model.TagCount{{Tag: "go", Count: 3}, {Tag: "db", Count: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
+
+func TestSqlDs_Slugs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := sqlDs{sqlSvc: db, table: "newTemp", slugTable: "newSlugs"}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT slug, id FROM newTemp WHERE slug = ? OR slug LIKE ? UNION ALL SELECT slug, article_id FROM newSlugs WHERE slug = ? OR slug LIKE ?")).
+		WithArgs("go", "go-%", "go", "go-%").
+		WillReturnRows(sqlmock.NewRows([]string{"slug", "id"}).AddRow("go", "1").AddRow("go-2", "2"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT article_id FROM newSlugs WHERE slug = ?")).
+		WithArgs("old").
+		WillReturnRows(sqlmock.NewRows([]string{"article_id"}).AddRow("1"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT article_id FROM newSlugs WHERE slug = ?")).
+		WithArgs("unknown").
+		WillReturnRows(sqlmock.NewRows([]string{"article_id"}))
+
+	owners, err := ds.GetSlugOwners("go")
+	if want := map[string]string{"go": "1", "go-2": "2"}; err != nil || !reflect.DeepEqual(want, owners) {
+		t.Errorf("Want: %v, Got: %v %v", want, owners, err)
+	}
+	if id, err := ds.GetSlugRedirect("old"); err != nil || id != "1" {
+		t.Errorf("Want: %v, Got: %v %v", "1", id, err)
+	}
+	if id, err := ds.GetSlugRedirect("unknown"); err != nil || id != "" {
+		t.Errorf("Want: %v, Got: %v %v", "", id, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
+
+func TestSqlDs_Edit_SlugTaken(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "FAILURE::Edit::slug taken",
+			err:  &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'title' for key 'newTemp.slug'"},
+			want: ErrSlugTaken,
+		},
+		{
+			name: "FAILURE::Edit::slug taken before MySQL 8",
+			err:  &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'title' for key 'slug'"},
+			want: ErrSlugTaken,
+		},
+		{
+			name: "FAILURE::Edit::other error",
+			err:  &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"},
+			want: &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("UPDATE newTemp SET slug = ?")).WillReturnError(tt.err)
+			mock.ExpectRollback()
+			_, err = sqlDs{sqlSvc: db, table: "newTemp"}.Edit("1", model.ArticleEdit{Fields: map[string]interface{}{"slug": "title"}})
+			if !reflect.DeepEqual(err, tt.want) {
+				t.Errorf("Want: %v, Got: %v", tt.want, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Want: %v, Got: %v", nil, err)
+			}
+		})
+	}
+}
+
+func TestSqlDs_UpdateIfStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := regexp.QuoteMeta("UPDATE newTemp SET status = ?, version = version + 1 WHERE id = ? AND status = ?")
+	mock.ExpectExec(query).WithArgs(model.StatusPublished, "1", model.StatusApproved).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(model.StatusPublished, "1", model.StatusApproved).WillReturnResult(sqlmock.NewResult(0, 0))
+	ds := sqlDs{sqlSvc: db, table: "newTemp"}
+	for _, want := range []bool{true, false} {
+		updated, err := ds.UpdateIfStatus("1", model.StatusApproved, map[string]interface{}{"status": model.StatusPublished})
+		if err != nil {
+			t.Errorf("Want: %v, Got: %v", nil, err)
+		}
+		if updated != want {
+			t.Errorf("Want: %v, Got: %v", want, updated)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
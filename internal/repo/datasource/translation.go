@@ -0,0 +1,49 @@
+package datasource
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../../pkg/mock/mock_translation_datasource.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource TranslationDataSourceI
+
+// TranslationDataSourceI writes the translations of articles, they are read together with their article
+type TranslationDataSourceI interface {
+	// PutTranslation adds the translation of an article into a locale or replaces it
+	PutTranslation(t model.TranslationDs) error
+	// DeleteTranslation removes the translation of an article into a locale, reporting whether there was one
+	DeleteTranslation(articleId string, locale string) (bool, error)
+}
+
+type translationSqlDs struct {
+	sqlSvc *sql.DB
+	table  string
+}
+
+// NewTranslationSql creates a new instance of translationSqlDs with a given database service and table name.
+func NewTranslationSql(dbSvc config.DbSvc, tableName string) TranslationDataSourceI {
+	return &translationSqlDs{
+		sqlSvc: dbSvc.Db,
+		table:  tableName,
+	}
+}
+
+// PutTranslation inserts the translation or replaces the title and content of the existing one.
+func (d translationSqlDs) PutTranslation(t model.TranslationDs) error {
+	_, err := d.sqlSvc.Exec(fmt.Sprintf("INSERT INTO %s(article_id, locale, title, content, content_format, content_html) VALUES(?,?,?,?,?,?) "+
+		"ON DUPLICATE KEY UPDATE title = VALUES(title), content = VALUES(content), content_format = VALUES(content_format), content_html = VALUES(content_html)", d.table),
+		t.ArticleId, t.Locale, t.Title, t.Content, t.ContentFormat, t.ContentHtml)
+	return err
+}
+
+// DeleteTranslation removes the translation of the article into locale.
+func (d translationSqlDs) DeleteTranslation(articleId string, locale string) (bool, error) {
+	res, err := d.sqlSvc.Exec(fmt.Sprintf("DELETE FROM %s WHERE article_id = ? AND locale = ?", d.table), articleId, locale)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,40 @@
+package datasource
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	"regexp"
+	"testing"
+)
+
+func TestTranslationSqlDs_PutDeleteTranslation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO article_translations(article_id, locale, title, content, content_format, content_html) VALUES(?,?,?,?,?,?) "+
+		"ON DUPLICATE KEY UPDATE title = VALUES(title), content = VALUES(content), content_format = VALUES(content_format), content_html = VALUES(content_html)")).
+		WithArgs("1", "fr", "Titre", "Contenu", "plain", "<p>Contenu</p>\n").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM article_translations WHERE article_id = ? AND locale = ?")).
+		WithArgs("1", "fr").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM article_translations WHERE article_id = ? AND locale = ?")).
+		WithArgs("1", "de").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ds := translationSqlDs{sqlSvc: db, table: "article_translations"}
+	err = ds.PutTranslation(model.TranslationDs{ArticleId: "1", Locale: "fr", Title: "Titre", Content: "Contenu", ContentFormat: "plain", ContentHtml: "<p>Contenu</p>\n"})
+	if err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	if deleted, err := ds.DeleteTranslation("1", "fr"); err != nil || !deleted {
+		t.Errorf("Want: %v, Got: %v %v", true, deleted, err)
+	}
+	if deleted, err := ds.DeleteTranslation("1", "de"); err != nil || deleted {
+		t.Errorf("Want: %v, Got: %v %v", false, deleted, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
@@ -0,0 +1,50 @@
+package datasource
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"sort"
+	"strings"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../../pkg/mock/mock_view_datasource.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource ViewDataSourceI
+
+type ViewDataSourceI interface {
+	// AddViews adds the given number of views to the totals of the articles
+	AddViews(views map[string]int64) error
+}
+
+type viewSqlDs struct {
+	sqlSvc *sql.DB
+	table  string
+}
+
+// NewViewSql creates a new instance of viewSqlDs with a given database service and table name.
+func NewViewSql(dbSvc config.DbSvc, tableName string) ViewDataSourceI {
+	return &viewSqlDs{
+		sqlSvc: dbSvc.Db,
+		table:  tableName,
+	}
+}
+
+// AddViews adds the views of every article with a single upsert, creating the totals of articles seen for the first time.
+func (d viewSqlDs) AddViews(views map[string]int64) error {
+	if len(views) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(views))
+	for id := range views {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	values := make([]string, 0, len(ids))
+	args := make([]interface{}, 0, 2*len(ids))
+	for _, id := range ids {
+		values = append(values, "(?,?)")
+		args = append(args, id, views[id])
+	}
+	q := fmt.Sprintf("INSERT INTO %s(article_id, views) VALUES%s ON DUPLICATE KEY UPDATE views = views + VALUES(views)", d.table, strings.Join(values, ","))
+	_, err := d.sqlSvc.Exec(q, args...)
+	return err
+}
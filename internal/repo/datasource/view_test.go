@@ -0,0 +1,28 @@
+package datasource
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"regexp"
+	"testing"
+)
+
+func TestViewSqlDs_AddViews(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO article_views(article_id, views) VALUES(?,?),(?,?) ON DUPLICATE KEY UPDATE views = views + VALUES(views)")).
+		WithArgs("1", int64(3), "2", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	ds := viewSqlDs{sqlSvc: db, table: "article_views"}
+	if err = ds.AddViews(map[string]int64{"2": 1, "1": 3}); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	if err = ds.AddViews(nil); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Want: %v, Got: %v", nil, err)
+	}
+}
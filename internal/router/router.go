@@ -1,12 +1,22 @@
 package router
 
 import (
+	"context"
 	"github.com/gorilla/mux"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/auth"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/authz"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/blob"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/handler"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/media"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/middleware"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/ratelimit"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/related"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/cacher"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/scheduler"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/views"
 	"net/http"
 )
 
@@ -14,19 +24,154 @@ func Register(svcCfg *config.SvcConfig) *mux.Router {
 	m := mux.NewRouter()
 
 	m.StrictSlash(true)
-	dataSource := datasource.NewSql(svcCfg.DbSvc, svcCfg.Cfg.DataBase.TableName)
-	svc := handler.NewArticleManagementHandlerI(dataSource)
-	cacher := cacher.NewCacher(svcCfg.CacherSvc)
-	mid := middleware.NewMiddleware(svcCfg, cacher)
+	dataSource := datasource.NewSql(svcCfg.DbSvc, svcCfg.Cfg.DataBase)
+	revisionDs := datasource.NewRevisionSql(svcCfg.DbSvc, svcCfg.Cfg.DataBase.RevisionTable())
+	svc := handler.NewArticleManagementHandlerI(dataSource, revisionDs)
+	cacheSvc := cacher.NewCacher(svcCfg.CacherSvc)
+	if svcCfg.Cfg.Cacher.Local.Enabled {
+		cacheSvc = cacher.NewLocalCacher(svcCfg.Cfg.Cacher.Local, svcCfg.CacherSvc, cacheSvc)
+	}
+	verifier, err := auth.NewJwtVerifier(svcCfg.Cfg.Auth)
+	if err != nil {
+		panic(err.Error())
+	}
+	apiKeyDs := datasource.NewApiKeySql(svcCfg.DbSvc, svcCfg.Cfg.DataBase.ApiKeyTable())
+	apiKeySvc := handler.NewApiKeyHandlerI(apiKeyDs)
+	authorSvc := handler.NewAuthorHandlerI(datasource.NewAuthorSql(svcCfg.DbSvc, svcCfg.Cfg.DataBase.AuthorTable(), svcCfg.Cfg.DataBase.TableName), dataSource)
+	commentSvc := handler.NewCommentHandlerI(datasource.NewCommentSql(svcCfg.DbSvc, svcCfg.Cfg.DataBase.CommentTable()), dataSource)
+	categorySvc := handler.NewCategoryHandlerI(datasource.NewCategorySql(svcCfg.DbSvc, svcCfg.Cfg.DataBase.CategoryTable()), dataSource)
+	store, err := blob.NewStoreI(svcCfg.Cfg.Media.Storage)
+	if err != nil {
+		panic(err.Error())
+	}
+	processor := media.NewProcessorI(svcCfg.Cfg.Media)
+	translationSvc := handler.NewTranslationHandlerI(datasource.NewTranslationSql(svcCfg.DbSvc, svcCfg.Cfg.DataBase.TranslationTable()), dataSource, revisionDs)
+	mediaSvc := handler.NewMediaHandlerI(datasource.NewMediaSql(svcCfg.DbSvc, svcCfg.Cfg.DataBase.MediaTable()), dataSource, revisionDs, store, processor)
+	limiter := ratelimit.NewMemoryLimiter()
+	if svcCfg.CacherSvc.Rdb != nil {
+		limiter = ratelimit.NewRedisLimiter(svcCfg.CacherSvc.Rdb, limiter)
+	}
+	mid := middleware.NewMiddleware(svcCfg, cacheSvc, verifier, auth.NewApiKeyVerifier(apiKeyDs), limiter)
 	m.NotFoundHandler = http.HandlerFunc(svc.RouteNotFound)
 	m.MethodNotAllowedHandler = http.HandlerFunc(svc.MethodNotAllowed)
+	m.Use(mid.Compress)
+	// credentials are throttled per address before they are verified, callers are then budgeted by identity
+	m.Use(mid.ThrottleAuth)
+	m.Use(mid.Authenticate)
+	m.Use(mid.RateLimit)
 
+	// mutating routes always require an authenticated author, ownership is checked by the authz policy
 	router1 := m.PathPrefix("").Subrouter()
 	router1.HandleFunc("/articles", svc.InsertArticle).Methods(http.MethodPost)
+	router1.HandleFunc("/articles/{id}", svc.UpdateArticle).Methods(http.MethodPut)
+	router1.HandleFunc("/articles/{id}/{action:submit|approve|reject|publish|archive}", svc.TransitionArticle).Methods(http.MethodPost)
+	router1.HandleFunc("/articles/{id}/schedule", svc.ScheduleArticle).Methods(http.MethodPut)
+	router1.HandleFunc("/articles/{id}/revisions/{rev}/restore", svc.RestoreRevision).Methods(http.MethodPost)
+	router1.Use(mid.RequireAuth, mid.RequireRole(authz.AtLeast(authz.RoleAuthor)...), mid.Idempotency, mid.Invalidate(middleware.ArticlePaths))
+
+	// the category tree and the primary category of articles are maintained by editors
+	categories := m.PathPrefix("/categories").Subrouter()
+	categories.HandleFunc("", categorySvc.InsertCategory).Methods(http.MethodPost)
+	categories.HandleFunc("/{id}", categorySvc.UpdateCategory).Methods(http.MethodPut)
+	categories.HandleFunc("/{id}", categorySvc.DeleteCategory).Methods(http.MethodDelete)
+	categories.Use(mid.RequireAuth, mid.RequireRole(authz.AtLeast(authz.RoleEditor)...), mid.Idempotency, mid.Invalidate(middleware.CategoryPaths))
+
+	// editors create profiles, authors edit their own, checked by the authz policy
+	authors := m.PathPrefix("/authors").Subrouter()
+	authors.HandleFunc("", authorSvc.InsertAuthor).Methods(http.MethodPost)
+	authors.HandleFunc("/{id}", authorSvc.UpdateAuthor).Methods(http.MethodPut)
+	authors.Use(mid.RequireAuth, mid.RequireRole(authz.AtLeast(authz.RoleAuthor)...), mid.Idempotency, mid.Invalidate(middleware.AuthorPaths))
+
+	// any signed in reader may comment, comments wait for an editor before they are shown
+	comments := m.PathPrefix("/articles/{id}/comments").Subrouter()
+	comments.HandleFunc("", commentSvc.PostComment).Methods(http.MethodPost)
+	comments.Use(mid.RequireAuth, mid.RequireRole(authz.AtLeast(authz.RoleReader)...), mid.Idempotency)
+
+	moderation := m.PathPrefix("").Subrouter()
+	moderation.HandleFunc("/comments", commentSvc.GetModerationQueue).Methods(http.MethodGet)
+	moderation.HandleFunc("/articles/{id}/comments/{comment}/{action:approve|reject}", commentSvc.ModerateComment).Methods(http.MethodPost)
+	moderation.Use(mid.RequireAuth, mid.RequireRole(authz.AtLeast(authz.RoleEditor)...), mid.Idempotency, mid.Invalidate(middleware.CommentPaths))
+
+	// files are attached by whoever may update the article, checked by the authz policy
+	attachments := m.PathPrefix("/articles/{id}/media").Subrouter()
+	attachments.HandleFunc("", mediaSvc.UploadMedia).Methods(http.MethodPost)
+	attachments.HandleFunc("/{media}", mediaSvc.DeleteMedia).Methods(http.MethodDelete)
+	attachments.Use(mid.RequireAuth, mid.RequireRole(authz.AtLeast(authz.RoleAuthor)...), mid.LimitBody(processor.MaxBytes()+handler.MultipartOverhead), mid.Idempotency, mid.Invalidate(middleware.ArticlePaths))
+
+	// translations are written by whoever may update the article, checked by the authz policy
+	translations := m.PathPrefix("/articles/{id}/translations/{lang}").Subrouter()
+	translations.HandleFunc("", translationSvc.PutTranslation).Methods(http.MethodPut)
+	translations.HandleFunc("", translationSvc.DeleteTranslation).Methods(http.MethodDelete)
+	translations.Use(mid.RequireAuth, mid.RequireRole(authz.AtLeast(authz.RoleAuthor)...), mid.Idempotency, mid.Invalidate(middleware.ArticlePaths))
+
+	// stored files are served as they are and validated by their checksum, they are too large for the response cache
+	files := m.PathPrefix("/media/{id}").Subrouter()
+	files.HandleFunc("", mediaSvc.GetMediaFile).Methods(http.MethodGet)
+	files.HandleFunc("/thumbnail", mediaSvc.GetMediaThumbnail).Methods(http.MethodGet)
+	if svcCfg.Cfg.Auth.RequireAuthForReads {
+		files.Use(mid.RequireAuth, mid.RequireRole(authz.AtLeast(authz.RoleReader)...))
+	}
+
+	assign := m.PathPrefix("/articles/{id}/category").Subrouter()
+	assign.HandleFunc("", categorySvc.AssignCategory).Methods(http.MethodPut)
+	assign.Use(mid.RequireAuth, mid.RequireRole(authz.AtLeast(authz.RoleEditor)...), mid.Idempotency, mid.Invalidate(middleware.ArticlePaths))
+
+	// revision history is visible to the article's author and editors only and is never cached
+	revisions := m.PathPrefix("/articles/{id}/revisions").Subrouter()
+	revisions.HandleFunc("", svc.GetRevisions).Methods(http.MethodGet)
+	revisions.HandleFunc("/diff", svc.DiffRevisions).Methods(http.MethodGet)
+	revisions.HandleFunc("/{rev}", svc.GetRevision).Methods(http.MethodGet)
+	revisions.Use(mid.RequireAuth, mid.RequireRole(authz.AtLeast(authz.RoleAuthor)...))
+
+	admin := m.PathPrefix("/admin").Subrouter()
+	admin.HandleFunc("/api-keys", apiKeySvc.CreateApiKey).Methods(http.MethodPost)
+	admin.HandleFunc("/api-keys", apiKeySvc.ListApiKeys).Methods(http.MethodGet)
+	admin.HandleFunc("/api-keys/{id}/rotate", apiKeySvc.RotateApiKey).Methods(http.MethodPost)
+	admin.HandleFunc("/api-keys/{id}", apiKeySvc.RevokeApiKey).Methods(http.MethodDelete)
+	admin.Use(mid.RequireRole(authz.AtLeast(authz.RoleAdmin)...))
+
+	// views are counted in redis, without it there is nothing to rank
+	var counter views.CounterI
+	if svcCfg.Cfg.Views.Enabled && svcCfg.CacherSvc.Rdb != nil {
+		counter = views.NewRedisCounter(svcCfg.CacherSvc.Rdb, svcCfg.Cfg.Views.KeyPrefix, svcCfg.Cfg.Views.DedupeWindowDuration)
+	}
+
+	// every instance keeps its own index of the published articles for ranking related ones
+	relatedIndex := related.NewIndexI()
+	go related.NewRefresherI(relatedIndex, dataSource, svcCfg.Cfg.Related.RefreshIntervalDuration).Run(context.Background())
 
 	router2 := m.PathPrefix("").Subrouter()
+	if counter != nil {
+		// registered before /articles/{id}, which would match it too
+		router2.HandleFunc("/articles/popular", handler.NewViewHandlerI(counter, dataSource).GetPopularArticles).Methods(http.MethodGet)
+	}
 	router2.HandleFunc("/articles/{id}", svc.GetArticleById).Methods(http.MethodGet)
+	router2.HandleFunc("/articles/by-slug/{slug}", svc.GetArticleBySlug).Methods(http.MethodGet)
+	router2.HandleFunc("/articles/{id}/comments", commentSvc.GetComments).Methods(http.MethodGet)
+	router2.HandleFunc("/articles/{id}/related", handler.NewRelatedHandlerI(relatedIndex, dataSource).GetRelatedArticles).Methods(http.MethodGet)
+	router2.HandleFunc("/articles/{id}/media", mediaSvc.GetArticleMedia).Methods(http.MethodGet)
 	router2.HandleFunc("/articles", svc.GetAllArticle).Methods(http.MethodGet)
+	router2.HandleFunc("/tags", svc.GetTags).Methods(http.MethodGet)
+	router2.HandleFunc("/authors", authorSvc.GetAuthors).Methods(http.MethodGet)
+	router2.HandleFunc("/authors/{id}", authorSvc.GetAuthor).Methods(http.MethodGet)
+	router2.HandleFunc("/authors/{id}/articles", authorSvc.GetAuthorArticles).Methods(http.MethodGet)
+	router2.HandleFunc("/categories", categorySvc.GetCategories).Methods(http.MethodGet)
+	router2.HandleFunc("/categories/{id}", categorySvc.GetCategory).Methods(http.MethodGet)
+	router2.HandleFunc("/categories/{id}/articles", categorySvc.GetCategoryArticles).Methods(http.MethodGet)
+	if svcCfg.Cfg.Auth.RequireAuthForReads {
+		router2.Use(mid.RequireAuth, mid.RequireRole(authz.AtLeast(authz.RoleReader)...))
+	}
+	if counter != nil {
+		// counted in front of the cache so that cache hits are views too
+		router2.Use(mid.CountViews(counter))
+		viewDs := datasource.NewViewSql(svcCfg.DbSvc, svcCfg.Cfg.DataBase.ViewTable())
+		go views.NewFlusherI(counter, viewDs, svcCfg.Cfg.Views.FlushIntervalDuration).Run(context.Background())
+	}
 	router2.Use(mid.Cacher)
+
+	if svcCfg.Cfg.Scheduler.Enabled {
+		leaseDs := datasource.NewLeaseSql(svcCfg.DbSvc, svcCfg.Cfg.DataBase.LeaseTable())
+		go scheduler.NewSchedulerI(svcCfg, logic.NewArticleManagementLogicI(dataSource, revisionDs), leaseDs, cacheSvc).Run(context.Background())
+	}
 	return m
 }
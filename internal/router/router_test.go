@@ -3,6 +3,7 @@ package router
 import (
 	"encoding/json"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/golang/mock/gomock"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
 	"github.com/vatsal-chaturvedi/article-management-sys/internal/model"
@@ -10,6 +11,7 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestRegister(t *testing.T) {
@@ -61,6 +63,96 @@ func TestRegister(t *testing.T) {
 			},
 			give: httptest.NewRequest(http.MethodGet, "/no-route", nil),
 		},
+		{
+			name: "Failure::Insert article without credentials",
+			setup: func() *config.SvcConfig {
+				return &config.SvcConfig{
+					Cfg: &config.Config{
+						DataBase: config.DbCfg{
+							Driver: "mysql",
+						},
+						Auth: config.AuthConfig{JwtSecret: "test-secret-of-at-least-32-bytes"},
+					},
+					DbSvc: config.DbSvc{}}
+			},
+			validate: func(w http.ResponseWriter) {
+				wIn := w.(*httptest.ResponseRecorder)
+				if !reflect.DeepEqual(wIn.Code, http.StatusUnauthorized) {
+					t.Errorf("Want: %v, Got: %v", http.StatusUnauthorized, wIn.Code)
+				}
+			},
+			give: httptest.NewRequest(http.MethodPost, "/articles", nil),
+		},
+		{
+			name: "Failure::Insert article as reader",
+			setup: func() *config.SvcConfig {
+				return &config.SvcConfig{
+					Cfg: &config.Config{
+						DataBase: config.DbCfg{
+							Driver: "mysql",
+						},
+						Auth: config.AuthConfig{JwtSecret: "test-secret-of-at-least-32-bytes"},
+					},
+					DbSvc: config.DbSvc{}}
+			},
+			validate: func(w http.ResponseWriter) {
+				wIn := w.(*httptest.ResponseRecorder)
+				if !reflect.DeepEqual(wIn.Code, http.StatusForbidden) {
+					t.Errorf("Want: %v, Got: %v", http.StatusForbidden, wIn.Code)
+				}
+			},
+			give: func() *http.Request {
+				token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user-1", "roles": []string{"reader"}, "exp": time.Now().Add(time.Hour).Unix()}).SignedString([]byte("test-secret-of-at-least-32-bytes"))
+				r := httptest.NewRequest(http.MethodPost, "/articles", nil)
+				r.Header.Set("Authorization", "Bearer "+token)
+				return r
+			}(),
+		},
+		{
+			name: "Failure::List api keys without credentials",
+			setup: func() *config.SvcConfig {
+				return &config.SvcConfig{
+					Cfg: &config.Config{
+						DataBase: config.DbCfg{
+							Driver: "mysql",
+						},
+						Auth: config.AuthConfig{JwtSecret: "test-secret-of-at-least-32-bytes"},
+					},
+					DbSvc: config.DbSvc{}}
+			},
+			validate: func(w http.ResponseWriter) {
+				wIn := w.(*httptest.ResponseRecorder)
+				if !reflect.DeepEqual(wIn.Code, http.StatusUnauthorized) {
+					t.Errorf("Want: %v, Got: %v", http.StatusUnauthorized, wIn.Code)
+				}
+			},
+			give: httptest.NewRequest(http.MethodGet, "/admin/api-keys", nil),
+		},
+		{
+			name: "Failure::Rotate api key as editor",
+			setup: func() *config.SvcConfig {
+				return &config.SvcConfig{
+					Cfg: &config.Config{
+						DataBase: config.DbCfg{
+							Driver: "mysql",
+						},
+						Auth: config.AuthConfig{JwtSecret: "test-secret-of-at-least-32-bytes"},
+					},
+					DbSvc: config.DbSvc{}}
+			},
+			validate: func(w http.ResponseWriter) {
+				wIn := w.(*httptest.ResponseRecorder)
+				if !reflect.DeepEqual(wIn.Code, http.StatusForbidden) {
+					t.Errorf("Want: %v, Got: %v", http.StatusForbidden, wIn.Code)
+				}
+			},
+			give: func() *http.Request {
+				token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "user-1", "roles": []string{"editor"}, "exp": time.Now().Add(time.Hour).Unix()}).SignedString([]byte("test-secret-of-at-least-32-bytes"))
+				r := httptest.NewRequest(http.MethodPost, "/admin/api-keys/1/rotate", nil)
+				r.Header.Set("Authorization", "Bearer "+token)
+				return r
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/logic"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/middleware"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/cacher"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"log"
+	"os"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_scheduler.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/scheduler SchedulerI
+
+// leaseName identifies the scheduler's lease among the rows of the lease table
+const leaseName = "article-schedule"
+
+const (
+	defaultInterval = 30 * time.Second
+	defaultLeaseTTL = 2 * time.Minute
+)
+
+// SchedulerI publishes and withdraws scheduled articles in the background
+type SchedulerI interface {
+	// Run ticks every interval until ctx is done, then gives up the lease
+	Run(ctx context.Context)
+	// Tick runs the due transitions once if this instance holds the lease
+	Tick(now time.Time) error
+}
+
+type scheduler struct {
+	logic    logic.ArticleManagementLogicI
+	lease    datasource.LeaseDataSourceI
+	cacher   cacher.CacherI
+	cacheCfg config.CacheConfig
+	interval time.Duration
+	leaseTTL time.Duration
+	holder   string
+}
+
+// NewSchedulerI creates a scheduler sharing c with the http handlers, so the pages of articles
+// it changes are dropped from the cache of this and, through the local tier, every other instance
+func NewSchedulerI(svcCfg *config.SvcConfig, l logic.ArticleManagementLogicI, lease datasource.LeaseDataSourceI, c cacher.CacherI) SchedulerI {
+	cfg := svcCfg.Cfg.Scheduler
+	s := &scheduler{
+		logic:    l,
+		lease:    lease,
+		cacher:   c,
+		cacheCfg: svcCfg.Cfg.Cacher,
+		interval: cfg.IntervalDuration,
+		leaseTTL: cfg.LeaseTTLDuration,
+		holder:   holderId(),
+	}
+	if s.interval <= 0 {
+		s.interval = defaultInterval
+	}
+	if s.leaseTTL <= 0 {
+		s.leaseTTL = defaultLeaseTTL
+	}
+	return s
+}
+
+func (s *scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := s.lease.Release(leaseName, s.holder); err != nil {
+				log.Print(err)
+			}
+			return
+		case now := <-ticker.C:
+			if err := s.Tick(now); err != nil {
+				log.Print(err)
+			}
+		}
+	}
+}
+
+func (s *scheduler) Tick(now time.Time) error {
+	held, err := s.lease.Acquire(leaseName, s.holder, s.leaseTTL)
+	if err != nil || !held {
+		return err
+	}
+	ids, err := s.logic.RunSchedule(now)
+	// articles changed before a failure are invalidated all the same
+	if len(ids) > 0 {
+		paths := middleware.ArticlePaths("")
+		for _, id := range ids {
+			paths = append(paths, "/articles/"+id, "/articles/"+id+"/related", "/articles/"+id+"/media")
+		}
+		if invalidateErr := middleware.InvalidatePaths(s.cacheCfg, s.cacher, paths...); invalidateErr != nil {
+			log.Print(invalidateErr)
+		}
+		log.Printf("scheduler changed the status of %d articles", len(ids))
+	}
+	return err
+}
+
+// holderId names this instance in the lease table
+func holderId() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%s", host, uuid.NewString())
+}
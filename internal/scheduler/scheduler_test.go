@@ -0,0 +1,73 @@
+package scheduler
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/config"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"testing"
+	"time"
+)
+
+func TestScheduler_Tick(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	now := time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		setup   func(*mock.MockArticleManagementLogicI, *mock.MockLeaseDataSourceI, *mock.MockCacherI)
+		wantErr bool
+	}{
+		{
+			name: "Success:: lease held, changed articles invalidated",
+			setup: func(l *mock.MockArticleManagementLogicI, lease *mock.MockLeaseDataSourceI, c *mock.MockCacherI) {
+				lease.EXPECT().Acquire(leaseName, "me", time.Minute).Return(true, nil)
+				l.EXPECT().RunSchedule(now).Return([]string{"1"}, nil)
+				c.EXPECT().InvalidateTags("ams:tag:/articles", "ams:tag:/tags", "ams:tag:/articles/popular", "ams:tag:/categories/{id}/articles", "ams:tag:/authors/{id}/articles", "ams:tag:/articles/by-slug/{slug}", "ams:tag:/articles/1", "ams:tag:/articles/1/related", "ams:tag:/articles/1/media").Return(nil, nil)
+			},
+		},
+		{
+			name: "Success:: nothing due",
+			setup: func(l *mock.MockArticleManagementLogicI, lease *mock.MockLeaseDataSourceI, c *mock.MockCacherI) {
+				lease.EXPECT().Acquire(leaseName, "me", time.Minute).Return(true, nil)
+				l.EXPECT().RunSchedule(now).Return(nil, nil)
+			},
+		},
+		{
+			name: "Success:: lease held by another instance",
+			setup: func(l *mock.MockArticleManagementLogicI, lease *mock.MockLeaseDataSourceI, c *mock.MockCacherI) {
+				lease.EXPECT().Acquire(leaseName, "me", time.Minute).Return(false, nil)
+			},
+		},
+		{
+			name: "Failure:: partial run still invalidates",
+			setup: func(l *mock.MockArticleManagementLogicI, lease *mock.MockLeaseDataSourceI, c *mock.MockCacherI) {
+				lease.EXPECT().Acquire(leaseName, "me", time.Minute).Return(true, nil)
+				l.EXPECT().RunSchedule(now).Return([]string{"1"}, errors.New("connection refused"))
+				c.EXPECT().InvalidateTags("ams:tag:/articles", "ams:tag:/tags", "ams:tag:/articles/popular", "ams:tag:/categories/{id}/articles", "ams:tag:/authors/{id}/articles", "ams:tag:/articles/by-slug/{slug}", "ams:tag:/articles/1", "ams:tag:/articles/1/related", "ams:tag:/articles/1/media").Return(nil, nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogic := mock.NewMockArticleManagementLogicI(mockCtrl)
+			mockLease := mock.NewMockLeaseDataSourceI(mockCtrl)
+			mockCacher := mock.NewMockCacherI(mockCtrl)
+			tt.setup(mockLogic, mockLease, mockCacher)
+			s := &scheduler{
+				logic:    mockLogic,
+				lease:    mockLease,
+				cacher:   mockCacher,
+				cacheCfg: config.CacheConfig{KeyPrefix: "ams"},
+				leaseTTL: time.Minute,
+				holder:   "me",
+			}
+			err := s.Tick(now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Want: %v, Got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Package slug turns article titles into URL-safe identifiers
+package slug
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength bounds a slug, suffix included, so it fits the slug columns
+const MaxLength = 200
+
+// fallback is used for titles with nothing transliterable in them
+const fallback = "article"
+
+// letters transliterates the letters that do not decompose into a Latin base letter and combining marks
+var letters = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'þ': "th", 'ł': "l", 'ı': "i", 'ŧ': "t", 'ħ': "h",
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i", 'й': "y",
+	'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+	'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i", 'κ': "k", 'λ': "l",
+	'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f",
+	'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// Make returns the slug of title: lower-case ASCII letters and digits, words joined with "-".
+// Accented letters lose their accents, Cyrillic and Greek are transliterated and anything else separates words.
+func Make(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range norm.NFD.String(strings.ToLower(title)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		s, ok := letters[r]
+		switch {
+		case ok:
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			s = string(r)
+		case r == '\'' || r == '’':
+			// apostrophes join words: "don't" is "dont"
+			continue
+		default:
+			dash = b.Len() > 0
+			continue
+		}
+		if s == "" {
+			continue
+		}
+		if dash {
+			b.WriteByte('-')
+			dash = false
+		}
+		b.WriteString(s)
+	}
+	slug := b.String()
+	if len(slug) > MaxLength {
+		slug = strings.TrimRight(slug[:MaxLength], "-")
+	}
+	if slug == "" {
+		return fallback
+	}
+	return slug
+}
+
+// Unique returns base, or base with the smallest suffix "-2", "-3", ... not in taken.
+// The suffix replaces the end of base when both would not fit MaxLength.
+func Unique(base string, taken map[string]bool) string {
+	if !taken[base] {
+		return base
+	}
+	for n := 2; ; n++ {
+		suffix := "-" + strconv.Itoa(n)
+		stem := base
+		if len(stem)+len(suffix) > MaxLength {
+			stem = strings.TrimRight(stem[:MaxLength-len(suffix)], "-")
+		}
+		if candidate := stem + suffix; !taken[candidate] {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		give string
+		want string
+	}{
+		{give: "Hello, World!", want: "hello-world"},
+		{give: "  Crème brûlée à la carte ", want: "creme-brulee-a-la-carte"},
+		{give: "Straße & Smørrebrød", want: "strasse-smorrebrod"},
+		{give: "Привет мир", want: "privet-mir"},
+		{give: "Don't panic: Go 1.18", want: "dont-panic-go-1-18"},
+		{give: "日本語", want: "article"},
+		{give: strings.Repeat("ab ", 100), want: strings.TrimRight(strings.Repeat("ab-", 67)[:MaxLength], "-")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			if got := Make(tt.give); got != tt.want {
+				t.Errorf("Want: %v, Got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUnique(t *testing.T) {
+	taken := map[string]bool{"go": true, "go-2": true}
+	if got := Unique("go", taken); got != "go-3" {
+		t.Errorf("Want: %v, Got: %v", "go-3", got)
+	}
+	if got := Unique("rust", taken); got != "rust" {
+		t.Errorf("Want: %v, Got: %v", "rust", got)
+	}
+	long := strings.Repeat("a", MaxLength)
+	if got := Unique(long, map[string]bool{long: true}); len(got) != MaxLength || !strings.HasSuffix(got, "-2") {
+		t.Errorf("Want: %v, Got: %v", "a...a-2", got)
+	}
+}
@@ -0,0 +1,73 @@
+package views
+
+import (
+	"context"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource"
+	"log"
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_flusher.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/views FlusherI
+
+const defaultFlushInterval = time.Minute
+
+// FlusherI adds the views counted in redis to the totals in the database in batches
+type FlusherI interface {
+	// Run flushes every interval until ctx is done, then flushes once more
+	Run(ctx context.Context)
+	// Flush adds the views counted since the last flush to the database
+	Flush() error
+}
+
+type flusher struct {
+	counter  CounterI
+	ds       datasource.ViewDataSourceI
+	interval time.Duration
+}
+
+// NewFlusherI creates a flusher. Every instance may run one, each flush takes the pending views from redis
+// atomically so no view is added twice.
+func NewFlusherI(counter CounterI, ds datasource.ViewDataSourceI, interval time.Duration) FlusherI {
+	if interval <= 0 {
+		interval = defaultFlushInterval
+	}
+	return &flusher{
+		counter:  counter,
+		ds:       ds,
+		interval: interval,
+	}
+}
+
+func (f *flusher) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := f.Flush(); err != nil {
+				log.Print(err)
+			}
+			return
+		case <-ticker.C:
+			if err := f.Flush(); err != nil {
+				log.Print(err)
+			}
+		}
+	}
+}
+
+func (f *flusher) Flush() error {
+	views, err := f.counter.Take()
+	if err != nil || len(views) == 0 {
+		return err
+	}
+	err = f.ds.AddViews(views)
+	if err == nil {
+		return nil
+	}
+	// the views are put back for the next flush rather than lost
+	if restoreErr := f.counter.Restore(views); restoreErr != nil {
+		log.Print(restoreErr)
+	}
+	return err
+}
@@ -0,0 +1,54 @@
+package views_test
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/vatsal-chaturvedi/article-management-sys/internal/views"
+	"github.com/vatsal-chaturvedi/article-management-sys/pkg/mock"
+	"testing"
+)
+
+func TestFlusher_Flush(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pending := map[string]int64{"1": 3, "2": 1}
+	tests := []struct {
+		name    string
+		setup   func(*mock.MockCounterI, *mock.MockViewDataSourceI)
+		wantErr bool
+	}{
+		{
+			name: "Success:: views added to the database",
+			setup: func(c *mock.MockCounterI, ds *mock.MockViewDataSourceI) {
+				c.EXPECT().Take().Return(pending, nil)
+				ds.EXPECT().AddViews(pending).Return(nil)
+			},
+		},
+		{
+			name: "Success:: nothing to flush",
+			setup: func(c *mock.MockCounterI, ds *mock.MockViewDataSourceI) {
+				c.EXPECT().Take().Return(map[string]int64{}, nil)
+			},
+		},
+		{
+			name: "Failure:: views put back when the database fails",
+			setup: func(c *mock.MockCounterI, ds *mock.MockViewDataSourceI) {
+				c.EXPECT().Take().Return(pending, nil)
+				ds.EXPECT().AddViews(pending).Return(errors.New("error"))
+				c.EXPECT().Restore(pending).Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCounter := mock.NewMockCounterI(mockCtrl)
+			mockDs := mock.NewMockViewDataSourceI(mockCtrl)
+			tt.setup(mockCounter, mockDs)
+			err := views.NewFlusherI(mockCounter, mockDs, 0).Flush()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Want: %v, Got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
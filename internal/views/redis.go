@@ -0,0 +1,140 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"strconv"
+	"time"
+)
+
+const defaultKeyPrefix = "article-management-sys:views"
+
+// bucketSize is the granularity of the ranking, a window covers the buckets of its last hours
+const bucketSize = time.Hour
+
+// recordView counts a view unless KEYS[1], marking that the client viewed the article, exists.
+// KEYS: seen marker, pending views hash, hourly ranking. ARGV: dedupe window in milliseconds (0 counts every view),
+// article id, ranking expiry in milliseconds. It returns 1 when the view was counted.
+var recordView = redis.NewScript(`
+if tonumber(ARGV[1]) > 0 then
+	if not redis.call('SET', KEYS[1], '1', 'NX', 'PX', ARGV[1]) then
+		return 0
+	end
+end
+redis.call('HINCRBY', KEYS[2], ARGV[2], 1)
+redis.call('ZINCRBY', KEYS[3], 1, ARGV[2])
+redis.call('PEXPIRE', KEYS[3], ARGV[3])
+return 1
+`)
+
+// takeViews returns the pending views hash at KEYS[1] and deletes it, so that concurrent flushers never add the
+// same views twice
+var takeViews = redis.NewScript(`
+local views = redis.call('HGETALL', KEYS[1])
+redis.call('DEL', KEYS[1])
+return views
+`)
+
+var errUnexpectedReply = errors.New("views: unexpected reply from redis")
+
+// redisCounter keeps the views of every hour in a sorted set and the views not flushed yet in a hash
+type redisCounter struct {
+	rdb    *redis.Client
+	prefix string
+	window time.Duration
+}
+
+// NewRedisCounter creates a counter storing its keys under prefix, counting views of an article by the same
+// client once per dedupe window
+func NewRedisCounter(rdb *redis.Client, prefix string, dedupe time.Duration) CounterI {
+	if prefix == "" {
+		prefix = defaultKeyPrefix
+	}
+	return &redisCounter{
+		rdb:    rdb,
+		prefix: prefix,
+		window: dedupe,
+	}
+}
+
+func (c redisCounter) Record(id string, client string, now time.Time) (bool, error) {
+	keys := []string{c.prefix + ":seen:" + id + ":" + client, c.pendingKey(), c.bucketKey(now)}
+	counted, err := recordView.Run(context.Background(), c.rdb, keys, c.window.Milliseconds(), id, (MaxWindow + bucketSize).Milliseconds()).Int()
+	return counted == 1, err
+}
+
+func (c redisCounter) Popular(window time.Duration, limit int, now time.Time) ([]Score, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	buckets := int((window + bucketSize - 1) / bucketSize)
+	keys := make([]string, 0, buckets)
+	for i := 0; i < buckets; i++ {
+		keys = append(keys, c.bucketKey(now.Add(-time.Duration(i)*bucketSize)))
+	}
+	ctx := context.Background()
+	dest := c.prefix + ":popular:" + uuid.NewString()
+	var ranked *redis.ZSliceCmd
+	_, err := c.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZUnionStore(ctx, dest, &redis.ZStore{Keys: keys})
+		ranked = pipe.ZRevRangeWithScores(ctx, dest, 0, int64(limit-1))
+		pipe.Del(ctx, dest)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	scores := make([]Score, 0, len(ranked.Val()))
+	for _, z := range ranked.Val() {
+		id, ok := z.Member.(string)
+		if !ok {
+			return nil, errUnexpectedReply
+		}
+		scores = append(scores, Score{Id: id, Views: int64(z.Score)})
+	}
+	return scores, nil
+}
+
+func (c redisCounter) Take() (map[string]int64, error) {
+	values, err := takeViews.Run(context.Background(), c.rdb, []string{c.pendingKey()}).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	if len(values)%2 != 0 {
+		return nil, errUnexpectedReply
+	}
+	views := make(map[string]int64, len(values)/2)
+	for i := 0; i < len(values); i += 2 {
+		n, err := strconv.ParseInt(values[i+1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		views[values[i]] = n
+	}
+	return views, nil
+}
+
+func (c redisCounter) Restore(views map[string]int64) error {
+	if len(views) == 0 {
+		return nil
+	}
+	ctx := context.Background()
+	_, err := c.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for id, n := range views {
+			pipe.HIncrBy(ctx, c.pendingKey(), id, n)
+		}
+		return nil
+	})
+	return err
+}
+
+func (c redisCounter) pendingKey() string {
+	return c.prefix + ":pending"
+}
+
+// bucketKey names the ranking of the hour t falls in
+func (c redisCounter) bucketKey(t time.Time) string {
+	return c.prefix + ":hour:" + strconv.FormatInt(t.Unix()/int64(bucketSize/time.Second), 10)
+}
@@ -0,0 +1,80 @@
+package views
+
+import (
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRedisCounter(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	counter := NewRedisCounter(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "views", 30*time.Minute)
+	now := time.Date(2023, 1, 10, 12, 30, 0, 0, time.UTC)
+
+	views := []struct {
+		id     string
+		client string
+		at     time.Time
+		want   bool
+	}{
+		{id: "1", client: "ip:a", at: now, want: true},
+		{id: "1", client: "ip:a", at: now, want: false},
+		{id: "1", client: "ip:b", at: now, want: true},
+		{id: "2", client: "ip:a", at: now, want: true},
+		{id: "2", client: "ip:b", at: now.Add(-48 * time.Hour), want: true},
+		{id: "2", client: "ip:c", at: now.Add(-72 * time.Hour), want: true},
+	}
+	for i, v := range views {
+		got, err := counter.Record(v.id, v.client, v.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v.want {
+			t.Errorf("view %d: Want: %v, Got: %v", i, v.want, got)
+		}
+	}
+
+	got, err := counter.Popular(Windows["24h"], 10, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Score{{Id: "1", Views: 2}, {Id: "2", Views: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+	got, _ = counter.Popular(Windows["7d"], 1, now)
+	want = []Score{{Id: "2", Views: 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Want: %v, Got: %v", want, got)
+	}
+
+	taken, err := counter.Take()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]int64{"1": 2, "2": 3}; !reflect.DeepEqual(taken, want) {
+		t.Errorf("Want: %v, Got: %v", want, taken)
+	}
+	if taken, _ = counter.Take(); len(taken) != 0 {
+		t.Errorf("Want: %v, Got: %v", "nothing left to take", taken)
+	}
+	if err = counter.Restore(map[string]int64{"1": 2}); err != nil {
+		t.Fatal(err)
+	}
+	counter.Record("1", "ip:c", now)
+	taken, _ = counter.Take()
+	if want := map[string]int64{"1": 3}; !reflect.DeepEqual(taken, want) {
+		t.Errorf("Want: %v, Got: %v", want, taken)
+	}
+
+	mr.FastForward(30 * time.Minute)
+	if got, _ := counter.Record("1", "ip:a", now); !got {
+		t.Errorf("Want: %v, Got: %v", "counted again after the dedupe window", got)
+	}
+}
@@ -0,0 +1,37 @@
+package views
+
+import (
+	"time"
+)
+
+//go:generate mockgen --build_flags=--mod=mod --destination=./../../pkg/mock/mock_views.go --package=mock github.com/vatsal-chaturvedi/article-management-sys/internal/views CounterI
+
+// Windows are the periods the popular articles can be ranked over, keyed by their name in the query
+var Windows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// MaxWindow is the longest of Windows, views are kept for ranking that long
+const MaxWindow = 30 * 24 * time.Hour
+
+// CounterI counts the views of articles, ranks them by their recent views and hands the views counted since the
+// last flush to the flusher
+type CounterI interface {
+	// Record counts a view of the article by client at now, unless client viewed it within the dedupe window,
+	// and reports whether it was counted
+	Record(id string, client string, now time.Time) (bool, error)
+	// Popular returns up to limit articles with the most views within window before now, most viewed first
+	Popular(window time.Duration, limit int, now time.Time) ([]Score, error)
+	// Take removes and returns the views counted since the last Take
+	Take() (map[string]int64, error)
+	// Restore adds views back to the ones counted since the last Take, for views that could not be flushed
+	Restore(views map[string]int64) error
+}
+
+// Score is the number of views of an article
+type Score struct {
+	Id    string
+	Views int64
+}
@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource (interfaces: ApiKeyDataSourceI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockApiKeyDataSourceI is a mock of ApiKeyDataSourceI interface.
+type MockApiKeyDataSourceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyDataSourceIMockRecorder
+}
+
+// MockApiKeyDataSourceIMockRecorder is the mock recorder for MockApiKeyDataSourceI.
+type MockApiKeyDataSourceIMockRecorder struct {
+	mock *MockApiKeyDataSourceI
+}
+
+// NewMockApiKeyDataSourceI creates a new mock instance.
+func NewMockApiKeyDataSourceI(ctrl *gomock.Controller) *MockApiKeyDataSourceI {
+	mock := &MockApiKeyDataSourceI{ctrl: ctrl}
+	mock.recorder = &MockApiKeyDataSourceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKeyDataSourceI) EXPECT() *MockApiKeyDataSourceIMockRecorder {
+	return m.recorder
+}
+
+// GetApiKeys mocks base method.
+func (m *MockApiKeyDataSourceI) GetApiKeys(arg0 map[string]interface{}) ([]model.ApiKeyDs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKeys", arg0)
+	ret0, _ := ret[0].([]model.ApiKeyDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKeys indicates an expected call of GetApiKeys.
+func (mr *MockApiKeyDataSourceIMockRecorder) GetApiKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeys", reflect.TypeOf((*MockApiKeyDataSourceI)(nil).GetApiKeys), arg0)
+}
+
+// InsertApiKey mocks base method.
+func (m *MockApiKeyDataSourceI) InsertApiKey(arg0 model.ApiKeyDs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertApiKey", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertApiKey indicates an expected call of InsertApiKey.
+func (mr *MockApiKeyDataSourceIMockRecorder) InsertApiKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertApiKey", reflect.TypeOf((*MockApiKeyDataSourceI)(nil).InsertApiKey), arg0)
+}
+
+// ReplaceApiKey mocks base method.
+func (m *MockApiKeyDataSourceI) ReplaceApiKey(arg0 string, arg1 model.ApiKeyDs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceApiKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceApiKey indicates an expected call of ReplaceApiKey.
+func (mr *MockApiKeyDataSourceIMockRecorder) ReplaceApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceApiKey", reflect.TypeOf((*MockApiKeyDataSourceI)(nil).ReplaceApiKey), arg0, arg1)
+}
+
+// UpdateApiKey mocks base method.
+func (m *MockApiKeyDataSourceI) UpdateApiKey(arg0 string, arg1 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApiKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApiKey indicates an expected call of UpdateApiKey.
+func (mr *MockApiKeyDataSourceIMockRecorder) UpdateApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApiKey", reflect.TypeOf((*MockApiKeyDataSourceI)(nil).UpdateApiKey), arg0, arg1)
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/handler (interfaces: ApiKeyHandlerI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockApiKeyHandlerI is a mock of ApiKeyHandlerI interface.
+type MockApiKeyHandlerI struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyHandlerIMockRecorder
+}
+
+// MockApiKeyHandlerIMockRecorder is the mock recorder for MockApiKeyHandlerI.
+type MockApiKeyHandlerIMockRecorder struct {
+	mock *MockApiKeyHandlerI
+}
+
+// NewMockApiKeyHandlerI creates a new mock instance.
+func NewMockApiKeyHandlerI(ctrl *gomock.Controller) *MockApiKeyHandlerI {
+	mock := &MockApiKeyHandlerI{ctrl: ctrl}
+	mock.recorder = &MockApiKeyHandlerIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKeyHandlerI) EXPECT() *MockApiKeyHandlerIMockRecorder {
+	return m.recorder
+}
+
+// CreateApiKey mocks base method.
+func (m *MockApiKeyHandlerI) CreateApiKey(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateApiKey", arg0, arg1)
+}
+
+// CreateApiKey indicates an expected call of CreateApiKey.
+func (mr *MockApiKeyHandlerIMockRecorder) CreateApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApiKey", reflect.TypeOf((*MockApiKeyHandlerI)(nil).CreateApiKey), arg0, arg1)
+}
+
+// ListApiKeys mocks base method.
+func (m *MockApiKeyHandlerI) ListApiKeys(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListApiKeys", arg0, arg1)
+}
+
+// ListApiKeys indicates an expected call of ListApiKeys.
+func (mr *MockApiKeyHandlerIMockRecorder) ListApiKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApiKeys", reflect.TypeOf((*MockApiKeyHandlerI)(nil).ListApiKeys), arg0, arg1)
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockApiKeyHandlerI) RevokeApiKey(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeApiKey", arg0, arg1)
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockApiKeyHandlerIMockRecorder) RevokeApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockApiKeyHandlerI)(nil).RevokeApiKey), arg0, arg1)
+}
+
+// RotateApiKey mocks base method.
+func (m *MockApiKeyHandlerI) RotateApiKey(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RotateApiKey", arg0, arg1)
+}
+
+// RotateApiKey indicates an expected call of RotateApiKey.
+func (mr *MockApiKeyHandlerIMockRecorder) RotateApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateApiKey", reflect.TypeOf((*MockApiKeyHandlerI)(nil).RotateApiKey), arg0, arg1)
+}
@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/logic (interfaces: ApiKeyLogicI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockApiKeyLogicI is a mock of ApiKeyLogicI interface.
+type MockApiKeyLogicI struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyLogicIMockRecorder
+}
+
+// MockApiKeyLogicIMockRecorder is the mock recorder for MockApiKeyLogicI.
+type MockApiKeyLogicIMockRecorder struct {
+	mock *MockApiKeyLogicI
+}
+
+// NewMockApiKeyLogicI creates a new mock instance.
+func NewMockApiKeyLogicI(ctrl *gomock.Controller) *MockApiKeyLogicI {
+	mock := &MockApiKeyLogicI{ctrl: ctrl}
+	mock.recorder = &MockApiKeyLogicIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKeyLogicI) EXPECT() *MockApiKeyLogicIMockRecorder {
+	return m.recorder
+}
+
+// CreateApiKey mocks base method.
+func (m *MockApiKeyLogicI) CreateApiKey(arg0 *model.ApiKeyRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApiKey", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// CreateApiKey indicates an expected call of CreateApiKey.
+func (mr *MockApiKeyLogicIMockRecorder) CreateApiKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApiKey", reflect.TypeOf((*MockApiKeyLogicI)(nil).CreateApiKey), arg0)
+}
+
+// ListApiKeys mocks base method.
+func (m *MockApiKeyLogicI) ListApiKeys() *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApiKeys")
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// ListApiKeys indicates an expected call of ListApiKeys.
+func (mr *MockApiKeyLogicIMockRecorder) ListApiKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApiKeys", reflect.TypeOf((*MockApiKeyLogicI)(nil).ListApiKeys))
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockApiKeyLogicI) RevokeApiKey(arg0 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeApiKey", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockApiKeyLogicIMockRecorder) RevokeApiKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockApiKeyLogicI)(nil).RevokeApiKey), arg0)
+}
+
+// RotateApiKey mocks base method.
+func (m *MockApiKeyLogicI) RotateApiKey(arg0 string, arg1 *model.ApiKeyRotateRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateApiKey", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// RotateApiKey indicates an expected call of RotateApiKey.
+func (mr *MockApiKeyLogicIMockRecorder) RotateApiKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateApiKey", reflect.TypeOf((*MockApiKeyLogicI)(nil).RotateApiKey), arg0, arg1)
+}
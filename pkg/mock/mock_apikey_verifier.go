@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/auth (interfaces: ApiKeyVerifierI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockApiKeyVerifierI is a mock of ApiKeyVerifierI interface.
+type MockApiKeyVerifierI struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyVerifierIMockRecorder
+}
+
+// MockApiKeyVerifierIMockRecorder is the mock recorder for MockApiKeyVerifierI.
+type MockApiKeyVerifierIMockRecorder struct {
+	mock *MockApiKeyVerifierI
+}
+
+// NewMockApiKeyVerifierI creates a new mock instance.
+func NewMockApiKeyVerifierI(ctrl *gomock.Controller) *MockApiKeyVerifierI {
+	mock := &MockApiKeyVerifierI{ctrl: ctrl}
+	mock.recorder = &MockApiKeyVerifierIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKeyVerifierI) EXPECT() *MockApiKeyVerifierIMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockApiKeyVerifierI) Verify(arg0 string) (*model.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", arg0)
+	ret0, _ := ret[0].(*model.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockApiKeyVerifierIMockRecorder) Verify(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockApiKeyVerifierI)(nil).Verify), arg0)
+}
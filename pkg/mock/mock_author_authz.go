@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/authz (interfaces: AuthorPolicyI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockAuthorPolicyI is a mock of AuthorPolicyI interface.
+type MockAuthorPolicyI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorPolicyIMockRecorder
+}
+
+// MockAuthorPolicyIMockRecorder is the mock recorder for MockAuthorPolicyI.
+type MockAuthorPolicyIMockRecorder struct {
+	mock *MockAuthorPolicyI
+}
+
+// NewMockAuthorPolicyI creates a new mock instance.
+func NewMockAuthorPolicyI(ctrl *gomock.Controller) *MockAuthorPolicyI {
+	mock := &MockAuthorPolicyI{ctrl: ctrl}
+	mock.recorder = &MockAuthorPolicyIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorPolicyI) EXPECT() *MockAuthorPolicyIMockRecorder {
+	return m.recorder
+}
+
+// InsertAuthor mocks base method.
+func (m *MockAuthorPolicyI) InsertAuthor(arg0 *model.Identity, arg1 *model.AuthorRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAuthor", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// InsertAuthor indicates an expected call of InsertAuthor.
+func (mr *MockAuthorPolicyIMockRecorder) InsertAuthor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuthor", reflect.TypeOf((*MockAuthorPolicyI)(nil).InsertAuthor), arg0, arg1)
+}
+
+// UpdateAuthor mocks base method.
+func (m *MockAuthorPolicyI) UpdateAuthor(arg0 *model.Identity, arg1 string, arg2 *model.AuthorRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// UpdateAuthor indicates an expected call of UpdateAuthor.
+func (mr *MockAuthorPolicyIMockRecorder) UpdateAuthor(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthor", reflect.TypeOf((*MockAuthorPolicyI)(nil).UpdateAuthor), arg0, arg1, arg2)
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource (interfaces: AuthorDataSourceI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockAuthorDataSourceI is a mock of AuthorDataSourceI interface.
+type MockAuthorDataSourceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorDataSourceIMockRecorder
+}
+
+// MockAuthorDataSourceIMockRecorder is the mock recorder for MockAuthorDataSourceI.
+type MockAuthorDataSourceIMockRecorder struct {
+	mock *MockAuthorDataSourceI
+}
+
+// NewMockAuthorDataSourceI creates a new mock instance.
+func NewMockAuthorDataSourceI(ctrl *gomock.Controller) *MockAuthorDataSourceI {
+	mock := &MockAuthorDataSourceI{ctrl: ctrl}
+	mock.recorder = &MockAuthorDataSourceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorDataSourceI) EXPECT() *MockAuthorDataSourceIMockRecorder {
+	return m.recorder
+}
+
+// GetAuthors mocks base method.
+func (m *MockAuthorDataSourceI) GetAuthors(arg0 map[string]interface{}) ([]model.AuthorDs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthors", arg0)
+	ret0, _ := ret[0].([]model.AuthorDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthors indicates an expected call of GetAuthors.
+func (mr *MockAuthorDataSourceIMockRecorder) GetAuthors(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthors", reflect.TypeOf((*MockAuthorDataSourceI)(nil).GetAuthors), arg0)
+}
+
+// InsertAuthor mocks base method.
+func (m *MockAuthorDataSourceI) InsertAuthor(arg0 model.AuthorDs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAuthor", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAuthor indicates an expected call of InsertAuthor.
+func (mr *MockAuthorDataSourceIMockRecorder) InsertAuthor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuthor", reflect.TypeOf((*MockAuthorDataSourceI)(nil).InsertAuthor), arg0)
+}
+
+// MigrateAuthors mocks base method.
+func (m *MockAuthorDataSourceI) MigrateAuthors() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateAuthors")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateAuthors indicates an expected call of MigrateAuthors.
+func (mr *MockAuthorDataSourceIMockRecorder) MigrateAuthors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateAuthors", reflect.TypeOf((*MockAuthorDataSourceI)(nil).MigrateAuthors))
+}
+
+// UpdateAuthor mocks base method.
+func (m *MockAuthorDataSourceI) UpdateAuthor(arg0 string, arg1 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAuthor indicates an expected call of UpdateAuthor.
+func (mr *MockAuthorDataSourceIMockRecorder) UpdateAuthor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthor", reflect.TypeOf((*MockAuthorDataSourceI)(nil).UpdateAuthor), arg0, arg1)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/handler (interfaces: AuthorHandlerI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuthorHandlerI is a mock of AuthorHandlerI interface.
+type MockAuthorHandlerI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorHandlerIMockRecorder
+}
+
+// MockAuthorHandlerIMockRecorder is the mock recorder for MockAuthorHandlerI.
+type MockAuthorHandlerIMockRecorder struct {
+	mock *MockAuthorHandlerI
+}
+
+// NewMockAuthorHandlerI creates a new mock instance.
+func NewMockAuthorHandlerI(ctrl *gomock.Controller) *MockAuthorHandlerI {
+	mock := &MockAuthorHandlerI{ctrl: ctrl}
+	mock.recorder = &MockAuthorHandlerIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorHandlerI) EXPECT() *MockAuthorHandlerIMockRecorder {
+	return m.recorder
+}
+
+// GetAuthor mocks base method.
+func (m *MockAuthorHandlerI) GetAuthor(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetAuthor", arg0, arg1)
+}
+
+// GetAuthor indicates an expected call of GetAuthor.
+func (mr *MockAuthorHandlerIMockRecorder) GetAuthor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthor", reflect.TypeOf((*MockAuthorHandlerI)(nil).GetAuthor), arg0, arg1)
+}
+
+// GetAuthorArticles mocks base method.
+func (m *MockAuthorHandlerI) GetAuthorArticles(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetAuthorArticles", arg0, arg1)
+}
+
+// GetAuthorArticles indicates an expected call of GetAuthorArticles.
+func (mr *MockAuthorHandlerIMockRecorder) GetAuthorArticles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorArticles", reflect.TypeOf((*MockAuthorHandlerI)(nil).GetAuthorArticles), arg0, arg1)
+}
+
+// GetAuthors mocks base method.
+func (m *MockAuthorHandlerI) GetAuthors(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetAuthors", arg0, arg1)
+}
+
+// GetAuthors indicates an expected call of GetAuthors.
+func (mr *MockAuthorHandlerIMockRecorder) GetAuthors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthors", reflect.TypeOf((*MockAuthorHandlerI)(nil).GetAuthors), arg0, arg1)
+}
+
+// InsertAuthor mocks base method.
+func (m *MockAuthorHandlerI) InsertAuthor(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InsertAuthor", arg0, arg1)
+}
+
+// InsertAuthor indicates an expected call of InsertAuthor.
+func (mr *MockAuthorHandlerIMockRecorder) InsertAuthor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuthor", reflect.TypeOf((*MockAuthorHandlerI)(nil).InsertAuthor), arg0, arg1)
+}
+
+// UpdateAuthor mocks base method.
+func (m *MockAuthorHandlerI) UpdateAuthor(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateAuthor", arg0, arg1)
+}
+
+// UpdateAuthor indicates an expected call of UpdateAuthor.
+func (mr *MockAuthorHandlerIMockRecorder) UpdateAuthor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthor", reflect.TypeOf((*MockAuthorHandlerI)(nil).UpdateAuthor), arg0, arg1)
+}
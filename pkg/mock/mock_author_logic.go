@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/logic (interfaces: AuthorLogicI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockAuthorLogicI is a mock of AuthorLogicI interface.
+type MockAuthorLogicI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorLogicIMockRecorder
+}
+
+// MockAuthorLogicIMockRecorder is the mock recorder for MockAuthorLogicI.
+type MockAuthorLogicIMockRecorder struct {
+	mock *MockAuthorLogicI
+}
+
+// NewMockAuthorLogicI creates a new mock instance.
+func NewMockAuthorLogicI(ctrl *gomock.Controller) *MockAuthorLogicI {
+	mock := &MockAuthorLogicI{ctrl: ctrl}
+	mock.recorder = &MockAuthorLogicIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorLogicI) EXPECT() *MockAuthorLogicIMockRecorder {
+	return m.recorder
+}
+
+// GetAuthor mocks base method.
+func (m *MockAuthorLogicI) GetAuthor(arg0 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthor", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetAuthor indicates an expected call of GetAuthor.
+func (mr *MockAuthorLogicIMockRecorder) GetAuthor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthor", reflect.TypeOf((*MockAuthorLogicI)(nil).GetAuthor), arg0)
+}
+
+// GetAuthorArticles mocks base method.
+func (m *MockAuthorLogicI) GetAuthorArticles(arg0 string, arg1, arg2 int) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorArticles", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetAuthorArticles indicates an expected call of GetAuthorArticles.
+func (mr *MockAuthorLogicIMockRecorder) GetAuthorArticles(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorArticles", reflect.TypeOf((*MockAuthorLogicI)(nil).GetAuthorArticles), arg0, arg1, arg2)
+}
+
+// GetAuthors mocks base method.
+func (m *MockAuthorLogicI) GetAuthors() *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthors")
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetAuthors indicates an expected call of GetAuthors.
+func (mr *MockAuthorLogicIMockRecorder) GetAuthors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthors", reflect.TypeOf((*MockAuthorLogicI)(nil).GetAuthors))
+}
+
+// InsertAuthor mocks base method.
+func (m *MockAuthorLogicI) InsertAuthor(arg0 *model.AuthorRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAuthor", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// InsertAuthor indicates an expected call of InsertAuthor.
+func (mr *MockAuthorLogicIMockRecorder) InsertAuthor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuthor", reflect.TypeOf((*MockAuthorLogicI)(nil).InsertAuthor), arg0)
+}
+
+// MigrateAuthors mocks base method.
+func (m *MockAuthorLogicI) MigrateAuthors() *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateAuthors")
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// MigrateAuthors indicates an expected call of MigrateAuthors.
+func (mr *MockAuthorLogicIMockRecorder) MigrateAuthors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateAuthors", reflect.TypeOf((*MockAuthorLogicI)(nil).MigrateAuthors))
+}
+
+// UpdateAuthor mocks base method.
+func (m *MockAuthorLogicI) UpdateAuthor(arg0 string, arg1 *model.AuthorRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthor", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// UpdateAuthor indicates an expected call of UpdateAuthor.
+func (mr *MockAuthorLogicIMockRecorder) UpdateAuthor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthor", reflect.TypeOf((*MockAuthorLogicI)(nil).UpdateAuthor), arg0, arg1)
+}
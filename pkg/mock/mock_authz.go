@@ -0,0 +1,203 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/authz (interfaces: ArticlePolicyI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockArticlePolicyI is a mock of ArticlePolicyI interface.
+type MockArticlePolicyI struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticlePolicyIMockRecorder
+}
+
+// MockArticlePolicyIMockRecorder is the mock recorder for MockArticlePolicyI.
+type MockArticlePolicyIMockRecorder struct {
+	mock *MockArticlePolicyI
+}
+
+// NewMockArticlePolicyI creates a new mock instance.
+func NewMockArticlePolicyI(ctrl *gomock.Controller) *MockArticlePolicyI {
+	mock := &MockArticlePolicyI{ctrl: ctrl}
+	mock.recorder = &MockArticlePolicyIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticlePolicyI) EXPECT() *MockArticlePolicyIMockRecorder {
+	return m.recorder
+}
+
+// DiffRevisions mocks base method.
+func (m *MockArticlePolicyI) DiffRevisions(arg0 *model.Identity, arg1 string, arg2, arg3 int) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockArticlePolicyIMockRecorder) DiffRevisions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockArticlePolicyI)(nil).DiffRevisions), arg0, arg1, arg2, arg3)
+}
+
+// GetAllArticle mocks base method.
+func (m *MockArticlePolicyI) GetAllArticle(arg0 *model.Identity, arg1, arg2 int, arg3 string, arg4 *model.TagFilter) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllArticle", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetAllArticle indicates an expected call of GetAllArticle.
+func (mr *MockArticlePolicyIMockRecorder) GetAllArticle(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllArticle", reflect.TypeOf((*MockArticlePolicyI)(nil).GetAllArticle), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetArticle mocks base method.
+func (m *MockArticlePolicyI) GetArticle(arg0 *model.Identity, arg1 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticle", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetArticle indicates an expected call of GetArticle.
+func (mr *MockArticlePolicyIMockRecorder) GetArticle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticle", reflect.TypeOf((*MockArticlePolicyI)(nil).GetArticle), arg0, arg1)
+}
+
+// GetArticleBySlug mocks base method.
+func (m *MockArticlePolicyI) GetArticleBySlug(arg0 *model.Identity, arg1 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleBySlug", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetArticleBySlug indicates an expected call of GetArticleBySlug.
+func (mr *MockArticlePolicyIMockRecorder) GetArticleBySlug(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleBySlug", reflect.TypeOf((*MockArticlePolicyI)(nil).GetArticleBySlug), arg0, arg1)
+}
+
+// GetRevision mocks base method.
+func (m *MockArticlePolicyI) GetRevision(arg0 *model.Identity, arg1 string, arg2 int) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockArticlePolicyIMockRecorder) GetRevision(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockArticlePolicyI)(nil).GetRevision), arg0, arg1, arg2)
+}
+
+// GetRevisions mocks base method.
+func (m *MockArticlePolicyI) GetRevisions(arg0 *model.Identity, arg1 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockArticlePolicyIMockRecorder) GetRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockArticlePolicyI)(nil).GetRevisions), arg0, arg1)
+}
+
+// GetTags mocks base method.
+func (m *MockArticlePolicyI) GetTags(arg0 *model.Identity) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockArticlePolicyIMockRecorder) GetTags(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockArticlePolicyI)(nil).GetTags), arg0)
+}
+
+// InsertArticle mocks base method.
+func (m *MockArticlePolicyI) InsertArticle(arg0 *model.Identity, arg1 *model.Article) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertArticle", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// InsertArticle indicates an expected call of InsertArticle.
+func (mr *MockArticlePolicyIMockRecorder) InsertArticle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertArticle", reflect.TypeOf((*MockArticlePolicyI)(nil).InsertArticle), arg0, arg1)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockArticlePolicyI) RestoreRevision(arg0 *model.Identity, arg1 string, arg2, arg3 int) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockArticlePolicyIMockRecorder) RestoreRevision(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockArticlePolicyI)(nil).RestoreRevision), arg0, arg1, arg2, arg3)
+}
+
+// ScheduleArticle mocks base method.
+func (m *MockArticlePolicyI) ScheduleArticle(arg0 *model.Identity, arg1 string, arg2 *model.ScheduleRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleArticle", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// ScheduleArticle indicates an expected call of ScheduleArticle.
+func (mr *MockArticlePolicyIMockRecorder) ScheduleArticle(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleArticle", reflect.TypeOf((*MockArticlePolicyI)(nil).ScheduleArticle), arg0, arg1, arg2)
+}
+
+// TransitionArticle mocks base method.
+func (m *MockArticlePolicyI) TransitionArticle(arg0 *model.Identity, arg1, arg2 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionArticle", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// TransitionArticle indicates an expected call of TransitionArticle.
+func (mr *MockArticlePolicyIMockRecorder) TransitionArticle(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionArticle", reflect.TypeOf((*MockArticlePolicyI)(nil).TransitionArticle), arg0, arg1, arg2)
+}
+
+// UpdateArticle mocks base method.
+func (m *MockArticlePolicyI) UpdateArticle(arg0 *model.Identity, arg1 string, arg2 *model.Article) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateArticle", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// UpdateArticle indicates an expected call of UpdateArticle.
+func (mr *MockArticlePolicyIMockRecorder) UpdateArticle(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArticle", reflect.TypeOf((*MockArticlePolicyI)(nil).UpdateArticle), arg0, arg1, arg2)
+}
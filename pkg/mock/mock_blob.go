@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/blob (interfaces: StoreI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStoreI is a mock of StoreI interface.
+type MockStoreI struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreIMockRecorder
+}
+
+// MockStoreIMockRecorder is the mock recorder for MockStoreI.
+type MockStoreIMockRecorder struct {
+	mock *MockStoreI
+}
+
+// NewMockStoreI creates a new mock instance.
+func NewMockStoreI(ctrl *gomock.Controller) *MockStoreI {
+	mock := &MockStoreI{ctrl: ctrl}
+	mock.recorder = &MockStoreIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreI) EXPECT() *MockStoreIMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockStoreI) Delete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStoreIMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStoreI)(nil).Delete), arg0)
+}
+
+// Get mocks base method.
+func (m *MockStoreI) Get(arg0 string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockStoreIMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStoreI)(nil).Get), arg0)
+}
+
+// Put mocks base method.
+func (m *MockStoreI) Put(arg0 string, arg1 []byte, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockStoreIMockRecorder) Put(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStoreI)(nil).Put), arg0, arg1, arg2)
+}
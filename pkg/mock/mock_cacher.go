@@ -34,6 +34,24 @@ func (m *MockCacherI) EXPECT() *MockCacherIMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockCacherI) Delete(arg0 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCacherIMockRecorder) Delete(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCacherI)(nil).Delete), arg0...)
+}
+
 // Get mocks base method.
 func (m *MockCacherI) Get(arg0 string) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +67,25 @@ func (mr *MockCacherIMockRecorder) Get(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCacherI)(nil).Get), arg0)
 }
 
+// InvalidateTags mocks base method.
+func (m *MockCacherI) InvalidateTags(arg0 ...string) ([]string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvalidateTags", varargs...)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvalidateTags indicates an expected call of InvalidateTags.
+func (mr *MockCacherIMockRecorder) InvalidateTags(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateTags", reflect.TypeOf((*MockCacherI)(nil).InvalidateTags), arg0...)
+}
+
 // Set mocks base method.
 func (m *MockCacherI) Set(arg0 string, arg1 interface{}, arg2 time.Duration) error {
 	m.ctrl.T.Helper()
@@ -62,3 +99,32 @@ func (mr *MockCacherIMockRecorder) Set(arg0, arg1, arg2 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCacherI)(nil).Set), arg0, arg1, arg2)
 }
+
+// SetNX mocks base method.
+func (m *MockCacherI) SetNX(arg0 string, arg1 interface{}, arg2 time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNX", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetNX indicates an expected call of SetNX.
+func (mr *MockCacherIMockRecorder) SetNX(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNX", reflect.TypeOf((*MockCacherI)(nil).SetNX), arg0, arg1, arg2)
+}
+
+// Tag mocks base method.
+func (m *MockCacherI) Tag(arg0, arg1 string, arg2 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Tag indicates an expected call of Tag.
+func (mr *MockCacherIMockRecorder) Tag(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tag", reflect.TypeOf((*MockCacherI)(nil).Tag), arg0, arg1, arg2)
+}
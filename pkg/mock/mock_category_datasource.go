@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource (interfaces: CategoryDataSourceI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockCategoryDataSourceI is a mock of CategoryDataSourceI interface.
+type MockCategoryDataSourceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryDataSourceIMockRecorder
+}
+
+// MockCategoryDataSourceIMockRecorder is the mock recorder for MockCategoryDataSourceI.
+type MockCategoryDataSourceIMockRecorder struct {
+	mock *MockCategoryDataSourceI
+}
+
+// NewMockCategoryDataSourceI creates a new mock instance.
+func NewMockCategoryDataSourceI(ctrl *gomock.Controller) *MockCategoryDataSourceI {
+	mock := &MockCategoryDataSourceI{ctrl: ctrl}
+	mock.recorder = &MockCategoryDataSourceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryDataSourceI) EXPECT() *MockCategoryDataSourceIMockRecorder {
+	return m.recorder
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategoryDataSourceI) DeleteCategory(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategoryDataSourceIMockRecorder) DeleteCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryDataSourceI)(nil).DeleteCategory), arg0)
+}
+
+// GetCategories mocks base method.
+func (m *MockCategoryDataSourceI) GetCategories(arg0 map[string]interface{}) ([]model.CategoryDs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategories", arg0)
+	ret0, _ := ret[0].([]model.CategoryDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *MockCategoryDataSourceIMockRecorder) GetCategories(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockCategoryDataSourceI)(nil).GetCategories), arg0)
+}
+
+// InsertCategory mocks base method.
+func (m *MockCategoryDataSourceI) InsertCategory(arg0 model.CategoryDs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCategory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertCategory indicates an expected call of InsertCategory.
+func (mr *MockCategoryDataSourceIMockRecorder) InsertCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCategory", reflect.TypeOf((*MockCategoryDataSourceI)(nil).InsertCategory), arg0)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCategoryDataSourceI) UpdateCategory(arg0 string, arg1 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCategoryDataSourceIMockRecorder) UpdateCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryDataSourceI)(nil).UpdateCategory), arg0, arg1)
+}
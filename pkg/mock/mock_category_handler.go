@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/handler (interfaces: CategoryHandlerI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCategoryHandlerI is a mock of CategoryHandlerI interface.
+type MockCategoryHandlerI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryHandlerIMockRecorder
+}
+
+// MockCategoryHandlerIMockRecorder is the mock recorder for MockCategoryHandlerI.
+type MockCategoryHandlerIMockRecorder struct {
+	mock *MockCategoryHandlerI
+}
+
+// NewMockCategoryHandlerI creates a new mock instance.
+func NewMockCategoryHandlerI(ctrl *gomock.Controller) *MockCategoryHandlerI {
+	mock := &MockCategoryHandlerI{ctrl: ctrl}
+	mock.recorder = &MockCategoryHandlerIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryHandlerI) EXPECT() *MockCategoryHandlerIMockRecorder {
+	return m.recorder
+}
+
+// AssignCategory mocks base method.
+func (m *MockCategoryHandlerI) AssignCategory(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AssignCategory", arg0, arg1)
+}
+
+// AssignCategory indicates an expected call of AssignCategory.
+func (mr *MockCategoryHandlerIMockRecorder) AssignCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignCategory", reflect.TypeOf((*MockCategoryHandlerI)(nil).AssignCategory), arg0, arg1)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategoryHandlerI) DeleteCategory(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategoryHandlerIMockRecorder) DeleteCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryHandlerI)(nil).DeleteCategory), arg0, arg1)
+}
+
+// GetCategories mocks base method.
+func (m *MockCategoryHandlerI) GetCategories(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetCategories", arg0, arg1)
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *MockCategoryHandlerIMockRecorder) GetCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockCategoryHandlerI)(nil).GetCategories), arg0, arg1)
+}
+
+// GetCategory mocks base method.
+func (m *MockCategoryHandlerI) GetCategory(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetCategory", arg0, arg1)
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockCategoryHandlerIMockRecorder) GetCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockCategoryHandlerI)(nil).GetCategory), arg0, arg1)
+}
+
+// GetCategoryArticles mocks base method.
+func (m *MockCategoryHandlerI) GetCategoryArticles(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetCategoryArticles", arg0, arg1)
+}
+
+// GetCategoryArticles indicates an expected call of GetCategoryArticles.
+func (mr *MockCategoryHandlerIMockRecorder) GetCategoryArticles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryArticles", reflect.TypeOf((*MockCategoryHandlerI)(nil).GetCategoryArticles), arg0, arg1)
+}
+
+// InsertCategory mocks base method.
+func (m *MockCategoryHandlerI) InsertCategory(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InsertCategory", arg0, arg1)
+}
+
+// InsertCategory indicates an expected call of InsertCategory.
+func (mr *MockCategoryHandlerIMockRecorder) InsertCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCategory", reflect.TypeOf((*MockCategoryHandlerI)(nil).InsertCategory), arg0, arg1)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCategoryHandlerI) UpdateCategory(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateCategory", arg0, arg1)
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCategoryHandlerIMockRecorder) UpdateCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryHandlerI)(nil).UpdateCategory), arg0, arg1)
+}
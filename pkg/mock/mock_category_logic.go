@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/logic (interfaces: CategoryLogicI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockCategoryLogicI is a mock of CategoryLogicI interface.
+type MockCategoryLogicI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryLogicIMockRecorder
+}
+
+// MockCategoryLogicIMockRecorder is the mock recorder for MockCategoryLogicI.
+type MockCategoryLogicIMockRecorder struct {
+	mock *MockCategoryLogicI
+}
+
+// NewMockCategoryLogicI creates a new mock instance.
+func NewMockCategoryLogicI(ctrl *gomock.Controller) *MockCategoryLogicI {
+	mock := &MockCategoryLogicI{ctrl: ctrl}
+	mock.recorder = &MockCategoryLogicIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryLogicI) EXPECT() *MockCategoryLogicIMockRecorder {
+	return m.recorder
+}
+
+// AssignCategory mocks base method.
+func (m *MockCategoryLogicI) AssignCategory(arg0 string, arg1 *model.AssignCategoryRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignCategory", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// AssignCategory indicates an expected call of AssignCategory.
+func (mr *MockCategoryLogicIMockRecorder) AssignCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignCategory", reflect.TypeOf((*MockCategoryLogicI)(nil).AssignCategory), arg0, arg1)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategoryLogicI) DeleteCategory(arg0 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategoryLogicIMockRecorder) DeleteCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryLogicI)(nil).DeleteCategory), arg0)
+}
+
+// GetCategories mocks base method.
+func (m *MockCategoryLogicI) GetCategories() *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategories")
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *MockCategoryLogicIMockRecorder) GetCategories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockCategoryLogicI)(nil).GetCategories))
+}
+
+// GetCategory mocks base method.
+func (m *MockCategoryLogicI) GetCategory(arg0 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockCategoryLogicIMockRecorder) GetCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockCategoryLogicI)(nil).GetCategory), arg0)
+}
+
+// GetCategoryArticles mocks base method.
+func (m *MockCategoryLogicI) GetCategoryArticles(arg0 string, arg1, arg2 int, arg3 bool) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryArticles", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetCategoryArticles indicates an expected call of GetCategoryArticles.
+func (mr *MockCategoryLogicIMockRecorder) GetCategoryArticles(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryArticles", reflect.TypeOf((*MockCategoryLogicI)(nil).GetCategoryArticles), arg0, arg1, arg2, arg3)
+}
+
+// InsertCategory mocks base method.
+func (m *MockCategoryLogicI) InsertCategory(arg0 *model.CategoryRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCategory", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// InsertCategory indicates an expected call of InsertCategory.
+func (mr *MockCategoryLogicIMockRecorder) InsertCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCategory", reflect.TypeOf((*MockCategoryLogicI)(nil).InsertCategory), arg0)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCategoryLogicI) UpdateCategory(arg0 string, arg1 *model.CategoryRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCategoryLogicIMockRecorder) UpdateCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryLogicI)(nil).UpdateCategory), arg0, arg1)
+}
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource (interfaces: CommentDataSourceI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockCommentDataSourceI is a mock of CommentDataSourceI interface.
+type MockCommentDataSourceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentDataSourceIMockRecorder
+}
+
+// MockCommentDataSourceIMockRecorder is the mock recorder for MockCommentDataSourceI.
+type MockCommentDataSourceIMockRecorder struct {
+	mock *MockCommentDataSourceI
+}
+
+// NewMockCommentDataSourceI creates a new mock instance.
+func NewMockCommentDataSourceI(ctrl *gomock.Controller) *MockCommentDataSourceI {
+	mock := &MockCommentDataSourceI{ctrl: ctrl}
+	mock.recorder = &MockCommentDataSourceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentDataSourceI) EXPECT() *MockCommentDataSourceIMockRecorder {
+	return m.recorder
+}
+
+// GetComments mocks base method.
+func (m *MockCommentDataSourceI) GetComments(arg0 map[string]interface{}, arg1, arg2 int) ([]model.CommentDs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.CommentDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentDataSourceIMockRecorder) GetComments(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockCommentDataSourceI)(nil).GetComments), arg0, arg1, arg2)
+}
+
+// InsertComment mocks base method.
+func (m *MockCommentDataSourceI) InsertComment(arg0 model.CommentDs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertComment", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertComment indicates an expected call of InsertComment.
+func (mr *MockCommentDataSourceIMockRecorder) InsertComment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertComment", reflect.TypeOf((*MockCommentDataSourceI)(nil).InsertComment), arg0)
+}
+
+// UpdateComment mocks base method.
+func (m *MockCommentDataSourceI) UpdateComment(arg0 string, arg1 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockCommentDataSourceIMockRecorder) UpdateComment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockCommentDataSourceI)(nil).UpdateComment), arg0, arg1)
+}
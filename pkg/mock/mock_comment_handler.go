@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/handler (interfaces: CommentHandlerI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCommentHandlerI is a mock of CommentHandlerI interface.
+type MockCommentHandlerI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentHandlerIMockRecorder
+}
+
+// MockCommentHandlerIMockRecorder is the mock recorder for MockCommentHandlerI.
+type MockCommentHandlerIMockRecorder struct {
+	mock *MockCommentHandlerI
+}
+
+// NewMockCommentHandlerI creates a new mock instance.
+func NewMockCommentHandlerI(ctrl *gomock.Controller) *MockCommentHandlerI {
+	mock := &MockCommentHandlerI{ctrl: ctrl}
+	mock.recorder = &MockCommentHandlerIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentHandlerI) EXPECT() *MockCommentHandlerIMockRecorder {
+	return m.recorder
+}
+
+// GetComments mocks base method.
+func (m *MockCommentHandlerI) GetComments(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetComments", arg0, arg1)
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentHandlerIMockRecorder) GetComments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockCommentHandlerI)(nil).GetComments), arg0, arg1)
+}
+
+// GetModerationQueue mocks base method.
+func (m *MockCommentHandlerI) GetModerationQueue(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetModerationQueue", arg0, arg1)
+}
+
+// GetModerationQueue indicates an expected call of GetModerationQueue.
+func (mr *MockCommentHandlerIMockRecorder) GetModerationQueue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationQueue", reflect.TypeOf((*MockCommentHandlerI)(nil).GetModerationQueue), arg0, arg1)
+}
+
+// ModerateComment mocks base method.
+func (m *MockCommentHandlerI) ModerateComment(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ModerateComment", arg0, arg1)
+}
+
+// ModerateComment indicates an expected call of ModerateComment.
+func (mr *MockCommentHandlerIMockRecorder) ModerateComment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateComment", reflect.TypeOf((*MockCommentHandlerI)(nil).ModerateComment), arg0, arg1)
+}
+
+// PostComment mocks base method.
+func (m *MockCommentHandlerI) PostComment(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PostComment", arg0, arg1)
+}
+
+// PostComment indicates an expected call of PostComment.
+func (mr *MockCommentHandlerIMockRecorder) PostComment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostComment", reflect.TypeOf((*MockCommentHandlerI)(nil).PostComment), arg0, arg1)
+}
@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/logic (interfaces: CommentLogicI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockCommentLogicI is a mock of CommentLogicI interface.
+type MockCommentLogicI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentLogicIMockRecorder
+}
+
+// MockCommentLogicIMockRecorder is the mock recorder for MockCommentLogicI.
+type MockCommentLogicIMockRecorder struct {
+	mock *MockCommentLogicI
+}
+
+// NewMockCommentLogicI creates a new mock instance.
+func NewMockCommentLogicI(ctrl *gomock.Controller) *MockCommentLogicI {
+	mock := &MockCommentLogicI{ctrl: ctrl}
+	mock.recorder = &MockCommentLogicIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentLogicI) EXPECT() *MockCommentLogicIMockRecorder {
+	return m.recorder
+}
+
+// GetComments mocks base method.
+func (m *MockCommentLogicI) GetComments(arg0 string, arg1, arg2 int) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentLogicIMockRecorder) GetComments(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockCommentLogicI)(nil).GetComments), arg0, arg1, arg2)
+}
+
+// GetModerationQueue mocks base method.
+func (m *MockCommentLogicI) GetModerationQueue(arg0 string, arg1, arg2 int) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationQueue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetModerationQueue indicates an expected call of GetModerationQueue.
+func (mr *MockCommentLogicIMockRecorder) GetModerationQueue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationQueue", reflect.TypeOf((*MockCommentLogicI)(nil).GetModerationQueue), arg0, arg1, arg2)
+}
+
+// ModerateComment mocks base method.
+func (m *MockCommentLogicI) ModerateComment(arg0, arg1, arg2 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateComment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// ModerateComment indicates an expected call of ModerateComment.
+func (mr *MockCommentLogicIMockRecorder) ModerateComment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateComment", reflect.TypeOf((*MockCommentLogicI)(nil).ModerateComment), arg0, arg1, arg2)
+}
+
+// PostComment mocks base method.
+func (m *MockCommentLogicI) PostComment(arg0 string, arg1 *model.CommentRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostComment", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// PostComment indicates an expected call of PostComment.
+func (mr *MockCommentLogicIMockRecorder) PostComment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostComment", reflect.TypeOf((*MockCommentLogicI)(nil).PostComment), arg0, arg1)
+}
@@ -6,6 +6,7 @@ package mock
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
@@ -34,6 +35,21 @@ func (m *MockDataSourceI) EXPECT() *MockDataSourceIMockRecorder {
 	return m.recorder
 }
 
+// Edit mocks base method.
+func (m *MockDataSourceI) Edit(arg0 string, arg1 model.ArticleEdit) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Edit", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Edit indicates an expected call of Edit.
+func (mr *MockDataSourceIMockRecorder) Edit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Edit", reflect.TypeOf((*MockDataSourceI)(nil).Edit), arg0, arg1)
+}
+
 // Get mocks base method.
 func (m *MockDataSourceI) Get(arg0 map[string]interface{}, arg1, arg2 int) ([]model.ArticleDs, error) {
 	m.ctrl.T.Helper()
@@ -49,16 +65,105 @@ func (mr *MockDataSourceIMockRecorder) Get(arg0, arg1, arg2 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDataSourceI)(nil).Get), arg0, arg1, arg2)
 }
 
+// GetDue mocks base method.
+func (m *MockDataSourceI) GetDue(arg0 time.Time) ([]model.ArticleDs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDue", arg0)
+	ret0, _ := ret[0].([]model.ArticleDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDue indicates an expected call of GetDue.
+func (mr *MockDataSourceIMockRecorder) GetDue(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockDataSourceI)(nil).GetDue), arg0)
+}
+
+// GetSlugOwners mocks base method.
+func (m *MockDataSourceI) GetSlugOwners(arg0 string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlugOwners", arg0)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlugOwners indicates an expected call of GetSlugOwners.
+func (mr *MockDataSourceIMockRecorder) GetSlugOwners(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlugOwners", reflect.TypeOf((*MockDataSourceI)(nil).GetSlugOwners), arg0)
+}
+
+// GetSlugRedirect mocks base method.
+func (m *MockDataSourceI) GetSlugRedirect(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlugRedirect", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlugRedirect indicates an expected call of GetSlugRedirect.
+func (mr *MockDataSourceIMockRecorder) GetSlugRedirect(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlugRedirect", reflect.TypeOf((*MockDataSourceI)(nil).GetSlugRedirect), arg0)
+}
+
+// GetTagCounts mocks base method.
+func (m *MockDataSourceI) GetTagCounts() ([]model.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagCounts")
+	ret0, _ := ret[0].([]model.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagCounts indicates an expected call of GetTagCounts.
+func (mr *MockDataSourceIMockRecorder) GetTagCounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagCounts", reflect.TypeOf((*MockDataSourceI)(nil).GetTagCounts))
+}
+
 // Insert mocks base method.
-func (m *MockDataSourceI) Insert(arg0 model.ArticleDs) error {
+func (m *MockDataSourceI) Insert(arg0 model.ArticleDs, arg1 model.RevisionDs) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Insert", arg0)
+	ret := m.ctrl.Call(m, "Insert", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Insert indicates an expected call of Insert.
-func (mr *MockDataSourceIMockRecorder) Insert(arg0 interface{}) *gomock.Call {
+func (mr *MockDataSourceIMockRecorder) Insert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDataSourceI)(nil).Insert), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockDataSourceI) Update(arg0 string, arg1 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDataSourceIMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDataSourceI)(nil).Update), arg0, arg1)
+}
+
+// UpdateIfStatus mocks base method.
+func (m *MockDataSourceI) UpdateIfStatus(arg0, arg1 string, arg2 map[string]interface{}) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIfStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIfStatus indicates an expected call of UpdateIfStatus.
+func (mr *MockDataSourceIMockRecorder) UpdateIfStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDataSourceI)(nil).Insert), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIfStatus", reflect.TypeOf((*MockDataSourceI)(nil).UpdateIfStatus), arg0, arg1, arg2)
 }
@@ -0,0 +1,61 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/views (interfaces: FlusherI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFlusherI is a mock of FlusherI interface.
+type MockFlusherI struct {
+	ctrl     *gomock.Controller
+	recorder *MockFlusherIMockRecorder
+}
+
+// MockFlusherIMockRecorder is the mock recorder for MockFlusherI.
+type MockFlusherIMockRecorder struct {
+	mock *MockFlusherI
+}
+
+// NewMockFlusherI creates a new mock instance.
+func NewMockFlusherI(ctrl *gomock.Controller) *MockFlusherI {
+	mock := &MockFlusherI{ctrl: ctrl}
+	mock.recorder = &MockFlusherIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFlusherI) EXPECT() *MockFlusherIMockRecorder {
+	return m.recorder
+}
+
+// Flush mocks base method.
+func (m *MockFlusherI) Flush() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockFlusherIMockRecorder) Flush() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockFlusherI)(nil).Flush))
+}
+
+// Run mocks base method.
+func (m *MockFlusherI) Run(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", arg0)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockFlusherIMockRecorder) Run(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockFlusherI)(nil).Run), arg0)
+}
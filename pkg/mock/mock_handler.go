@@ -34,6 +34,18 @@ func (m *MockArticleManagementHandlerI) EXPECT() *MockArticleManagementHandlerIM
 	return m.recorder
 }
 
+// DiffRevisions mocks base method.
+func (m *MockArticleManagementHandlerI) DiffRevisions(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DiffRevisions", arg0, arg1)
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockArticleManagementHandlerIMockRecorder) DiffRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockArticleManagementHandlerI)(nil).DiffRevisions), arg0, arg1)
+}
+
 // GetAllArticle mocks base method.
 func (m *MockArticleManagementHandlerI) GetAllArticle(arg0 http.ResponseWriter, arg1 *http.Request) {
 	m.ctrl.T.Helper()
@@ -58,6 +70,54 @@ func (mr *MockArticleManagementHandlerIMockRecorder) GetArticleById(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleById", reflect.TypeOf((*MockArticleManagementHandlerI)(nil).GetArticleById), arg0, arg1)
 }
 
+// GetArticleBySlug mocks base method.
+func (m *MockArticleManagementHandlerI) GetArticleBySlug(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetArticleBySlug", arg0, arg1)
+}
+
+// GetArticleBySlug indicates an expected call of GetArticleBySlug.
+func (mr *MockArticleManagementHandlerIMockRecorder) GetArticleBySlug(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleBySlug", reflect.TypeOf((*MockArticleManagementHandlerI)(nil).GetArticleBySlug), arg0, arg1)
+}
+
+// GetRevision mocks base method.
+func (m *MockArticleManagementHandlerI) GetRevision(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetRevision", arg0, arg1)
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockArticleManagementHandlerIMockRecorder) GetRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockArticleManagementHandlerI)(nil).GetRevision), arg0, arg1)
+}
+
+// GetRevisions mocks base method.
+func (m *MockArticleManagementHandlerI) GetRevisions(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetRevisions", arg0, arg1)
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockArticleManagementHandlerIMockRecorder) GetRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockArticleManagementHandlerI)(nil).GetRevisions), arg0, arg1)
+}
+
+// GetTags mocks base method.
+func (m *MockArticleManagementHandlerI) GetTags(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetTags", arg0, arg1)
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockArticleManagementHandlerIMockRecorder) GetTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockArticleManagementHandlerI)(nil).GetTags), arg0, arg1)
+}
+
 // InsertArticle mocks base method.
 func (m *MockArticleManagementHandlerI) InsertArticle(arg0 http.ResponseWriter, arg1 *http.Request) {
 	m.ctrl.T.Helper()
@@ -82,6 +142,18 @@ func (mr *MockArticleManagementHandlerIMockRecorder) MethodNotAllowed(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MethodNotAllowed", reflect.TypeOf((*MockArticleManagementHandlerI)(nil).MethodNotAllowed), arg0, arg1)
 }
 
+// RestoreRevision mocks base method.
+func (m *MockArticleManagementHandlerI) RestoreRevision(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RestoreRevision", arg0, arg1)
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockArticleManagementHandlerIMockRecorder) RestoreRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockArticleManagementHandlerI)(nil).RestoreRevision), arg0, arg1)
+}
+
 // RouteNotFound mocks base method.
 func (m *MockArticleManagementHandlerI) RouteNotFound(arg0 http.ResponseWriter, arg1 *http.Request) {
 	m.ctrl.T.Helper()
@@ -93,3 +165,39 @@ func (mr *MockArticleManagementHandlerIMockRecorder) RouteNotFound(arg0, arg1 in
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RouteNotFound", reflect.TypeOf((*MockArticleManagementHandlerI)(nil).RouteNotFound), arg0, arg1)
 }
+
+// ScheduleArticle mocks base method.
+func (m *MockArticleManagementHandlerI) ScheduleArticle(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ScheduleArticle", arg0, arg1)
+}
+
+// ScheduleArticle indicates an expected call of ScheduleArticle.
+func (mr *MockArticleManagementHandlerIMockRecorder) ScheduleArticle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleArticle", reflect.TypeOf((*MockArticleManagementHandlerI)(nil).ScheduleArticle), arg0, arg1)
+}
+
+// TransitionArticle mocks base method.
+func (m *MockArticleManagementHandlerI) TransitionArticle(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TransitionArticle", arg0, arg1)
+}
+
+// TransitionArticle indicates an expected call of TransitionArticle.
+func (mr *MockArticleManagementHandlerIMockRecorder) TransitionArticle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionArticle", reflect.TypeOf((*MockArticleManagementHandlerI)(nil).TransitionArticle), arg0, arg1)
+}
+
+// UpdateArticle mocks base method.
+func (m *MockArticleManagementHandlerI) UpdateArticle(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateArticle", arg0, arg1)
+}
+
+// UpdateArticle indicates an expected call of UpdateArticle.
+func (mr *MockArticleManagementHandlerIMockRecorder) UpdateArticle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArticle", reflect.TypeOf((*MockArticleManagementHandlerI)(nil).UpdateArticle), arg0, arg1)
+}
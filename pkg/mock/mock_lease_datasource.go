@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource (interfaces: LeaseDataSourceI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLeaseDataSourceI is a mock of LeaseDataSourceI interface.
+type MockLeaseDataSourceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeaseDataSourceIMockRecorder
+}
+
+// MockLeaseDataSourceIMockRecorder is the mock recorder for MockLeaseDataSourceI.
+type MockLeaseDataSourceIMockRecorder struct {
+	mock *MockLeaseDataSourceI
+}
+
+// NewMockLeaseDataSourceI creates a new mock instance.
+func NewMockLeaseDataSourceI(ctrl *gomock.Controller) *MockLeaseDataSourceI {
+	mock := &MockLeaseDataSourceI{ctrl: ctrl}
+	mock.recorder = &MockLeaseDataSourceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLeaseDataSourceI) EXPECT() *MockLeaseDataSourceIMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockLeaseDataSourceI) Acquire(arg0, arg1 string, arg2 time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockLeaseDataSourceIMockRecorder) Acquire(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockLeaseDataSourceI)(nil).Acquire), arg0, arg1, arg2)
+}
+
+// Release mocks base method.
+func (m *MockLeaseDataSourceI) Release(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockLeaseDataSourceIMockRecorder) Release(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockLeaseDataSourceI)(nil).Release), arg0, arg1)
+}
@@ -6,6 +6,7 @@ package mock
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
@@ -34,18 +35,32 @@ func (m *MockArticleManagementLogicI) EXPECT() *MockArticleManagementLogicIMockR
 	return m.recorder
 }
 
+// DiffRevisions mocks base method.
+func (m *MockArticleManagementLogicI) DiffRevisions(arg0 string, arg1, arg2 int) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockArticleManagementLogicIMockRecorder) DiffRevisions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockArticleManagementLogicI)(nil).DiffRevisions), arg0, arg1, arg2)
+}
+
 // GetAllArticle mocks base method.
-func (m *MockArticleManagementLogicI) GetAllArticle(arg0, arg1 int) *model.Response {
+func (m *MockArticleManagementLogicI) GetAllArticle(arg0, arg1 int, arg2 map[string]interface{}) *model.Response {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllArticle", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetAllArticle", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*model.Response)
 	return ret0
 }
 
 // GetAllArticle indicates an expected call of GetAllArticle.
-func (mr *MockArticleManagementLogicIMockRecorder) GetAllArticle(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockArticleManagementLogicIMockRecorder) GetAllArticle(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllArticle", reflect.TypeOf((*MockArticleManagementLogicI)(nil).GetAllArticle), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllArticle", reflect.TypeOf((*MockArticleManagementLogicI)(nil).GetAllArticle), arg0, arg1, arg2)
 }
 
 // GetArticle mocks base method.
@@ -62,6 +77,62 @@ func (mr *MockArticleManagementLogicIMockRecorder) GetArticle(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticle", reflect.TypeOf((*MockArticleManagementLogicI)(nil).GetArticle), arg0)
 }
 
+// GetArticleBySlug mocks base method.
+func (m *MockArticleManagementLogicI) GetArticleBySlug(arg0 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleBySlug", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetArticleBySlug indicates an expected call of GetArticleBySlug.
+func (mr *MockArticleManagementLogicIMockRecorder) GetArticleBySlug(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleBySlug", reflect.TypeOf((*MockArticleManagementLogicI)(nil).GetArticleBySlug), arg0)
+}
+
+// GetRevision mocks base method.
+func (m *MockArticleManagementLogicI) GetRevision(arg0 string, arg1 int) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockArticleManagementLogicIMockRecorder) GetRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockArticleManagementLogicI)(nil).GetRevision), arg0, arg1)
+}
+
+// GetRevisions mocks base method.
+func (m *MockArticleManagementLogicI) GetRevisions(arg0 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockArticleManagementLogicIMockRecorder) GetRevisions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockArticleManagementLogicI)(nil).GetRevisions), arg0)
+}
+
+// GetTags mocks base method.
+func (m *MockArticleManagementLogicI) GetTags() *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags")
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockArticleManagementLogicIMockRecorder) GetTags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockArticleManagementLogicI)(nil).GetTags))
+}
+
 // InsertArticle mocks base method.
 func (m *MockArticleManagementLogicI) InsertArticle(arg0 *model.Article) *model.Response {
 	m.ctrl.T.Helper()
@@ -75,3 +146,74 @@ func (mr *MockArticleManagementLogicIMockRecorder) InsertArticle(arg0 interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertArticle", reflect.TypeOf((*MockArticleManagementLogicI)(nil).InsertArticle), arg0)
 }
+
+// RestoreRevision mocks base method.
+func (m *MockArticleManagementLogicI) RestoreRevision(arg0 string, arg1, arg2 int, arg3 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockArticleManagementLogicIMockRecorder) RestoreRevision(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockArticleManagementLogicI)(nil).RestoreRevision), arg0, arg1, arg2, arg3)
+}
+
+// RunSchedule mocks base method.
+func (m *MockArticleManagementLogicI) RunSchedule(arg0 time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunSchedule", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunSchedule indicates an expected call of RunSchedule.
+func (mr *MockArticleManagementLogicIMockRecorder) RunSchedule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunSchedule", reflect.TypeOf((*MockArticleManagementLogicI)(nil).RunSchedule), arg0)
+}
+
+// ScheduleArticle mocks base method.
+func (m *MockArticleManagementLogicI) ScheduleArticle(arg0 string, arg1 *model.ScheduleRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleArticle", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// ScheduleArticle indicates an expected call of ScheduleArticle.
+func (mr *MockArticleManagementLogicIMockRecorder) ScheduleArticle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleArticle", reflect.TypeOf((*MockArticleManagementLogicI)(nil).ScheduleArticle), arg0, arg1)
+}
+
+// TransitionArticle mocks base method.
+func (m *MockArticleManagementLogicI) TransitionArticle(arg0, arg1 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionArticle", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// TransitionArticle indicates an expected call of TransitionArticle.
+func (mr *MockArticleManagementLogicIMockRecorder) TransitionArticle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionArticle", reflect.TypeOf((*MockArticleManagementLogicI)(nil).TransitionArticle), arg0, arg1)
+}
+
+// UpdateArticle mocks base method.
+func (m *MockArticleManagementLogicI) UpdateArticle(arg0 string, arg1 *model.Article) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateArticle", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// UpdateArticle indicates an expected call of UpdateArticle.
+func (mr *MockArticleManagementLogicIMockRecorder) UpdateArticle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArticle", reflect.TypeOf((*MockArticleManagementLogicI)(nil).UpdateArticle), arg0, arg1)
+}
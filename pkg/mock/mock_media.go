@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/media (interfaces: ProcessorI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	media "github.com/vatsal-chaturvedi/article-management-sys/internal/media"
+)
+
+// MockProcessorI is a mock of ProcessorI interface.
+type MockProcessorI struct {
+	ctrl     *gomock.Controller
+	recorder *MockProcessorIMockRecorder
+}
+
+// MockProcessorIMockRecorder is the mock recorder for MockProcessorI.
+type MockProcessorIMockRecorder struct {
+	mock *MockProcessorI
+}
+
+// NewMockProcessorI creates a new mock instance.
+func NewMockProcessorI(ctrl *gomock.Controller) *MockProcessorI {
+	mock := &MockProcessorI{ctrl: ctrl}
+	mock.recorder = &MockProcessorIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProcessorI) EXPECT() *MockProcessorIMockRecorder {
+	return m.recorder
+}
+
+// MaxBytes mocks base method.
+func (m *MockProcessorI) MaxBytes() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxBytes")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// MaxBytes indicates an expected call of MaxBytes.
+func (mr *MockProcessorIMockRecorder) MaxBytes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxBytes", reflect.TypeOf((*MockProcessorI)(nil).MaxBytes))
+}
+
+// Process mocks base method.
+func (m *MockProcessorI) Process(arg0 []byte) (*media.Processed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Process", arg0)
+	ret0, _ := ret[0].(*media.Processed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Process indicates an expected call of Process.
+func (mr *MockProcessorIMockRecorder) Process(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockProcessorI)(nil).Process), arg0)
+}
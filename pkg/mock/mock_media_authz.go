@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/authz (interfaces: MediaPolicyI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockMediaPolicyI is a mock of MediaPolicyI interface.
+type MockMediaPolicyI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaPolicyIMockRecorder
+}
+
+// MockMediaPolicyIMockRecorder is the mock recorder for MockMediaPolicyI.
+type MockMediaPolicyIMockRecorder struct {
+	mock *MockMediaPolicyI
+}
+
+// NewMockMediaPolicyI creates a new mock instance.
+func NewMockMediaPolicyI(ctrl *gomock.Controller) *MockMediaPolicyI {
+	mock := &MockMediaPolicyI{ctrl: ctrl}
+	mock.recorder = &MockMediaPolicyIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaPolicyI) EXPECT() *MockMediaPolicyIMockRecorder {
+	return m.recorder
+}
+
+// DeleteMedia mocks base method.
+func (m *MockMediaPolicyI) DeleteMedia(arg0 *model.Identity, arg1, arg2 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMedia", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// DeleteMedia indicates an expected call of DeleteMedia.
+func (mr *MockMediaPolicyIMockRecorder) DeleteMedia(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedia", reflect.TypeOf((*MockMediaPolicyI)(nil).DeleteMedia), arg0, arg1, arg2)
+}
+
+// GetArticleMedia mocks base method.
+func (m *MockMediaPolicyI) GetArticleMedia(arg0 *model.Identity, arg1 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleMedia", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetArticleMedia indicates an expected call of GetArticleMedia.
+func (mr *MockMediaPolicyIMockRecorder) GetArticleMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleMedia", reflect.TypeOf((*MockMediaPolicyI)(nil).GetArticleMedia), arg0, arg1)
+}
+
+// OpenMedia mocks base method.
+func (m *MockMediaPolicyI) OpenMedia(arg0 *model.Identity, arg1 string, arg2 bool) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenMedia", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// OpenMedia indicates an expected call of OpenMedia.
+func (mr *MockMediaPolicyIMockRecorder) OpenMedia(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenMedia", reflect.TypeOf((*MockMediaPolicyI)(nil).OpenMedia), arg0, arg1, arg2)
+}
+
+// UploadMedia mocks base method.
+func (m *MockMediaPolicyI) UploadMedia(arg0 *model.Identity, arg1 string, arg2 *model.MediaUpload) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadMedia", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// UploadMedia indicates an expected call of UploadMedia.
+func (mr *MockMediaPolicyIMockRecorder) UploadMedia(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadMedia", reflect.TypeOf((*MockMediaPolicyI)(nil).UploadMedia), arg0, arg1, arg2)
+}
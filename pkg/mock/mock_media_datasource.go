@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource (interfaces: MediaDataSourceI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockMediaDataSourceI is a mock of MediaDataSourceI interface.
+type MockMediaDataSourceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaDataSourceIMockRecorder
+}
+
+// MockMediaDataSourceIMockRecorder is the mock recorder for MockMediaDataSourceI.
+type MockMediaDataSourceIMockRecorder struct {
+	mock *MockMediaDataSourceI
+}
+
+// NewMockMediaDataSourceI creates a new mock instance.
+func NewMockMediaDataSourceI(ctrl *gomock.Controller) *MockMediaDataSourceI {
+	mock := &MockMediaDataSourceI{ctrl: ctrl}
+	mock.recorder = &MockMediaDataSourceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaDataSourceI) EXPECT() *MockMediaDataSourceIMockRecorder {
+	return m.recorder
+}
+
+// DeleteMedia mocks base method.
+func (m *MockMediaDataSourceI) DeleteMedia(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMedia", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMedia indicates an expected call of DeleteMedia.
+func (mr *MockMediaDataSourceIMockRecorder) DeleteMedia(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedia", reflect.TypeOf((*MockMediaDataSourceI)(nil).DeleteMedia), arg0)
+}
+
+// GetMedia mocks base method.
+func (m *MockMediaDataSourceI) GetMedia(arg0 map[string]interface{}) ([]model.MediaDs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedia", arg0)
+	ret0, _ := ret[0].([]model.MediaDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMedia indicates an expected call of GetMedia.
+func (mr *MockMediaDataSourceIMockRecorder) GetMedia(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedia", reflect.TypeOf((*MockMediaDataSourceI)(nil).GetMedia), arg0)
+}
+
+// InsertMedia mocks base method.
+func (m *MockMediaDataSourceI) InsertMedia(arg0 model.MediaDs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertMedia", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertMedia indicates an expected call of InsertMedia.
+func (mr *MockMediaDataSourceIMockRecorder) InsertMedia(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMedia", reflect.TypeOf((*MockMediaDataSourceI)(nil).InsertMedia), arg0)
+}
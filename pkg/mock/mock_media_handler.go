@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/handler (interfaces: MediaHandlerI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMediaHandlerI is a mock of MediaHandlerI interface.
+type MockMediaHandlerI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaHandlerIMockRecorder
+}
+
+// MockMediaHandlerIMockRecorder is the mock recorder for MockMediaHandlerI.
+type MockMediaHandlerIMockRecorder struct {
+	mock *MockMediaHandlerI
+}
+
+// NewMockMediaHandlerI creates a new mock instance.
+func NewMockMediaHandlerI(ctrl *gomock.Controller) *MockMediaHandlerI {
+	mock := &MockMediaHandlerI{ctrl: ctrl}
+	mock.recorder = &MockMediaHandlerIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaHandlerI) EXPECT() *MockMediaHandlerIMockRecorder {
+	return m.recorder
+}
+
+// DeleteMedia mocks base method.
+func (m *MockMediaHandlerI) DeleteMedia(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteMedia", arg0, arg1)
+}
+
+// DeleteMedia indicates an expected call of DeleteMedia.
+func (mr *MockMediaHandlerIMockRecorder) DeleteMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedia", reflect.TypeOf((*MockMediaHandlerI)(nil).DeleteMedia), arg0, arg1)
+}
+
+// GetArticleMedia mocks base method.
+func (m *MockMediaHandlerI) GetArticleMedia(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetArticleMedia", arg0, arg1)
+}
+
+// GetArticleMedia indicates an expected call of GetArticleMedia.
+func (mr *MockMediaHandlerIMockRecorder) GetArticleMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleMedia", reflect.TypeOf((*MockMediaHandlerI)(nil).GetArticleMedia), arg0, arg1)
+}
+
+// GetMediaFile mocks base method.
+func (m *MockMediaHandlerI) GetMediaFile(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetMediaFile", arg0, arg1)
+}
+
+// GetMediaFile indicates an expected call of GetMediaFile.
+func (mr *MockMediaHandlerIMockRecorder) GetMediaFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaFile", reflect.TypeOf((*MockMediaHandlerI)(nil).GetMediaFile), arg0, arg1)
+}
+
+// GetMediaThumbnail mocks base method.
+func (m *MockMediaHandlerI) GetMediaThumbnail(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetMediaThumbnail", arg0, arg1)
+}
+
+// GetMediaThumbnail indicates an expected call of GetMediaThumbnail.
+func (mr *MockMediaHandlerIMockRecorder) GetMediaThumbnail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaThumbnail", reflect.TypeOf((*MockMediaHandlerI)(nil).GetMediaThumbnail), arg0, arg1)
+}
+
+// UploadMedia mocks base method.
+func (m *MockMediaHandlerI) UploadMedia(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UploadMedia", arg0, arg1)
+}
+
+// UploadMedia indicates an expected call of UploadMedia.
+func (mr *MockMediaHandlerIMockRecorder) UploadMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadMedia", reflect.TypeOf((*MockMediaHandlerI)(nil).UploadMedia), arg0, arg1)
+}
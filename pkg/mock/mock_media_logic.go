@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/logic (interfaces: MediaLogicI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockMediaLogicI is a mock of MediaLogicI interface.
+type MockMediaLogicI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaLogicIMockRecorder
+}
+
+// MockMediaLogicIMockRecorder is the mock recorder for MockMediaLogicI.
+type MockMediaLogicIMockRecorder struct {
+	mock *MockMediaLogicI
+}
+
+// NewMockMediaLogicI creates a new mock instance.
+func NewMockMediaLogicI(ctrl *gomock.Controller) *MockMediaLogicI {
+	mock := &MockMediaLogicI{ctrl: ctrl}
+	mock.recorder = &MockMediaLogicIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaLogicI) EXPECT() *MockMediaLogicIMockRecorder {
+	return m.recorder
+}
+
+// DeleteMedia mocks base method.
+func (m *MockMediaLogicI) DeleteMedia(arg0, arg1 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMedia", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// DeleteMedia indicates an expected call of DeleteMedia.
+func (mr *MockMediaLogicIMockRecorder) DeleteMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedia", reflect.TypeOf((*MockMediaLogicI)(nil).DeleteMedia), arg0, arg1)
+}
+
+// GetArticleMedia mocks base method.
+func (m *MockMediaLogicI) GetArticleMedia(arg0 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleMedia", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetArticleMedia indicates an expected call of GetArticleMedia.
+func (mr *MockMediaLogicIMockRecorder) GetArticleMedia(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleMedia", reflect.TypeOf((*MockMediaLogicI)(nil).GetArticleMedia), arg0)
+}
+
+// GetMedia mocks base method.
+func (m *MockMediaLogicI) GetMedia(arg0 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedia", arg0)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetMedia indicates an expected call of GetMedia.
+func (mr *MockMediaLogicIMockRecorder) GetMedia(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedia", reflect.TypeOf((*MockMediaLogicI)(nil).GetMedia), arg0)
+}
+
+// OpenMedia mocks base method.
+func (m *MockMediaLogicI) OpenMedia(arg0 model.MediaDs, arg1 bool) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenMedia", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// OpenMedia indicates an expected call of OpenMedia.
+func (mr *MockMediaLogicIMockRecorder) OpenMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenMedia", reflect.TypeOf((*MockMediaLogicI)(nil).OpenMedia), arg0, arg1)
+}
+
+// UploadMedia mocks base method.
+func (m *MockMediaLogicI) UploadMedia(arg0 string, arg1 *model.MediaUpload) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadMedia", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// UploadMedia indicates an expected call of UploadMedia.
+func (mr *MockMediaLogicIMockRecorder) UploadMedia(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadMedia", reflect.TypeOf((*MockMediaLogicI)(nil).UploadMedia), arg0, arg1)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/ratelimit (interfaces: LimiterI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	ratelimit "github.com/vatsal-chaturvedi/article-management-sys/internal/ratelimit"
+)
+
+// MockLimiterI is a mock of LimiterI interface.
+type MockLimiterI struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimiterIMockRecorder
+}
+
+// MockLimiterIMockRecorder is the mock recorder for MockLimiterI.
+type MockLimiterIMockRecorder struct {
+	mock *MockLimiterI
+}
+
+// NewMockLimiterI creates a new mock instance.
+func NewMockLimiterI(ctrl *gomock.Controller) *MockLimiterI {
+	mock := &MockLimiterI{ctrl: ctrl}
+	mock.recorder = &MockLimiterIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimiterI) EXPECT() *MockLimiterIMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockLimiterI) Allow(arg0 string, arg1 ratelimit.Limit) (ratelimit.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", arg0, arg1)
+	ret0, _ := ret[0].(ratelimit.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockLimiterIMockRecorder) Allow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockLimiterI)(nil).Allow), arg0, arg1)
+}
@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/related (interfaces: IndexI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+	related "github.com/vatsal-chaturvedi/article-management-sys/internal/related"
+)
+
+// MockIndexI is a mock of IndexI interface.
+type MockIndexI struct {
+	ctrl     *gomock.Controller
+	recorder *MockIndexIMockRecorder
+}
+
+// MockIndexIMockRecorder is the mock recorder for MockIndexI.
+type MockIndexIMockRecorder struct {
+	mock *MockIndexI
+}
+
+// NewMockIndexI creates a new mock instance.
+func NewMockIndexI(ctrl *gomock.Controller) *MockIndexI {
+	mock := &MockIndexI{ctrl: ctrl}
+	mock.recorder = &MockIndexIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIndexI) EXPECT() *MockIndexIMockRecorder {
+	return m.recorder
+}
+
+// Build mocks base method.
+func (m *MockIndexI) Build(arg0 []model.ArticleDs) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Build", arg0)
+}
+
+// Build indicates an expected call of Build.
+func (mr *MockIndexIMockRecorder) Build(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockIndexI)(nil).Build), arg0)
+}
+
+// Related mocks base method.
+func (m *MockIndexI) Related(arg0 model.ArticleDs, arg1 int) []related.Match {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Related", arg0, arg1)
+	ret0, _ := ret[0].([]related.Match)
+	return ret0
+}
+
+// Related indicates an expected call of Related.
+func (mr *MockIndexIMockRecorder) Related(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Related", reflect.TypeOf((*MockIndexI)(nil).Related), arg0, arg1)
+}
@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/handler (interfaces: RelatedHandlerI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRelatedHandlerI is a mock of RelatedHandlerI interface.
+type MockRelatedHandlerI struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelatedHandlerIMockRecorder
+}
+
+// MockRelatedHandlerIMockRecorder is the mock recorder for MockRelatedHandlerI.
+type MockRelatedHandlerIMockRecorder struct {
+	mock *MockRelatedHandlerI
+}
+
+// NewMockRelatedHandlerI creates a new mock instance.
+func NewMockRelatedHandlerI(ctrl *gomock.Controller) *MockRelatedHandlerI {
+	mock := &MockRelatedHandlerI{ctrl: ctrl}
+	mock.recorder = &MockRelatedHandlerIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelatedHandlerI) EXPECT() *MockRelatedHandlerIMockRecorder {
+	return m.recorder
+}
+
+// GetRelatedArticles mocks base method.
+func (m *MockRelatedHandlerI) GetRelatedArticles(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetRelatedArticles", arg0, arg1)
+}
+
+// GetRelatedArticles indicates an expected call of GetRelatedArticles.
+func (mr *MockRelatedHandlerIMockRecorder) GetRelatedArticles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelatedArticles", reflect.TypeOf((*MockRelatedHandlerI)(nil).GetRelatedArticles), arg0, arg1)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/logic (interfaces: RelatedLogicI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockRelatedLogicI is a mock of RelatedLogicI interface.
+type MockRelatedLogicI struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelatedLogicIMockRecorder
+}
+
+// MockRelatedLogicIMockRecorder is the mock recorder for MockRelatedLogicI.
+type MockRelatedLogicIMockRecorder struct {
+	mock *MockRelatedLogicI
+}
+
+// NewMockRelatedLogicI creates a new mock instance.
+func NewMockRelatedLogicI(ctrl *gomock.Controller) *MockRelatedLogicI {
+	mock := &MockRelatedLogicI{ctrl: ctrl}
+	mock.recorder = &MockRelatedLogicIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelatedLogicI) EXPECT() *MockRelatedLogicIMockRecorder {
+	return m.recorder
+}
+
+// GetRelatedArticles mocks base method.
+func (m *MockRelatedLogicI) GetRelatedArticles(arg0 string, arg1 int) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelatedArticles", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetRelatedArticles indicates an expected call of GetRelatedArticles.
+func (mr *MockRelatedLogicIMockRecorder) GetRelatedArticles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelatedArticles", reflect.TypeOf((*MockRelatedLogicI)(nil).GetRelatedArticles), arg0, arg1)
+}
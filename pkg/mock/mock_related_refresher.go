@@ -0,0 +1,61 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/related (interfaces: RefresherI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRefresherI is a mock of RefresherI interface.
+type MockRefresherI struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefresherIMockRecorder
+}
+
+// MockRefresherIMockRecorder is the mock recorder for MockRefresherI.
+type MockRefresherIMockRecorder struct {
+	mock *MockRefresherI
+}
+
+// NewMockRefresherI creates a new mock instance.
+func NewMockRefresherI(ctrl *gomock.Controller) *MockRefresherI {
+	mock := &MockRefresherI{ctrl: ctrl}
+	mock.recorder = &MockRefresherIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefresherI) EXPECT() *MockRefresherIMockRecorder {
+	return m.recorder
+}
+
+// Refresh mocks base method.
+func (m *MockRefresherI) Refresh() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockRefresherIMockRecorder) Refresh() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockRefresherI)(nil).Refresh))
+}
+
+// Run mocks base method.
+func (m *MockRefresherI) Run(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", arg0)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockRefresherIMockRecorder) Run(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockRefresherI)(nil).Run), arg0)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource (interfaces: RevisionDataSourceI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockRevisionDataSourceI is a mock of RevisionDataSourceI interface.
+type MockRevisionDataSourceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionDataSourceIMockRecorder
+}
+
+// MockRevisionDataSourceIMockRecorder is the mock recorder for MockRevisionDataSourceI.
+type MockRevisionDataSourceIMockRecorder struct {
+	mock *MockRevisionDataSourceI
+}
+
+// NewMockRevisionDataSourceI creates a new mock instance.
+func NewMockRevisionDataSourceI(ctrl *gomock.Controller) *MockRevisionDataSourceI {
+	mock := &MockRevisionDataSourceI{ctrl: ctrl}
+	mock.recorder = &MockRevisionDataSourceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionDataSourceI) EXPECT() *MockRevisionDataSourceIMockRecorder {
+	return m.recorder
+}
+
+// GetRevisions mocks base method.
+func (m *MockRevisionDataSourceI) GetRevisions(arg0 map[string]interface{}) ([]model.RevisionDs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", arg0)
+	ret0, _ := ret[0].([]model.RevisionDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockRevisionDataSourceIMockRecorder) GetRevisions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockRevisionDataSourceI)(nil).GetRevisions), arg0)
+}
@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/scheduler (interfaces: SchedulerI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSchedulerI is a mock of SchedulerI interface.
+type MockSchedulerI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulerIMockRecorder
+}
+
+// MockSchedulerIMockRecorder is the mock recorder for MockSchedulerI.
+type MockSchedulerIMockRecorder struct {
+	mock *MockSchedulerI
+}
+
+// NewMockSchedulerI creates a new mock instance.
+func NewMockSchedulerI(ctrl *gomock.Controller) *MockSchedulerI {
+	mock := &MockSchedulerI{ctrl: ctrl}
+	mock.recorder = &MockSchedulerIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedulerI) EXPECT() *MockSchedulerIMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockSchedulerI) Run(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", arg0)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockSchedulerIMockRecorder) Run(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockSchedulerI)(nil).Run), arg0)
+}
+
+// Tick mocks base method.
+func (m *MockSchedulerI) Tick(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tick", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Tick indicates an expected call of Tick.
+func (mr *MockSchedulerIMockRecorder) Tick(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tick", reflect.TypeOf((*MockSchedulerI)(nil).Tick), arg0)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource (interfaces: SchemaDataSourceI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSchemaDataSourceI is a mock of SchemaDataSourceI interface.
+type MockSchemaDataSourceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchemaDataSourceIMockRecorder
+}
+
+// MockSchemaDataSourceIMockRecorder is the mock recorder for MockSchemaDataSourceI.
+type MockSchemaDataSourceIMockRecorder struct {
+	mock *MockSchemaDataSourceI
+}
+
+// NewMockSchemaDataSourceI creates a new mock instance.
+func NewMockSchemaDataSourceI(ctrl *gomock.Controller) *MockSchemaDataSourceI {
+	mock := &MockSchemaDataSourceI{ctrl: ctrl}
+	mock.recorder = &MockSchemaDataSourceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchemaDataSourceI) EXPECT() *MockSchemaDataSourceIMockRecorder {
+	return m.recorder
+}
+
+// Migrate mocks base method.
+func (m *MockSchemaDataSourceI) Migrate() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Migrate")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Migrate indicates an expected call of Migrate.
+func (mr *MockSchemaDataSourceIMockRecorder) Migrate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockSchemaDataSourceI)(nil).Migrate))
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/auth (interfaces: TokenVerifierI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockTokenVerifierI is a mock of TokenVerifierI interface.
+type MockTokenVerifierI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenVerifierIMockRecorder
+}
+
+// MockTokenVerifierIMockRecorder is the mock recorder for MockTokenVerifierI.
+type MockTokenVerifierIMockRecorder struct {
+	mock *MockTokenVerifierI
+}
+
+// NewMockTokenVerifierI creates a new mock instance.
+func NewMockTokenVerifierI(ctrl *gomock.Controller) *MockTokenVerifierI {
+	mock := &MockTokenVerifierI{ctrl: ctrl}
+	mock.recorder = &MockTokenVerifierIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenVerifierI) EXPECT() *MockTokenVerifierIMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockTokenVerifierI) Verify(arg0 string) (*model.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", arg0)
+	ret0, _ := ret[0].(*model.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockTokenVerifierIMockRecorder) Verify(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockTokenVerifierI)(nil).Verify), arg0)
+}
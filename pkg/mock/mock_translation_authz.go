@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/authz (interfaces: TranslationPolicyI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockTranslationPolicyI is a mock of TranslationPolicyI interface.
+type MockTranslationPolicyI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationPolicyIMockRecorder
+}
+
+// MockTranslationPolicyIMockRecorder is the mock recorder for MockTranslationPolicyI.
+type MockTranslationPolicyIMockRecorder struct {
+	mock *MockTranslationPolicyI
+}
+
+// NewMockTranslationPolicyI creates a new mock instance.
+func NewMockTranslationPolicyI(ctrl *gomock.Controller) *MockTranslationPolicyI {
+	mock := &MockTranslationPolicyI{ctrl: ctrl}
+	mock.recorder = &MockTranslationPolicyIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationPolicyI) EXPECT() *MockTranslationPolicyIMockRecorder {
+	return m.recorder
+}
+
+// DeleteTranslation mocks base method.
+func (m *MockTranslationPolicyI) DeleteTranslation(arg0 *model.Identity, arg1, arg2 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTranslation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// DeleteTranslation indicates an expected call of DeleteTranslation.
+func (mr *MockTranslationPolicyIMockRecorder) DeleteTranslation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTranslation", reflect.TypeOf((*MockTranslationPolicyI)(nil).DeleteTranslation), arg0, arg1, arg2)
+}
+
+// PutTranslation mocks base method.
+func (m *MockTranslationPolicyI) PutTranslation(arg0 *model.Identity, arg1, arg2 string, arg3 *model.TranslationRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutTranslation", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// PutTranslation indicates an expected call of PutTranslation.
+func (mr *MockTranslationPolicyIMockRecorder) PutTranslation(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTranslation", reflect.TypeOf((*MockTranslationPolicyI)(nil).PutTranslation), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource (interfaces: TranslationDataSourceI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockTranslationDataSourceI is a mock of TranslationDataSourceI interface.
+type MockTranslationDataSourceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationDataSourceIMockRecorder
+}
+
+// MockTranslationDataSourceIMockRecorder is the mock recorder for MockTranslationDataSourceI.
+type MockTranslationDataSourceIMockRecorder struct {
+	mock *MockTranslationDataSourceI
+}
+
+// NewMockTranslationDataSourceI creates a new mock instance.
+func NewMockTranslationDataSourceI(ctrl *gomock.Controller) *MockTranslationDataSourceI {
+	mock := &MockTranslationDataSourceI{ctrl: ctrl}
+	mock.recorder = &MockTranslationDataSourceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationDataSourceI) EXPECT() *MockTranslationDataSourceIMockRecorder {
+	return m.recorder
+}
+
+// DeleteTranslation mocks base method.
+func (m *MockTranslationDataSourceI) DeleteTranslation(arg0, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTranslation", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTranslation indicates an expected call of DeleteTranslation.
+func (mr *MockTranslationDataSourceIMockRecorder) DeleteTranslation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTranslation", reflect.TypeOf((*MockTranslationDataSourceI)(nil).DeleteTranslation), arg0, arg1)
+}
+
+// PutTranslation mocks base method.
+func (m *MockTranslationDataSourceI) PutTranslation(arg0 model.TranslationDs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutTranslation", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutTranslation indicates an expected call of PutTranslation.
+func (mr *MockTranslationDataSourceIMockRecorder) PutTranslation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTranslation", reflect.TypeOf((*MockTranslationDataSourceI)(nil).PutTranslation), arg0)
+}
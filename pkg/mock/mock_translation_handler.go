@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/handler (interfaces: TranslationHandlerI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTranslationHandlerI is a mock of TranslationHandlerI interface.
+type MockTranslationHandlerI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationHandlerIMockRecorder
+}
+
+// MockTranslationHandlerIMockRecorder is the mock recorder for MockTranslationHandlerI.
+type MockTranslationHandlerIMockRecorder struct {
+	mock *MockTranslationHandlerI
+}
+
+// NewMockTranslationHandlerI creates a new mock instance.
+func NewMockTranslationHandlerI(ctrl *gomock.Controller) *MockTranslationHandlerI {
+	mock := &MockTranslationHandlerI{ctrl: ctrl}
+	mock.recorder = &MockTranslationHandlerIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationHandlerI) EXPECT() *MockTranslationHandlerIMockRecorder {
+	return m.recorder
+}
+
+// DeleteTranslation mocks base method.
+func (m *MockTranslationHandlerI) DeleteTranslation(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteTranslation", arg0, arg1)
+}
+
+// DeleteTranslation indicates an expected call of DeleteTranslation.
+func (mr *MockTranslationHandlerIMockRecorder) DeleteTranslation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTranslation", reflect.TypeOf((*MockTranslationHandlerI)(nil).DeleteTranslation), arg0, arg1)
+}
+
+// PutTranslation mocks base method.
+func (m *MockTranslationHandlerI) PutTranslation(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PutTranslation", arg0, arg1)
+}
+
+// PutTranslation indicates an expected call of PutTranslation.
+func (mr *MockTranslationHandlerIMockRecorder) PutTranslation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTranslation", reflect.TypeOf((*MockTranslationHandlerI)(nil).PutTranslation), arg0, arg1)
+}
@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/logic (interfaces: TranslationLogicI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockTranslationLogicI is a mock of TranslationLogicI interface.
+type MockTranslationLogicI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationLogicIMockRecorder
+}
+
+// MockTranslationLogicIMockRecorder is the mock recorder for MockTranslationLogicI.
+type MockTranslationLogicIMockRecorder struct {
+	mock *MockTranslationLogicI
+}
+
+// NewMockTranslationLogicI creates a new mock instance.
+func NewMockTranslationLogicI(ctrl *gomock.Controller) *MockTranslationLogicI {
+	mock := &MockTranslationLogicI{ctrl: ctrl}
+	mock.recorder = &MockTranslationLogicIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationLogicI) EXPECT() *MockTranslationLogicIMockRecorder {
+	return m.recorder
+}
+
+// DeleteTranslation mocks base method.
+func (m *MockTranslationLogicI) DeleteTranslation(arg0, arg1 string) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTranslation", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// DeleteTranslation indicates an expected call of DeleteTranslation.
+func (mr *MockTranslationLogicIMockRecorder) DeleteTranslation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTranslation", reflect.TypeOf((*MockTranslationLogicI)(nil).DeleteTranslation), arg0, arg1)
+}
+
+// PutTranslation mocks base method.
+func (m *MockTranslationLogicI) PutTranslation(arg0, arg1 string, arg2 *model.TranslationRequest) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutTranslation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// PutTranslation indicates an expected call of PutTranslation.
+func (mr *MockTranslationLogicIMockRecorder) PutTranslation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTranslation", reflect.TypeOf((*MockTranslationLogicI)(nil).PutTranslation), arg0, arg1, arg2)
+}
@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/repo/datasource (interfaces: ViewDataSourceI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockViewDataSourceI is a mock of ViewDataSourceI interface.
+type MockViewDataSourceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewDataSourceIMockRecorder
+}
+
+// MockViewDataSourceIMockRecorder is the mock recorder for MockViewDataSourceI.
+type MockViewDataSourceIMockRecorder struct {
+	mock *MockViewDataSourceI
+}
+
+// NewMockViewDataSourceI creates a new mock instance.
+func NewMockViewDataSourceI(ctrl *gomock.Controller) *MockViewDataSourceI {
+	mock := &MockViewDataSourceI{ctrl: ctrl}
+	mock.recorder = &MockViewDataSourceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockViewDataSourceI) EXPECT() *MockViewDataSourceIMockRecorder {
+	return m.recorder
+}
+
+// AddViews mocks base method.
+func (m *MockViewDataSourceI) AddViews(arg0 map[string]int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddViews", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddViews indicates an expected call of AddViews.
+func (mr *MockViewDataSourceIMockRecorder) AddViews(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddViews", reflect.TypeOf((*MockViewDataSourceI)(nil).AddViews), arg0)
+}
@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/handler (interfaces: ViewHandlerI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockViewHandlerI is a mock of ViewHandlerI interface.
+type MockViewHandlerI struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewHandlerIMockRecorder
+}
+
+// MockViewHandlerIMockRecorder is the mock recorder for MockViewHandlerI.
+type MockViewHandlerIMockRecorder struct {
+	mock *MockViewHandlerI
+}
+
+// NewMockViewHandlerI creates a new mock instance.
+func NewMockViewHandlerI(ctrl *gomock.Controller) *MockViewHandlerI {
+	mock := &MockViewHandlerI{ctrl: ctrl}
+	mock.recorder = &MockViewHandlerIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockViewHandlerI) EXPECT() *MockViewHandlerIMockRecorder {
+	return m.recorder
+}
+
+// GetPopularArticles mocks base method.
+func (m *MockViewHandlerI) GetPopularArticles(arg0 http.ResponseWriter, arg1 *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetPopularArticles", arg0, arg1)
+}
+
+// GetPopularArticles indicates an expected call of GetPopularArticles.
+func (mr *MockViewHandlerIMockRecorder) GetPopularArticles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularArticles", reflect.TypeOf((*MockViewHandlerI)(nil).GetPopularArticles), arg0, arg1)
+}
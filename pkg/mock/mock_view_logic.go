@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/logic (interfaces: ViewLogicI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/vatsal-chaturvedi/article-management-sys/internal/model"
+)
+
+// MockViewLogicI is a mock of ViewLogicI interface.
+type MockViewLogicI struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewLogicIMockRecorder
+}
+
+// MockViewLogicIMockRecorder is the mock recorder for MockViewLogicI.
+type MockViewLogicIMockRecorder struct {
+	mock *MockViewLogicI
+}
+
+// NewMockViewLogicI creates a new mock instance.
+func NewMockViewLogicI(ctrl *gomock.Controller) *MockViewLogicI {
+	mock := &MockViewLogicI{ctrl: ctrl}
+	mock.recorder = &MockViewLogicIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockViewLogicI) EXPECT() *MockViewLogicIMockRecorder {
+	return m.recorder
+}
+
+// GetPopularArticles mocks base method.
+func (m *MockViewLogicI) GetPopularArticles(arg0 string, arg1 int) *model.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPopularArticles", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	return ret0
+}
+
+// GetPopularArticles indicates an expected call of GetPopularArticles.
+func (mr *MockViewLogicIMockRecorder) GetPopularArticles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularArticles", reflect.TypeOf((*MockViewLogicI)(nil).GetPopularArticles), arg0, arg1)
+}
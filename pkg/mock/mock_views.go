@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vatsal-chaturvedi/article-management-sys/internal/views (interfaces: CounterI)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	views "github.com/vatsal-chaturvedi/article-management-sys/internal/views"
+)
+
+// MockCounterI is a mock of CounterI interface.
+type MockCounterI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCounterIMockRecorder
+}
+
+// MockCounterIMockRecorder is the mock recorder for MockCounterI.
+type MockCounterIMockRecorder struct {
+	mock *MockCounterI
+}
+
+// NewMockCounterI creates a new mock instance.
+func NewMockCounterI(ctrl *gomock.Controller) *MockCounterI {
+	mock := &MockCounterI{ctrl: ctrl}
+	mock.recorder = &MockCounterIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCounterI) EXPECT() *MockCounterIMockRecorder {
+	return m.recorder
+}
+
+// Popular mocks base method.
+func (m *MockCounterI) Popular(arg0 time.Duration, arg1 int, arg2 time.Time) ([]views.Score, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Popular", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]views.Score)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Popular indicates an expected call of Popular.
+func (mr *MockCounterIMockRecorder) Popular(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Popular", reflect.TypeOf((*MockCounterI)(nil).Popular), arg0, arg1, arg2)
+}
+
+// Record mocks base method.
+func (m *MockCounterI) Record(arg0, arg1 string, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockCounterIMockRecorder) Record(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockCounterI)(nil).Record), arg0, arg1, arg2)
+}
+
+// Restore mocks base method.
+func (m *MockCounterI) Restore(arg0 map[string]int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockCounterIMockRecorder) Restore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockCounterI)(nil).Restore), arg0)
+}
+
+// Take mocks base method.
+func (m *MockCounterI) Take() (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take")
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockCounterIMockRecorder) Take() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockCounterI)(nil).Take))
+}